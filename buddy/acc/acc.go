@@ -1,20 +1,73 @@
 package acc
 
 import (
+	"crypto/tls"
 	"github.com/buddy/api-go-sdk/buddy"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"net/http"
 	"os"
 	"terraform-provider-buddy/buddy/provider"
+	"terraform-provider-buddy/buddy/util"
 	"testing"
 )
 
+// TestPrefix starts unique names of acceptance tests objects so leaked ones can be swept
+const TestPrefix = "tfacc"
+
 var ProviderFactories map[string]func() (tfprotov6.ProviderServer, error)
 var ApiClient *buddy.Client
 
+// UseFakeServer runs acceptance tests against in-memory fake of the Buddy API (BUDDY_ACC_FAKE=true)
+var UseFakeServer = os.Getenv("BUDDY_ACC_FAKE") == "true"
+var Fake *FakeServer
+
+// Mode records or replays API calls of acceptance tests (BUDDY_ACC_MODE=record|replay|passthrough)
+var Mode = os.Getenv("BUDDY_ACC_MODE")
+var Cassette *CassetteTransport
+
 func init() {
+	if UseFakeServer {
+		Fake = NewFakeServer()
+		_ = os.Setenv("BUDDY_BASE_URL", Fake.URL)
+		_ = os.Setenv("BUDDY_TOKEN", "fake")
+		_ = os.Setenv("BUDDY_INSECURE", "false")
+	}
+	if Mode == ModeRecord || Mode == ModeReplay {
+		initCassette()
+		return
+	}
 	ApiClient, _ = buddy.NewClient(os.Getenv("BUDDY_TOKEN"), os.Getenv("BUDDY_BASE_URL"), os.Getenv("BUDDY_INSECURE") == "true")
+	initProviderFactories()
+}
+
+func initCassette() {
+	if Mode == ModeReplay {
+		if os.Getenv("BUDDY_BASE_URL") == "" {
+			_ = os.Setenv("BUDDY_BASE_URL", util.DefaultBaseUrl)
+		}
+		if os.Getenv("BUDDY_TOKEN") == "" {
+			_ = os.Setenv("BUDDY_TOKEN", "replay")
+		}
+	}
+	base := http.DefaultTransport.(*http.Transport).Clone()
+	base.TLSClientConfig = &tls.Config{
+		InsecureSkipVerify: os.Getenv("BUDDY_INSECURE") == "true",
+	}
+	Cassette = NewCassetteTransport(Mode, base, os.Getenv("BUDDY_BASE_URL"))
+	util.SetRandSource(cassetteRand)
+	ApiClient, _ = buddy.NewClientWithHttpClient(os.Getenv("BUDDY_TOKEN"), os.Getenv("BUDDY_BASE_URL"), &http.Client{
+		Transport: Cassette,
+	})
+	ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+		"buddy": providerserver.NewProtocol6WithError(provider.NewWithTransport("test", func(_ http.RoundTripper) http.RoundTripper {
+			return Cassette
+		})()),
+	}
+}
+
+func initProviderFactories() {
 	ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
 		"buddy": providerserver.NewProtocol6WithError(provider.New("test")()),
 	}
@@ -27,6 +80,9 @@ func PreCheck(t *testing.T) {
 	if baseUrl := os.Getenv("BUDDY_BASE_URL"); baseUrl == "" {
 		t.Fatal("BUDDY_BASE_URL must be set for acceptance tests")
 	}
+	if Cassette != nil {
+		Cassette.Start(t)
+	}
 }
 
 func DummyCheckDestroy(_ *terraform.State) error {
@@ -0,0 +1,299 @@
+package acc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"math/rand"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+	"terraform-provider-buddy/buddy/util"
+	"testing"
+)
+
+const (
+	ModePassthrough = "passthrough"
+	ModeRecord      = "record"
+	ModeReplay      = "replay"
+
+	CassetteDir = "testdata/cassettes"
+
+	cassetteBaseUrl  = "{{base_url}}"
+	cassetteRedacted = "***"
+)
+
+// env variables which values are scrubbed from recorded fixtures
+var cassetteSecretEnvs = []string{
+	"BUDDY_TOKEN",
+	"BUDDY_GH_TOKEN",
+}
+
+var cassetteTestRegexp = regexp.MustCompile(`/test\.(Test[^./]+)`)
+
+type cassetteInteraction struct {
+	Method          string            `json:"method"`
+	Url             string            `json:"url"`
+	RequestBody     string            `json:"request_body,omitempty"`
+	Status          int               `json:"status"`
+	ResponseHeaders map[string]string `json:"response_headers,omitempty"`
+	ResponseBody    string            `json:"response_body,omitempty"`
+	replayed        bool
+}
+
+type cassette struct {
+	name         string
+	Interactions []*cassetteInteraction `json:"interactions"`
+}
+
+type CassetteTransport struct {
+	mu       sync.Mutex
+	mode     string
+	next     http.RoundTripper
+	baseUrl  string
+	secrets  []string
+	cassette *cassette
+}
+
+// NewCassetteTransport records API calls of the acceptance tests to fixtures or replays them (BUDDY_ACC_MODE=record|replay|passthrough)
+func NewCassetteTransport(mode string, next http.RoundTripper, baseUrl string) *CassetteTransport {
+	t := &CassetteTransport{
+		mode:    mode,
+		next:    next,
+		baseUrl: strings.TrimSuffix(baseUrl, "/"),
+	}
+	for _, env := range cassetteSecretEnvs {
+		if v := os.Getenv(env); v != "" {
+			t.secrets = append(t.secrets, v)
+		}
+	}
+	return t
+}
+
+func cassettePath(name string) string {
+	return filepath.Join(CassetteDir, strings.ReplaceAll(name, "/", "_")+".json")
+}
+
+// Start loads the cassette of the test and saves it after the test in record mode
+func (t *CassetteTransport) Start(tt *testing.T) {
+	c := &cassette{
+		name: tt.Name(),
+	}
+	switch t.mode {
+	case ModeRecord:
+		tt.Cleanup(func() {
+			if tt.Failed() {
+				return
+			}
+			if err := t.save(c); err != nil {
+				tt.Errorf("failed to save cassette %s: %s", c.name, err)
+			}
+		})
+	case ModeReplay:
+		b, err := os.ReadFile(cassettePath(c.name))
+		if err != nil {
+			tt.Fatalf("failed to load cassette %s: %s", c.name, err)
+		}
+		if err := json.Unmarshal(b, c); err != nil {
+			tt.Fatalf("failed to parse cassette %s: %s", c.name, err)
+		}
+	default:
+		return
+	}
+	t.mu.Lock()
+	t.cassette = c
+	t.mu.Unlock()
+}
+
+func (t *CassetteTransport) save(c *cassette) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	b, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(CassetteDir, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(cassettePath(c.name), b, 0644)
+}
+
+func (t *CassetteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch t.mode {
+	case ModeRecord:
+		return t.record(req)
+	case ModeReplay:
+		return t.replay(req)
+	}
+	return t.next.RoundTrip(req)
+}
+
+func (t *CassetteTransport) record(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		reqBody, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.cassette != nil {
+		headers := map[string]string{}
+		for _, h := range []string{"Content-Type", "Retry-After", "X-Request-Id"} {
+			if v := resp.Header.Get(h); v != "" {
+				headers[h] = v
+			}
+		}
+		t.cassette.Interactions = append(t.cassette.Interactions, &cassetteInteraction{
+			Method:          req.Method,
+			Url:             req.URL.RequestURI(),
+			RequestBody:     t.scrub(reqBody),
+			Status:          resp.StatusCode,
+			ResponseHeaders: headers,
+			ResponseBody:    t.scrub(respBody),
+		})
+	}
+	return resp, nil
+}
+
+// replay returns first not replayed interaction with the same method and url
+func (t *CassetteTransport) replay(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.cassette == nil {
+		return nil, fmt.Errorf("no cassette loaded to replay %s %s", req.Method, req.URL.RequestURI())
+	}
+	for _, i := range t.cassette.Interactions {
+		if i.replayed || i.Method != req.Method || i.Url != req.URL.RequestURI() {
+			continue
+		}
+		i.replayed = true
+		header := http.Header{}
+		for k, v := range i.ResponseHeaders {
+			header.Set(k, v)
+		}
+		body := strings.ReplaceAll(i.ResponseBody, cassetteBaseUrl, t.baseUrl)
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", i.Status, http.StatusText(i.Status)),
+			StatusCode:    i.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(strings.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("no recorded interaction in cassette %s for %s %s", t.cassette.name, req.Method, req.URL.RequestURI())
+}
+
+func (t *CassetteTransport) scrub(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	var v interface{}
+	d := json.NewDecoder(bytes.NewReader(body))
+	d.UseNumber()
+	if err := d.Decode(&v); err == nil {
+		if b, err := json.Marshal(cassetteScrubValue(v, "")); err == nil {
+			body = b
+		}
+	}
+	s := string(body)
+	if t.baseUrl != "" {
+		s = strings.ReplaceAll(s, t.baseUrl, cassetteBaseUrl)
+	}
+	for _, secret := range t.secrets {
+		s = strings.ReplaceAll(s, secret, cassetteRedacted)
+	}
+	return s
+}
+
+// cassetteScrubValue scrubs the same fields which are redacted in the provider's logs
+func cassetteScrubValue(v interface{}, parent string) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, field := range val {
+			if util.IsSensitiveField(parent, strings.ToLower(k)) {
+				if s, ok := field.(string); ok && s != "" {
+					val[k] = cassetteRedacted
+					continue
+				}
+			}
+			val[k] = cassetteScrubValue(field, strings.ToLower(k))
+		}
+	case []interface{}:
+		for i, item := range val {
+			val[i] = cassetteScrubValue(item, parent)
+		}
+	}
+	return v
+}
+
+var (
+	randMu      sync.Mutex
+	randSources = map[string]*rand.Rand{}
+)
+
+// cassetteRand returns random values generator seeded per test function, so replayed requests match recorded ones.
+// Values generated outside of tests (e.g. by the provider) use the default generator
+func cassetteRand() *rand.Rand {
+	key, seed := cassetteRandSeed()
+	if key == "" {
+		return nil
+	}
+	randMu.Lock()
+	defer randMu.Unlock()
+	r, ok := randSources[key]
+	if !ok {
+		r = rand.New(rand.NewSource(seed))
+		randSources[key] = r
+	}
+	return r
+}
+
+// UniqueString returns unique name prefixed with TestPrefix
+func UniqueString() string {
+	return TestPrefix + util.UniqueString()
+}
+
+func cassetteRandSeed() (string, int64) {
+	pc := make([]uintptr, 64)
+	n := runtime.Callers(3, pc)
+	frames := runtime.CallersFrames(pc[:n])
+	for {
+		frame, more := frames.Next()
+		if m := cassetteTestRegexp.FindStringSubmatch(frame.Function); m != nil {
+			h := fnv.New64a()
+			_, _ = h.Write([]byte(m[1]))
+			return m[1], int64(h.Sum64() >> 1)
+		}
+		if !more {
+			return "", 0
+		}
+	}
+}
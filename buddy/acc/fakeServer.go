@@ -0,0 +1,625 @@
+package acc
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type fakeCollection struct {
+	idField string
+	intId   bool
+	listKey string
+}
+
+// collections of the Buddy REST API served by the fake server
+var fakeCollections = map[string]fakeCollection{
+	"workspaces":   {idField: "domain", listKey: "workspaces"},
+	"projects":     {idField: "name", listKey: "projects"},
+	"pipelines":    {idField: "id", intId: true, listKey: "pipelines"},
+	"actions":      {idField: "id", intId: true, listKey: "actions"},
+	"executions":   {idField: "id", intId: true, listKey: "executions"},
+	"variables":    {idField: "id", intId: true, listKey: "variables"},
+	"targets":      {idField: "id", listKey: "targets"},
+	"environments": {idField: "id", listKey: "environments"},
+	"sandboxes":    {idField: "id", listKey: "sandboxes"},
+	"integrations": {idField: "hash_id", listKey: "integrations"},
+	"members":      {idField: "id", intId: true, listKey: "members"},
+	"groups":       {idField: "id", intId: true, listKey: "groups"},
+	"permissions":  {idField: "id", intId: true, listKey: "permission_sets"},
+	"webhooks":     {idField: "id", intId: true, listKey: "webhooks"},
+	"domains":      {idField: "id", listKey: "domains"},
+	"records":      {idField: "name", listKey: "records"},
+	"emails":       {idField: "email", listKey: "emails"},
+	"keys":         {idField: "id", intId: true, listKey: "keys"},
+}
+
+// status of the item after its action
+var fakeItemActions = map[string]string{
+	"start":   "RUNNING",
+	"restart": "RUNNING",
+	"stop":    "STOPPED",
+}
+
+// list query params which are not filters
+var fakeQueryParams = map[string]bool{
+	"page":           true,
+	"per_page":       true,
+	"sort_by":        true,
+	"sort_direction": true,
+}
+
+// fields of the item which are not returned in the list
+var fakeListOmit = map[string][]string{
+	"executions": {"action_executions"},
+}
+
+// scopes of the item, list filtered by a scope doesn't return items of the other scopes
+var fakeScopes = []string{"pipeline", "action", "environment", "sandbox"}
+
+var fakeSlugRegexp = regexp.MustCompile(`[^a-z0-9]+`)
+
+var fakeCamelRegexp = regexp.MustCompile(`([a-z0-9])([A-Z])`)
+
+type fakeList struct {
+	order []string
+	items map[string]map[string]interface{}
+}
+
+type FakeServer struct {
+	*httptest.Server
+	mu      sync.Mutex
+	lists   map[string]*fakeList
+	profile map[string]interface{}
+	nextId  int
+}
+
+func NewFakeServer() *FakeServer {
+	s := &FakeServer{
+		lists:  map[string]*fakeList{},
+		nextId: 1,
+	}
+	s.profile = map[string]interface{}{
+		"id":         0,
+		"name":       "Terraform",
+		"avatar_url": "",
+		"title":      "",
+	}
+	s.Server = httptest.NewServer(s)
+	return s
+}
+
+func (s *FakeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := "/" + strings.Trim(r.URL.Path, "/")
+	segments := strings.Split(strings.Trim(p, "/"), "/")
+	var body map[string]interface{}
+	if r.Body != nil {
+		d := json.NewDecoder(r.Body)
+		d.UseNumber()
+		_ = d.Decode(&body)
+	}
+	if body == nil {
+		body = map[string]interface{}{}
+	}
+	if segments[0] == "user" && len(segments) == 1 {
+		s.serveProfile(w, r, body)
+		return
+	}
+	last := segments[len(segments)-1]
+	if last == "yaml" && len(segments) >= 2 && segments[len(segments)-2] == "pipelines" {
+		s.servePipelinesYaml(w, r, strings.TrimSuffix(p, "/yaml"), body)
+		return
+	}
+	if _, ok := fakeCollections[last]; ok {
+		s.serveCollection(w, r, p, last, body)
+		return
+	}
+	if len(segments) >= 2 {
+		if _, ok := fakeCollections[segments[len(segments)-2]]; ok {
+			s.serveItem(w, r, p, body)
+			return
+		}
+	}
+	if last == "yaml" && len(segments) >= 3 && segments[len(segments)-3] == "pipelines" {
+		s.servePipelineYaml(w, r, strings.TrimSuffix(p, "/yaml"), body)
+		return
+	}
+	// item's action e.g. start, stop
+	if len(segments) >= 3 {
+		parent := "/" + strings.Join(segments[:len(segments)-1], "/")
+		if item := s.getItem(parent); item != nil {
+			s.serveItemAction(w, r, last, item)
+			return
+		}
+	}
+	fakeNotFound(w)
+}
+
+// serveItemAction changes the item's status, request body is not a part of the item
+func (s *FakeServer) serveItemAction(w http.ResponseWriter, r *http.Request, action string, item map[string]interface{}) {
+	if r.Method != http.MethodPost {
+		fakeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	status, ok := fakeItemActions[action]
+	if !ok {
+		fakeNotFound(w)
+		return
+	}
+	item["status"] = status
+	fakeWrite(w, http.StatusOK, item)
+}
+
+func (s *FakeServer) serveProfile(w http.ResponseWriter, r *http.Request, body map[string]interface{}) {
+	if r.Method == http.MethodPatch {
+		for k, v := range body {
+			s.profile[k] = v
+		}
+	}
+	fakeWrite(w, http.StatusOK, s.profile)
+}
+
+func (s *FakeServer) serveCollection(w http.ResponseWriter, r *http.Request, p string, name string, body map[string]interface{}) {
+	col := fakeCollections[name]
+	parent := strings.TrimSuffix(p, "/"+name)
+	if parent != "" && parent != "/user" && s.getItem(parent) == nil {
+		fakeNotFound(w)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		items := []interface{}{}
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		filters := fakeFilters(r.URL.Query())
+		if l, ok := s.lists[p]; ok && page <= 1 {
+			for _, id := range l.order {
+				if fakeMatch(l.items[id], filters) {
+					items = append(items, fakeListItem(name, l.items[id]))
+				}
+			}
+		}
+		fakeWrite(w, http.StatusOK, map[string]interface{}{
+			"url":       s.URL + p,
+			"html_url":  s.URL + p,
+			col.listKey: items,
+		})
+	case http.MethodPost:
+		item := body
+		if err := s.createItem(p, name, item); err != nil {
+			fakeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		s.placeAfter(p, item)
+		fakeWrite(w, http.StatusCreated, item)
+	default:
+		fakeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// createItem adds the item to the collection under p and sets fields computed by the Buddy API
+func (s *FakeServer) createItem(p string, name string, item map[string]interface{}) error {
+	col := fakeCollections[name]
+	id := s.itemId(col, item)
+	if id == "" {
+		return fmt.Errorf("%s is required", col.idField)
+	}
+	l, ok := s.lists[p]
+	if !ok {
+		l = &fakeList{
+			items: map[string]map[string]interface{}{},
+		}
+		s.lists[p] = l
+	}
+	if _, exists := l.items[id]; exists {
+		return fmt.Errorf("%s %s already exists", col.idField, id)
+	}
+	if itemName, ok := item["name"].(string); ok && item["identifier"] == nil {
+		item["identifier"] = fakeSlug(itemName)
+	}
+	item["url"] = s.URL + p + "/" + id
+	item["html_url"] = s.URL + p + "/" + id
+	if item["create_date"] == nil {
+		item["create_date"] = time.Now().UTC().Format(time.RFC3339)
+	}
+	s.defaults(p, name, item)
+	l.order = append(l.order, id)
+	l.items[id] = item
+	return nil
+}
+
+// placeAfter moves the item right after the one from after_action_id, the field is not returned by the API
+func (s *FakeServer) placeAfter(p string, item map[string]interface{}) {
+	after, ok := item["after_action_id"]
+	delete(item, "after_action_id")
+	l := s.lists[p]
+	if !ok || l == nil {
+		return
+	}
+	id := fmt.Sprint(item["id"])
+	order := slices.DeleteFunc(slices.Clone(l.order), func(o string) bool {
+		return o == id
+	})
+	i := slices.Index(order, fmt.Sprint(after))
+	if i < 0 {
+		return
+	}
+	l.order = slices.Insert(order, i+1, id)
+}
+
+func (s *FakeServer) serveItem(w http.ResponseWriter, r *http.Request, p string, body map[string]interface{}) {
+	item := s.getItem(p)
+	if item == nil {
+		fakeNotFound(w)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		fakeWrite(w, http.StatusOK, item)
+	case http.MethodPatch, http.MethodPut:
+		for k, v := range body {
+			item[k] = v
+		}
+		s.placeAfter(p[:strings.LastIndex(p, "/")], item)
+		fakeWrite(w, http.StatusOK, item)
+	case http.MethodDelete:
+		s.deleteItem(p)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		fakeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// servePipelinesYaml creates pipeline with its actions from base64 encoded YAML
+func (s *FakeServer) servePipelinesYaml(w http.ResponseWriter, r *http.Request, p string, body map[string]interface{}) {
+	if s.getItem(strings.TrimSuffix(p, "/pipelines")) == nil {
+		fakeNotFound(w)
+		return
+	}
+	if r.Method != http.MethodPost {
+		fakeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	definition, err := fakePipelineDefinition(body)
+	if err != nil {
+		fakeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	pipeline := map[string]interface{}{}
+	if err = s.createItem(p, "pipelines", pipeline); err != nil {
+		fakeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err = s.loadPipelineDefinition(p+"/"+fmt.Sprint(pipeline["id"]), pipeline, definition); err != nil {
+		fakeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	fakeWrite(w, http.StatusCreated, pipeline)
+}
+
+// servePipelineYaml returns pipeline with its actions as base64 encoded YAML (JSON is valid YAML) or replaces them
+func (s *FakeServer) servePipelineYaml(w http.ResponseWriter, r *http.Request, p string, body map[string]interface{}) {
+	pipeline := s.getItem(p)
+	if pipeline == nil {
+		fakeNotFound(w)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPatch, http.MethodPut:
+		definition, err := fakePipelineDefinition(body)
+		if err != nil {
+			fakeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if err = s.loadPipelineDefinition(p, pipeline, definition); err != nil {
+			fakeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		fakeWrite(w, http.StatusOK, pipeline)
+		return
+	default:
+		fakeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	actions := []interface{}{}
+	if l, ok := s.lists[p+"/actions"]; ok {
+		for _, id := range l.order {
+			a := l.items[id]
+			action := map[string]interface{}{
+				"action": a["name"],
+				"type":   a["type"],
+			}
+			for _, k := range []string{"docker_image_name", "docker_image_tag", "execute_commands", "shell"} {
+				if a[k] != nil {
+					action[k] = a[k]
+				}
+			}
+			actions = append(actions, action)
+		}
+	}
+	definition := map[string]interface{}{
+		"pipeline": pipeline["name"],
+		"actions":  actions,
+	}
+	for _, k := range []string{"refs", "priority"} {
+		if pipeline[k] != nil {
+			definition[k] = pipeline[k]
+		}
+	}
+	b, _ := json.Marshal([]interface{}{definition})
+	fakeWrite(w, http.StatusOK, map[string]interface{}{
+		"url":  s.URL + p + "/yaml",
+		"yaml": base64.StdEncoding.EncodeToString(b),
+	})
+}
+
+// loadPipelineDefinition sets pipeline fields from the YAML definition and replaces its actions
+func (s *FakeServer) loadPipelineDefinition(p string, pipeline map[string]interface{}, definition map[string]interface{}) error {
+	name, ok := definition["pipeline"].(string)
+	if !ok || name == "" {
+		return fmt.Errorf("pipeline name is required")
+	}
+	pipeline["name"] = name
+	pipeline["identifier"] = fakeSlug(name)
+	for _, k := range []string{"refs", "priority"} {
+		pipeline[k] = definition[k]
+	}
+	delete(s.lists, p+"/actions")
+	actions, _ := definition["actions"].([]interface{})
+	for _, a := range actions {
+		def, ok := a.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("invalid action")
+		}
+		action := map[string]interface{}{
+			"name": def["action"],
+			"type": def["type"],
+		}
+		for _, k := range []string{"docker_image_name", "docker_image_tag", "execute_commands", "shell"} {
+			if def[k] != nil {
+				action[k] = def[k]
+			}
+		}
+		if err := s.createItem(p+"/actions", "actions", action); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fakePipelineDefinition decodes the only pipeline from the request's base64 encoded YAML
+func fakePipelineDefinition(body map[string]interface{}) (map[string]interface{}, error) {
+	encoded, _ := body["yaml"].(string)
+	b, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+	var v interface{}
+	if err = yaml.Unmarshal(b, &v); err != nil {
+		return nil, err
+	}
+	if l, ok := v.([]interface{}); ok && len(l) == 1 {
+		v = l[0]
+	}
+	definition, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("yaml must define exactly one pipeline")
+	}
+	return definition, nil
+}
+
+func (s *FakeServer) itemId(col fakeCollection, item map[string]interface{}) string {
+	if v, ok := item[col.idField]; ok && v != nil && fmt.Sprint(v) != "" {
+		return fmt.Sprint(v)
+	}
+	switch col.idField {
+	case "name":
+		if dn, ok := item["display_name"].(string); ok {
+			item["name"] = fakeSlug(dn)
+			return item["name"].(string)
+		}
+		return ""
+	case "domain", "email":
+		return ""
+	}
+	id := s.nextId
+	s.nextId += 1
+	if col.intId {
+		item[col.idField] = id
+		return strconv.Itoa(id)
+	}
+	sid := fmt.Sprintf("fake%d", id)
+	item[col.idField] = sid
+	return sid
+}
+
+// defaults sets fields which are computed by the Buddy API
+func (s *FakeServer) defaults(p string, name string, item map[string]interface{}) {
+	set := func(k string, v interface{}) {
+		if item[k] == nil {
+			item[k] = v
+		}
+	}
+	switch name {
+	case "projects":
+		set("status", "ACTIVE")
+		set("access", "PRIVATE")
+	case "pipelines":
+		set("last_execution_status", "INITIAL")
+		set("disabled", false)
+	case "executions":
+		// runs finish immediately
+		now := time.Now().UTC().Format(time.RFC3339)
+		set("status", "SUCCESSFUL")
+		set("start_date", now)
+		set("finish_date", now)
+		set("action_executions", s.actionExecutions(strings.TrimSuffix(p, "/executions")+"/actions", now))
+	case "sandboxes":
+		set("status", "RUNNING")
+		set("setup_status", "SUCCESS")
+		set("app_status", "RUNNING")
+	case "workspaces":
+		set("name", item["domain"])
+		set("frozen", false)
+	case "members":
+		set("admin", false)
+		set("workspace_owner", false)
+		set("status", "ACTIVE")
+	}
+}
+
+// actionExecutions returns successful runs of the pipeline's actions
+func (s *FakeServer) actionExecutions(p string, date string) []interface{} {
+	executions := []interface{}{}
+	l, ok := s.lists[p]
+	if !ok {
+		return executions
+	}
+	for _, id := range l.order {
+		executions = append(executions, map[string]interface{}{
+			"status":      "SUCCESSFUL",
+			"start_date":  date,
+			"finish_date": date,
+			"action": map[string]interface{}{
+				"id":   l.items[id]["id"],
+				"name": l.items[id]["name"],
+			},
+		})
+	}
+	return executions
+}
+
+// fakeListItem returns copy of the item without fields which are not returned in the list
+func fakeListItem(name string, item map[string]interface{}) map[string]interface{} {
+	omit, ok := fakeListOmit[name]
+	if !ok {
+		return item
+	}
+	c := map[string]interface{}{}
+	for k, v := range item {
+		if !slices.Contains(omit, k) {
+			c[k] = v
+		}
+	}
+	return c
+}
+
+func (s *FakeServer) getItem(p string) map[string]interface{} {
+	i := strings.LastIndex(p, "/")
+	if i <= 0 {
+		return nil
+	}
+	l, ok := s.lists[p[:i]]
+	if !ok {
+		return nil
+	}
+	return l.items[p[i+1:]]
+}
+
+func (s *FakeServer) deleteItem(p string) {
+	i := strings.LastIndex(p, "/")
+	l := s.lists[p[:i]]
+	id := p[i+1:]
+	delete(l.items, id)
+	for j, o := range l.order {
+		if o == id {
+			l.order = append(l.order[:j], l.order[j+1:]...)
+			break
+		}
+	}
+	// remove nested collections
+	for k := range s.lists {
+		if strings.HasPrefix(k, p+"/") {
+			delete(s.lists, k)
+		}
+	}
+}
+
+// fakeFilters returns list filters from the query with snake case keys e.g. projectName=a -> project_name=a
+func fakeFilters(query url.Values) map[string]string {
+	filters := map[string]string{}
+	for k := range query {
+		key := strings.ToLower(fakeCamelRegexp.ReplaceAllString(k, "${1}_${2}"))
+		if fakeQueryParams[key] || query.Get(k) == "" {
+			continue
+		}
+		filters[key] = query.Get(k)
+	}
+	return filters
+}
+
+// fakeMatch checks the item's field (e.g. type) or the field of the item's scope (e.g. project_name -> project.name)
+func fakeMatch(item map[string]interface{}, filters map[string]string) bool {
+	scoped := false
+	for k, want := range filters {
+		if v, ok := item[k]; ok && v != nil {
+			if _, isMap := v.(map[string]interface{}); !isMap {
+				if fmt.Sprint(v) != want {
+					return false
+				}
+				continue
+			}
+		}
+		scope, field, found := strings.Cut(k, "_")
+		if !found {
+			// filter not supported by the fake
+			continue
+		}
+		parent, ok := item[scope].(map[string]interface{})
+		if !ok || fmt.Sprint(parent[field]) != want {
+			return false
+		}
+		scoped = true
+	}
+	if !scoped {
+		return true
+	}
+	for _, scope := range fakeScopes {
+		if _, filtered := filters[scope+"_id"]; filtered {
+			continue
+		}
+		if v, ok := item[scope]; ok && v != nil {
+			return false
+		}
+	}
+	return true
+}
+
+func fakeSlug(s string) string {
+	return strings.Trim(fakeSlugRegexp.ReplaceAllString(strings.ToLower(s), "-"), "-")
+}
+
+func fakeWrite(w http.ResponseWriter, status int, v interface{}) {
+	var buf bytes.Buffer
+	_ = json.NewEncoder(&buf).Encode(v)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(buf.Bytes())
+}
+
+func fakeError(w http.ResponseWriter, status int, message string) {
+	fakeWrite(w, status, map[string]interface{}{
+		"errors": []map[string]string{
+			{
+				"message": message,
+			},
+		},
+	})
+}
+
+func fakeNotFound(w http.ResponseWriter) {
+	fakeError(w, http.StatusNotFound, "Not Found")
+}
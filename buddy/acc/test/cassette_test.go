@@ -0,0 +1,216 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"terraform-provider-buddy/buddy/acc"
+	"terraform-provider-buddy/buddy/util"
+	"testing"
+)
+
+const (
+	testCassetteToken     = "cassette-env-token"
+	testCassetteBaseUrl   = "https://api.buddy.test"
+	testCassetteRedacted  = "***"
+	testCassetteVarValue  = "secret-variable-value"
+	testCassetteSshKey    = "secret-ssh-key"
+	testCassettePassword  = "secret-auth-password"
+	testCassetteCertValue = "secret-certificate"
+)
+
+var testCassetteSecrets = []string{
+	testCassetteToken,
+	testCassetteVarValue,
+	testCassetteSshKey,
+	testCassettePassword,
+	testCassetteCertValue,
+}
+
+func testCassetteDo(t *testing.T, client *http.Client, baseUrl string, method string, path string, body interface{}) (int, map[string]interface{}) {
+	var buf bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&buf).Encode(body); err != nil {
+			t.Fatal(err)
+		}
+	}
+	req, err := http.NewRequest(method, baseUrl+path, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	result := map[string]interface{}{}
+	_ = json.NewDecoder(resp.Body).Decode(&result)
+	return resp.StatusCode, result
+}
+
+// testCassetteInteractions creates workspace, variable and target with secrets
+func testCassetteInteractions(t *testing.T, client *http.Client, baseUrl string) map[string]map[string]interface{} {
+	results := map[string]map[string]interface{}{}
+	_, results["workspace"] = testCassetteDo(t, client, baseUrl, http.MethodPost, "/workspaces", map[string]interface{}{
+		"domain": "ws",
+		"name":   testCassetteToken,
+	})
+	_, results["variable"] = testCassetteDo(t, client, baseUrl, http.MethodPost, "/workspaces/ws/variables", map[string]interface{}{
+		"key":       "SECRET",
+		"value":     testCassetteVarValue,
+		"encrypted": true,
+	})
+	_, results["target"] = testCassetteDo(t, client, baseUrl, http.MethodPost, "/workspaces/ws/targets", map[string]interface{}{
+		"name":        "target",
+		"certificate": testCassetteCertValue,
+		"auth": map[string]interface{}{
+			"method":   "SSH_KEY",
+			"username": "user",
+			"key":      testCassetteSshKey,
+			"password": testCassettePassword,
+		},
+	})
+	_, results["variables"] = testCassetteDo(t, client, baseUrl, http.MethodGet, "/workspaces/ws/variables", nil)
+	return results
+}
+
+func testCassetteCheckScrubbed(t *testing.T, file string) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range testCassetteSecrets {
+		if strings.Contains(string(b), secret) {
+			t.Fatalf("secret %s found in cassette %s", secret, file)
+		}
+	}
+	var c struct {
+		Interactions []struct {
+			RequestBody  string `json:"request_body"`
+			ResponseBody string `json:"response_body"`
+		} `json:"interactions"`
+	}
+	if err := json.Unmarshal(b, &c); err != nil {
+		t.Fatalf("failed to parse cassette %s: %s", file, err)
+	}
+	for _, i := range c.Interactions {
+		for _, body := range []string{i.RequestBody, i.ResponseBody} {
+			var v interface{}
+			if body == "" || json.Unmarshal([]byte(body), &v) != nil {
+				continue
+			}
+			if field := testCassetteSensitiveField(v, ""); field != "" {
+				t.Fatalf("field %s is not scrubbed in cassette %s", field, file)
+			}
+		}
+	}
+}
+
+func testCassetteSensitiveField(v interface{}, parent string) string {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, field := range val {
+			if s, ok := field.(string); ok && s != "" && s != testCassetteRedacted && util.IsSensitiveField(parent, strings.ToLower(k)) {
+				return k
+			}
+			if f := testCassetteSensitiveField(field, strings.ToLower(k)); f != "" {
+				return f
+			}
+		}
+	case []interface{}:
+		for _, item := range val {
+			if f := testCassetteSensitiveField(item, parent); f != "" {
+				return f
+			}
+		}
+	}
+	return ""
+}
+
+func TestCassetteRecord(t *testing.T) {
+	t.Setenv("BUDDY_TOKEN", testCassetteToken)
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = os.Chdir(wd)
+	})
+	fake := acc.NewFakeServer()
+	defer fake.Close()
+	transport := acc.NewCassetteTransport(acc.ModeRecord, http.DefaultTransport, fake.URL)
+	t.Run("recorded", func(tt *testing.T) {
+		transport.Start(tt)
+		results := testCassetteInteractions(tt, &http.Client{Transport: transport}, fake.URL)
+		// recording doesn't change responses
+		if err := util.CheckFieldEqual("value", fmt.Sprint(results["variable"]["value"]), testCassetteVarValue); err != nil {
+			tt.Fatal(err)
+		}
+	})
+	file := filepath.Join(acc.CassetteDir, "TestCassetteRecord_recorded.json")
+	testCassetteCheckScrubbed(t, file)
+	b, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(b), fake.URL) {
+		t.Fatalf("base url found in cassette: %s", string(b))
+	}
+}
+
+func TestCassetteReplay(t *testing.T) {
+	transport := acc.NewCassetteTransport(acc.ModeReplay, nil, testCassetteBaseUrl)
+	transport.Start(t)
+	client := &http.Client{Transport: transport}
+	results := testCassetteInteractions(t, client, testCassetteBaseUrl)
+	if err := util.CheckFieldEqual("domain", fmt.Sprint(results["workspace"]["domain"]), "ws"); err != nil {
+		t.Fatal(err)
+	}
+	if err := util.CheckFieldEqual("name", fmt.Sprint(results["workspace"]["name"]), testCassetteRedacted); err != nil {
+		t.Fatal(err)
+	}
+	if err := util.CheckFieldEqual("value", fmt.Sprint(results["variable"]["value"]), testCassetteRedacted); err != nil {
+		t.Fatal(err)
+	}
+	if err := util.CheckFieldEqual("url", fmt.Sprint(results["variable"]["url"]), testCassetteBaseUrl+"/workspaces/ws/variables/1"); err != nil {
+		t.Fatal(err)
+	}
+	auth, _ := results["target"]["auth"].(map[string]interface{})
+	if err := util.CheckFieldEqual("auth.key", fmt.Sprint(auth["key"]), testCassetteRedacted); err != nil {
+		t.Fatal(err)
+	}
+	if err := util.CheckFieldEqual("auth.username", fmt.Sprint(auth["username"]), "user"); err != nil {
+		t.Fatal(err)
+	}
+	variables, _ := results["variables"]["variables"].([]interface{})
+	if err := util.CheckIntFieldEqual("variables", len(variables), 1); err != nil {
+		t.Fatal(err)
+	}
+	// every interaction is replayed once
+	req, _ := http.NewRequest(http.MethodGet, testCassetteBaseUrl+"/workspaces/ws/variables", nil)
+	if resp, err := transport.RoundTrip(req); err == nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		t.Fatal("expected error for not recorded interaction")
+	}
+}
+
+func TestCassettesScrubbed(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("..", "..", "*", "test", acc.CassetteDir, "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no committed cassettes found")
+	}
+	for _, file := range files {
+		testCassetteCheckScrubbed(t, file)
+	}
+}
@@ -0,0 +1,164 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"terraform-provider-buddy/buddy/acc"
+	"terraform-provider-buddy/buddy/util"
+	"testing"
+)
+
+func testFakeDo(t *testing.T, s *acc.FakeServer, method string, path string, body interface{}) (int, map[string]interface{}) {
+	var buf bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&buf).Encode(body); err != nil {
+			t.Fatal(err)
+		}
+	}
+	req, err := http.NewRequest(method, s.URL+path, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := s.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	result := map[string]interface{}{}
+	_ = json.NewDecoder(resp.Body).Decode(&result)
+	return resp.StatusCode, result
+}
+
+func testFakeListIds(t *testing.T, s *acc.FakeServer, path string, listKey string) []string {
+	status, result := testFakeDo(t, s, http.MethodGet, path, nil)
+	if err := util.CheckIntFieldEqual("status", status, http.StatusOK); err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	items, _ := result[listKey].([]interface{})
+	for _, item := range items {
+		ids = append(ids, fmt.Sprint(item.(map[string]interface{})["key"]))
+	}
+	return ids
+}
+
+func testFakeCheckIds(t *testing.T, got []string, want ...string) {
+	if err := util.CheckFieldEqual("ids", fmt.Sprint(got), fmt.Sprint(want)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFakeServerVariablesFilters(t *testing.T) {
+	s := acc.NewFakeServer()
+	defer s.Close()
+	testFakeDo(t, s, http.MethodPost, "/workspaces", map[string]interface{}{"domain": "ws"})
+	testFakeDo(t, s, http.MethodPost, "/workspaces/ws/projects", map[string]interface{}{"display_name": "proj"})
+	testFakeDo(t, s, http.MethodPost, "/workspaces/ws/projects", map[string]interface{}{"display_name": "other"})
+	testFakeDo(t, s, http.MethodPost, "/workspaces/ws/variables", map[string]interface{}{
+		"key": "WORKSPACE",
+	})
+	testFakeDo(t, s, http.MethodPost, "/workspaces/ws/variables", map[string]interface{}{
+		"key":     "PROJECT",
+		"project": map[string]interface{}{"name": "proj"},
+	})
+	testFakeDo(t, s, http.MethodPost, "/workspaces/ws/variables", map[string]interface{}{
+		"key":     "OTHER",
+		"project": map[string]interface{}{"name": "other"},
+	})
+	testFakeDo(t, s, http.MethodPost, "/workspaces/ws/variables", map[string]interface{}{
+		"key":      "PIPELINE",
+		"project":  map[string]interface{}{"name": "proj"},
+		"pipeline": map[string]interface{}{"id": 10},
+	})
+	testFakeDo(t, s, http.MethodPost, "/workspaces/ws/variables", map[string]interface{}{
+		"key":      "ACTION",
+		"project":  map[string]interface{}{"name": "proj"},
+		"pipeline": map[string]interface{}{"id": 10},
+		"action":   map[string]interface{}{"id": 20},
+	})
+	testFakeCheckIds(t, testFakeListIds(t, s, "/workspaces/ws/variables", "variables"), "WORKSPACE", "PROJECT", "OTHER", "PIPELINE", "ACTION")
+	testFakeCheckIds(t, testFakeListIds(t, s, "/workspaces/ws/variables?projectName=proj", "variables"), "PROJECT")
+	testFakeCheckIds(t, testFakeListIds(t, s, "/workspaces/ws/variables?project_name=other", "variables"), "OTHER")
+	testFakeCheckIds(t, testFakeListIds(t, s, "/workspaces/ws/variables?projectName=proj&pipelineId=10", "variables"), "PIPELINE")
+	testFakeCheckIds(t, testFakeListIds(t, s, "/workspaces/ws/variables?projectName=proj&pipelineId=10&actionId=20", "variables"), "ACTION")
+	testFakeCheckIds(t, testFakeListIds(t, s, "/workspaces/ws/variables?projectName=proj&pipelineId=11", "variables"))
+	testFakeCheckIds(t, testFakeListIds(t, s, "/workspaces/ws/variables?key=OTHER", "variables"), "OTHER")
+	testFakeCheckIds(t, testFakeListIds(t, s, "/workspaces/ws/variables?page=2", "variables"))
+}
+
+func TestFakeServerItemAction(t *testing.T) {
+	s := acc.NewFakeServer()
+	defer s.Close()
+	testFakeDo(t, s, http.MethodPost, "/workspaces", map[string]interface{}{"domain": "ws"})
+	_, sandbox := testFakeDo(t, s, http.MethodPost, "/workspaces/ws/sandboxes", map[string]interface{}{"name": "sb"})
+	p := fmt.Sprintf("/workspaces/ws/sandboxes/%s", sandbox["id"])
+	status, item := testFakeDo(t, s, http.MethodPost, p+"/stop", map[string]interface{}{"name": "changed"})
+	if err := util.CheckIntFieldEqual("status", status, http.StatusOK); err != nil {
+		t.Fatal(err)
+	}
+	if err := util.CheckFieldEqual("status", fmt.Sprint(item["status"]), "STOPPED"); err != nil {
+		t.Fatal(err)
+	}
+	_, item = testFakeDo(t, s, http.MethodGet, p, nil)
+	if err := util.CheckFieldEqual("name", fmt.Sprint(item["name"]), "sb"); err != nil {
+		t.Fatal(err)
+	}
+	if err := util.CheckFieldEqual("status", fmt.Sprint(item["status"]), "STOPPED"); err != nil {
+		t.Fatal(err)
+	}
+	_, item = testFakeDo(t, s, http.MethodPost, p+"/start", nil)
+	if err := util.CheckFieldEqual("status", fmt.Sprint(item["status"]), "RUNNING"); err != nil {
+		t.Fatal(err)
+	}
+	status, _ = testFakeDo(t, s, http.MethodPost, p+"/unknown", nil)
+	if err := util.CheckIntFieldEqual("status", status, http.StatusNotFound); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFakeServerActionOrder(t *testing.T) {
+	s := acc.NewFakeServer()
+	defer s.Close()
+	testFakeDo(t, s, http.MethodPost, "/workspaces", map[string]interface{}{"domain": "ws"})
+	testFakeDo(t, s, http.MethodPost, "/workspaces/ws/projects", map[string]interface{}{"display_name": "proj"})
+	_, pipeline := testFakeDo(t, s, http.MethodPost, "/workspaces/ws/projects/proj/pipelines", map[string]interface{}{"name": "p"})
+	actions := fmt.Sprintf("/workspaces/ws/projects/proj/pipelines/%v/actions", pipeline["id"])
+	_, first := testFakeDo(t, s, http.MethodPost, actions, map[string]interface{}{"name": "first", "key": "first"})
+	testFakeDo(t, s, http.MethodPost, actions, map[string]interface{}{"name": "last", "key": "last"})
+	_, middle := testFakeDo(t, s, http.MethodPost, actions, map[string]interface{}{"name": "middle", "key": "middle", "after_action_id": first["id"]})
+	if _, ok := middle["after_action_id"]; ok {
+		t.Fatal("after_action_id should not be returned")
+	}
+	testFakeCheckIds(t, testFakeListIds(t, s, actions, "actions"), "first", "middle", "last")
+}
+
+func TestFakeServerExecutionActions(t *testing.T) {
+	s := acc.NewFakeServer()
+	defer s.Close()
+	testFakeDo(t, s, http.MethodPost, "/workspaces", map[string]interface{}{"domain": "ws"})
+	testFakeDo(t, s, http.MethodPost, "/workspaces/ws/projects", map[string]interface{}{"display_name": "proj"})
+	_, pipeline := testFakeDo(t, s, http.MethodPost, "/workspaces/ws/projects/proj/pipelines", map[string]interface{}{"name": "p"})
+	p := fmt.Sprintf("/workspaces/ws/projects/proj/pipelines/%v", pipeline["id"])
+	testFakeDo(t, s, http.MethodPost, p+"/actions", map[string]interface{}{"name": "ls"})
+	_, execution := testFakeDo(t, s, http.MethodPost, p+"/executions", nil)
+	_, execution = testFakeDo(t, s, http.MethodGet, fmt.Sprintf("%s/executions/%v", p, execution["id"]), nil)
+	actions, _ := execution["action_executions"].([]interface{})
+	if err := util.CheckIntFieldEqual("action_executions", len(actions), 1); err != nil {
+		t.Fatal(err)
+	}
+	action, _ := actions[0].(map[string]interface{})["action"].(map[string]interface{})
+	if err := util.CheckFieldEqual("action.name", fmt.Sprint(action["name"]), "ls"); err != nil {
+		t.Fatal(err)
+	}
+	// list of runs doesn't return actions' statuses
+	_, list := testFakeDo(t, s, http.MethodGet, p+"/executions", nil)
+	executions, _ := list["executions"].([]interface{})
+	if err := util.CheckIntFieldEqual("executions", len(executions), 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := executions[0].(map[string]interface{})["action_executions"]; ok {
+		t.Fatal("action_executions should not be returned in the list")
+	}
+}
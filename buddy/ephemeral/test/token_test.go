@@ -0,0 +1,75 @@
+package test
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"terraform-provider-buddy/buddy/acc"
+	"terraform-provider-buddy/buddy/util"
+	"testing"
+)
+
+func TestAccEphemeralToken(t *testing.T) {
+	name := acc.UniqueString()
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acc.PreCheck(t)
+		},
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"buddy": acc.ProviderFactories["buddy"],
+			"echo":  echoprovider.NewProviderServer(),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccEphemeralTokenConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("echo.test", "data.name", name),
+					resource.TestCheckResourceAttr("echo.test", "data.scopes.#", "3"),
+					resource.TestCheckResourceAttrSet("echo.test", "data.token"),
+					resource.TestCheckResourceAttrSet("echo.test", "data.token_id"),
+					resource.TestCheckResourceAttrSet("echo.test", "data.expires_at"),
+					testAccEphemeralTokenRevoked("echo.test"),
+				),
+			},
+		},
+	})
+}
+
+// testAccEphemeralTokenRevoked checks that the token was revoked when the ephemeral resource was closed
+func testAccEphemeralTokenRevoked(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+		_, resp, err := acc.ApiClient.TokenService.Get(rs.Primary.Attributes["data.token_id"])
+		if err == nil {
+			return util.ErrorResourceExists()
+		}
+		if !util.IsResourceNotFound(resp, err) {
+			return err
+		}
+		return nil
+	}
+}
+
+func testAccEphemeralTokenConfig(name string) string {
+	return fmt.Sprintf(`
+ephemeral "buddy_token" "foo" {
+    name = "%s"
+    scopes = ["WORKSPACE", "EXECUTION_INFO", "EXECUTION_RUN"]
+}
+
+provider "echo" {
+    data = ephemeral.buddy_token.foo
+}
+
+resource "echo" "test" {}
+`, name)
+}
@@ -0,0 +1,195 @@
+package ephemeral
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/buddy/api-go-sdk/buddy"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"strings"
+	"terraform-provider-buddy/buddy/util"
+)
+
+const (
+	tokenPrivateKey = "token_id"
+)
+
+var (
+	_ ephemeral.EphemeralResource              = &tokenEphemeral{}
+	_ ephemeral.EphemeralResourceWithConfigure = &tokenEphemeral{}
+	_ ephemeral.EphemeralResourceWithClose     = &tokenEphemeral{}
+)
+
+func NewTokenEphemeral() ephemeral.EphemeralResource {
+	return &tokenEphemeral{}
+}
+
+type tokenEphemeral struct {
+	client *buddy.Client
+}
+
+type tokenEphemeralModel struct {
+	Name                  types.String `tfsdk:"name"`
+	Scopes                types.Set    `tfsdk:"scopes"`
+	ExpiresIn             types.Int64  `tfsdk:"expires_in"`
+	IpRestrictions        types.Set    `tfsdk:"ip_restrictions"`
+	WorkspaceRestrictions types.Set    `tfsdk:"workspace_restrictions"`
+	Token                 types.String `tfsdk:"token"`
+	TokenId               types.String `tfsdk:"token_id"`
+	ExpiresAt             types.String `tfsdk:"expires_at"`
+}
+
+type tokenEphemeralPrivate struct {
+	TokenId string `json:"token_id"`
+}
+
+func (e *tokenEphemeralModel) loadAPI(token *buddy.Token) {
+	e.Token = types.StringValue(token.Token)
+	e.TokenId = types.StringValue(token.Id)
+	e.ExpiresAt = types.StringValue(token.ExpiresAt)
+}
+
+func (e *tokenEphemeral) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_token"
+}
+
+func (e *tokenEphemeral) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Create a short-lived personal access token. The token is revoked when Terraform no longer needs it\n\n" +
+			"Requires Terraform 1.10 or later",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The token's name",
+				Required:            true,
+			},
+			"scopes": schema.SetAttribute{
+				MarkdownDescription: "The token's scopes. Allowed: `" + strings.Join(util.TokenScopes, "`, `") + "`",
+				Required:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.OneOf(util.TokenScopes...)),
+				},
+			},
+			"expires_in": schema.Int64Attribute{
+				MarkdownDescription: "The token's expiration in days. Default: `1`",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"ip_restrictions": schema.SetAttribute{
+				MarkdownDescription: "The list of IP addresses or CIDRs from which the token can be used",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"workspace_restrictions": schema.SetAttribute{
+				MarkdownDescription: "The list of workspaces' URL handles in which the token can be used",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"token": schema.StringAttribute{
+				MarkdownDescription: "The token's value",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"token_id": schema.StringAttribute{
+				MarkdownDescription: "The token's ID",
+				Computed:            true,
+			},
+			"expires_at": schema.StringAttribute{
+				MarkdownDescription: "The token's expiration date",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (e *tokenEphemeral) Configure(_ context.Context, req ephemeral.ConfigureRequest, _ *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	e.client = req.ProviderData.(*util.ProviderData).Client
+}
+
+func (e *tokenEphemeral) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data *tokenEphemeralModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	expiresIn := 1
+	if !data.ExpiresIn.IsNull() && !data.ExpiresIn.IsUnknown() {
+		expiresIn = int(data.ExpiresIn.ValueInt64())
+	}
+	scopes, d := util.StringSetToApi(ctx, &data.Scopes)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ops := buddy.TokenOps{
+		Name:      data.Name.ValueStringPointer(),
+		ExpiresIn: &expiresIn,
+		Scopes:    scopes,
+	}
+	if !data.IpRestrictions.IsNull() && !data.IpRestrictions.IsUnknown() {
+		ips, d := util.StringSetToApi(ctx, &data.IpRestrictions)
+		resp.Diagnostics.Append(d...)
+		ops.IpRestrictions = ips
+	}
+	if !data.WorkspaceRestrictions.IsNull() && !data.WorkspaceRestrictions.IsUnknown() {
+		domains, d := util.StringSetToApi(ctx, &data.WorkspaceRestrictions)
+		resp.Diagnostics.Append(d...)
+		ops.WorkspaceRestrictions = domains
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Creating token", map[string]interface{}{
+		"name": data.Name.ValueString(),
+	})
+	token, _, err := e.client.TokenService.Create(&ops)
+	if err != nil {
+		resp.Diagnostics.Append(util.NewDiagnosticApiError("create token", err))
+		return
+	}
+	data.loadAPI(token)
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	private, err := json.Marshal(tokenEphemeralPrivate{
+		TokenId: token.Id,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to save token ID", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, tokenPrivateKey, private)...)
+}
+
+func (e *tokenEphemeral) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	b, d := req.Private.GetKey(ctx, tokenPrivateKey)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() || b == nil {
+		return
+	}
+	var private tokenEphemeralPrivate
+	if err := json.Unmarshal(b, &private); err != nil {
+		resp.Diagnostics.AddError("Failed to read token ID", err.Error())
+		return
+	}
+	tflog.Debug(ctx, "Revoking token", map[string]interface{}{
+		"token_id": private.TokenId,
+	})
+	httpResp, err := e.client.TokenService.Delete(private.TokenId)
+	if err != nil && !util.IsResourceNotFound(httpResp, err) {
+		resp.Diagnostics.Append(util.NewDiagnosticApiError("revoke token", err))
+	}
+}
@@ -0,0 +1,154 @@
+package function
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"sort"
+	"strconv"
+	"strings"
+	"terraform-provider-buddy/buddy/util"
+)
+
+var (
+	_ function.Function = &parseIdFunction{}
+)
+
+type idPart struct {
+	name     string
+	numeric  bool
+	optional bool
+}
+
+// parts of the resources' IDs in order in which they are composed
+var idKinds = map[string][]idPart{
+	"domain":           {{name: "workspace_domain"}, {name: "domain_id"}},
+	"domain_record":    {{name: "workspace_domain"}, {name: "domain_id"}, {name: "domain"}, {name: "type"}},
+	"environment":      {{name: "domain"}, {name: "project_name", optional: true}, {name: "environment_id"}},
+	"group":            {{name: "domain"}, {name: "group_id", numeric: true}},
+	"group_member":     {{name: "domain"}, {name: "group_id", numeric: true}, {name: "member_id", numeric: true}},
+	"integration":      {{name: "domain"}, {name: "integration_id"}},
+	"member":           {{name: "domain"}, {name: "member_id", numeric: true}},
+	"permission":       {{name: "domain"}, {name: "permission_id", numeric: true}},
+	"pipeline":         {{name: "domain"}, {name: "project_name"}, {name: "pipeline_id", numeric: true}},
+	"pipeline_action":  {{name: "domain"}, {name: "project_name"}, {name: "pipeline_id", numeric: true}, {name: "action_id", numeric: true}},
+	"project":          {{name: "domain"}, {name: "project_name"}},
+	"project_group":    {{name: "domain"}, {name: "project_name"}, {name: "group_id", numeric: true}},
+	"project_member":   {{name: "domain"}, {name: "project_name"}, {name: "member_id", numeric: true}},
+	"sandbox":          {{name: "domain"}, {name: "sandbox_id"}},
+	"target":           {{name: "domain"}, {name: "target_id"}},
+	"variable":         {{name: "domain"}, {name: "variable_id", numeric: true}},
+	"variable_ssh_key": {{name: "domain"}, {name: "variable_id", numeric: true}},
+	"webhook":          {{name: "domain"}, {name: "webhook_id", numeric: true}},
+}
+
+func idKindNames() []string {
+	var kinds []string
+	for k := range idKinds {
+		kinds = append(kinds, k)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+func NewParseIdFunction() function.Function {
+	return &parseIdFunction{}
+}
+
+type parseIdFunction struct{}
+
+func (f *parseIdFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_id"
+}
+
+func (f *parseIdFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parse the resource's ID",
+		MarkdownDescription: "Parse the resource's ID into an object with named parts, e.g. `provider::buddy::parse_id(\"pipeline\", buddy_pipeline.p.id).project_name`\n\n" +
+			"Requires Terraform 1.8 or later",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "kind",
+				MarkdownDescription: "The resource's kind (resource's type without the `buddy_` prefix). Allowed: `" + strings.Join(idKindNames(), "`, `") + "`",
+				Validators: []function.StringParameterValidator{
+					stringvalidator.OneOf(idKindNames()...),
+				},
+			},
+			function.StringParameter{
+				Name:                "id",
+				MarkdownDescription: "The resource's ID",
+			},
+		},
+		Return: function.DynamicReturn{},
+	}
+}
+
+func (f *parseIdFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var kind string
+	var id string
+	resp.Error = req.Arguments.Get(ctx, &kind, &id)
+	if resp.Error != nil {
+		return
+	}
+	parts, ok := idKinds[kind]
+	if !ok {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Unknown kind %q", kind))
+		return
+	}
+	values, err := decomposeId(id, len(parts))
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("Invalid %s ID: %s", kind, err))
+		return
+	}
+	attrTypes := map[string]attr.Type{}
+	attrValues := map[string]attr.Value{}
+	for i, part := range parts {
+		v := values[i]
+		if v == "" && !part.optional {
+			resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("Invalid %s ID %q: %s is empty", kind, id, part.name))
+			return
+		}
+		if v != "" && part.numeric {
+			if _, err := strconv.Atoi(v); err != nil {
+				resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("Invalid %s ID %q: %s must be a number", kind, id, part.name))
+				return
+			}
+		}
+		attrTypes[part.name] = types.StringType
+		attrValues[part.name] = types.StringValue(v)
+	}
+	obj, d := types.ObjectValue(attrTypes, attrValues)
+	resp.Error = function.FuncErrorFromDiags(ctx, d)
+	if resp.Error != nil {
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, types.DynamicValue(obj))
+}
+
+func decomposeId(id string, count int) ([]string, error) {
+	switch count {
+	case 2:
+		a, b, err := util.DecomposeDoubleId(id)
+		return []string{a, b}, err
+	case 3:
+		a, b, c, err := util.DecomposeTripleId(id)
+		return []string{a, b, c}, err
+	case 4:
+		a, b, c, d, err := util.DecomposeQuadrupleId(id)
+		return []string{a, b, c, d}, err
+	}
+	return nil, fmt.Errorf("unsupported id format %q", id)
+}
+
+func validateIdPart(idx int64, name string, value string) *function.FuncError {
+	if value == "" {
+		return function.NewArgumentFuncError(idx, fmt.Sprintf("%s must not be empty", name))
+	}
+	if strings.Contains(value, ":") {
+		return function.NewArgumentFuncError(idx, fmt.Sprintf("%s must not contain \":\"", name))
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package function
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"strconv"
+	"terraform-provider-buddy/buddy/util"
+)
+
+var (
+	_ function.Function = &pipelineActionIdFunction{}
+)
+
+func NewPipelineActionIdFunction() function.Function {
+	return &pipelineActionIdFunction{}
+}
+
+type pipelineActionIdFunction struct{}
+
+func (f *pipelineActionIdFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "pipeline_action_id"
+}
+
+func (f *pipelineActionIdFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Compose the pipeline action's ID",
+		MarkdownDescription: "Compose the `buddy_pipeline_action` resource's ID (`domain:project_name:pipeline_id:action_id`), e.g. for `import` blocks\n\n" +
+			"Requires Terraform 1.8 or later",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "domain",
+				MarkdownDescription: "The workspace's URL handle",
+			},
+			function.StringParameter{
+				Name:                "project_name",
+				MarkdownDescription: "The project's name",
+			},
+			function.Int64Parameter{
+				Name:                "pipeline_id",
+				MarkdownDescription: "The pipeline's ID",
+			},
+			function.Int64Parameter{
+				Name:                "action_id",
+				MarkdownDescription: "The action's ID",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *pipelineActionIdFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var domain string
+	var projectName string
+	var pipelineId int64
+	var actionId int64
+	resp.Error = req.Arguments.Get(ctx, &domain, &projectName, &pipelineId, &actionId)
+	if resp.Error != nil {
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(
+		validateIdPart(0, "domain", domain),
+		validateIdPart(1, "project_name", projectName),
+	)
+	if resp.Error != nil {
+		return
+	}
+	id := util.ComposeQuadrupleId(domain, projectName, strconv.FormatInt(pipelineId, 10), strconv.FormatInt(actionId, 10))
+	resp.Error = resp.Result.Set(ctx, id)
+}
@@ -0,0 +1,64 @@
+package function
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"strconv"
+	"terraform-provider-buddy/buddy/util"
+)
+
+var (
+	_ function.Function = &pipelineIdFunction{}
+)
+
+func NewPipelineIdFunction() function.Function {
+	return &pipelineIdFunction{}
+}
+
+type pipelineIdFunction struct{}
+
+func (f *pipelineIdFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "pipeline_id"
+}
+
+func (f *pipelineIdFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Compose the pipeline's ID",
+		MarkdownDescription: "Compose the `buddy_pipeline` resource's ID (`domain:project_name:pipeline_id`), e.g. for `import` blocks\n\n" +
+			"Requires Terraform 1.8 or later",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "domain",
+				MarkdownDescription: "The workspace's URL handle",
+			},
+			function.StringParameter{
+				Name:                "project_name",
+				MarkdownDescription: "The project's name",
+			},
+			function.Int64Parameter{
+				Name:                "pipeline_id",
+				MarkdownDescription: "The pipeline's ID",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *pipelineIdFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var domain string
+	var projectName string
+	var pipelineId int64
+	resp.Error = req.Arguments.Get(ctx, &domain, &projectName, &pipelineId)
+	if resp.Error != nil {
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(
+		validateIdPart(0, "domain", domain),
+		validateIdPart(1, "project_name", projectName),
+	)
+	if resp.Error != nil {
+		return
+	}
+	id := util.ComposeTripleId(domain, projectName, strconv.FormatInt(pipelineId, 10))
+	resp.Error = resp.Result.Set(ctx, id)
+}
@@ -0,0 +1,80 @@
+package test
+
+import (
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"regexp"
+	"terraform-provider-buddy/buddy/acc"
+	"testing"
+)
+
+func TestFunctionParseId(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: acc.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+locals {
+	pipeline = provider::buddy::parse_id("pipeline", "ws:project:123")
+	action = provider::buddy::parse_id("pipeline_action", "ws:project:123:456")
+	environment = provider::buddy::parse_id("environment", "ws::abc")
+}
+
+output "domain" {
+	value = local.pipeline.domain
+}
+
+output "project_name" {
+	value = local.pipeline.project_name
+}
+
+output "pipeline_id" {
+	value = local.pipeline.pipeline_id
+}
+
+output "action_id" {
+	value = local.action.action_id
+}
+
+output "environment_id" {
+	value = local.environment.environment_id
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("domain", "ws"),
+					resource.TestCheckOutput("project_name", "project"),
+					resource.TestCheckOutput("pipeline_id", "123"),
+					resource.TestCheckOutput("action_id", "456"),
+					resource.TestCheckOutput("environment_id", "abc"),
+				),
+			},
+			{
+				Config: `
+output "test" {
+	value = provider::buddy::parse_id("pipeline", "ws:project")
+}
+`,
+				ExpectError: regexp.MustCompile(`wrong id format`),
+			},
+			{
+				Config: `
+output "test" {
+	value = provider::buddy::parse_id("pipeline", "ws:project:abc")
+}
+`,
+				ExpectError: regexp.MustCompile(`pipeline_id must be a number`),
+			},
+			{
+				Config: `
+output "test" {
+	value = provider::buddy::parse_id("unknown", "ws:project")
+}
+`,
+				ExpectError: regexp.MustCompile(`value must be one of`),
+			},
+		},
+	})
+}
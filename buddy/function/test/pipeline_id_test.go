@@ -0,0 +1,67 @@
+package test
+
+import (
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"regexp"
+	"terraform-provider-buddy/buddy/acc"
+	"testing"
+)
+
+func TestFunctionPipelineId(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: acc.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "test" {
+	value = provider::buddy::pipeline_id("ws", "project", 123)
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("test", "ws:project:123"),
+				),
+			},
+			{
+				Config: `
+output "test" {
+	value = provider::buddy::pipeline_id("", "project", 123)
+}
+`,
+				ExpectError: regexp.MustCompile(`domain must not be empty`),
+			},
+		},
+	})
+}
+
+func TestFunctionPipelineActionId(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: acc.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "test" {
+	value = provider::buddy::pipeline_action_id("ws", "project", 123, 456)
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("test", "ws:project:123:456"),
+				),
+			},
+			{
+				Config: `
+output "test" {
+	value = provider::buddy::pipeline_action_id("ws", "a:b", 123, 456)
+}
+`,
+				ExpectError: regexp.MustCompile(`project_name must not contain ":"`),
+			},
+		},
+	})
+}
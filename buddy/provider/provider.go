@@ -4,30 +4,62 @@ import (
 	"context"
 	"fmt"
 	"github.com/buddy/api-go-sdk/buddy"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"net/http"
 	"os"
 	"strconv"
+	buddyephemeral "terraform-provider-buddy/buddy/ephemeral"
+	buddyfunction "terraform-provider-buddy/buddy/function"
 	buddyresource "terraform-provider-buddy/buddy/resource"
 	buddysource "terraform-provider-buddy/buddy/source"
+	"terraform-provider-buddy/buddy/util"
 	"time"
 )
 
-var _ provider.Provider = &BuddyProvider{}
+var (
+	_ provider.Provider                       = &BuddyProvider{}
+	_ provider.ProviderWithEphemeralResources = &BuddyProvider{}
+	_ provider.ProviderWithFunctions          = &BuddyProvider{}
+)
 
 type BuddyProvider struct {
-	version string
+	version       string
+	wrapTransport func(http.RoundTripper) http.RoundTripper
 }
 
 type BuddyProviderModel struct {
-	Token    types.String `tfsdk:"token"`
-	BaseUrl  types.String `tfsdk:"base_url"`
-	Insecure types.Bool   `tfsdk:"insecure"`
-	Timeout  types.Int64  `tfsdk:"timeout"`
+	Token                 types.String  `tfsdk:"token"`
+	TokenFile             types.String  `tfsdk:"token_file"`
+	TokenCommand          types.String  `tfsdk:"token_command"`
+	OidcToken             types.String  `tfsdk:"oidc_token"`
+	Domain                types.String  `tfsdk:"domain"`
+	DefaultTags           types.Set     `tfsdk:"default_tags"`
+	BaseUrl               types.String  `tfsdk:"base_url"`
+	Insecure              types.Bool    `tfsdk:"insecure"`
+	CaCertFile            types.String  `tfsdk:"ca_cert_file"`
+	CaCertPem             types.String  `tfsdk:"ca_cert_pem"`
+	ClientCert            types.String  `tfsdk:"client_cert"`
+	ClientKey             types.String  `tfsdk:"client_key"`
+	ProxyUrl              types.String  `tfsdk:"proxy_url"`
+	NoProxy               types.String  `tfsdk:"no_proxy"`
+	ProxyUsername         types.String  `tfsdk:"proxy_username"`
+	ProxyPassword         types.String  `tfsdk:"proxy_password"`
+	Timeout               types.Int64   `tfsdk:"timeout"`
+	MaxRetries            types.Int64   `tfsdk:"max_retries"`
+	RetryMaxWait          types.Int64   `tfsdk:"retry_max_wait"`
+	RequestsPerSecond     types.Float64 `tfsdk:"requests_per_second"`
+	MaxConcurrentRequests types.Int64   `tfsdk:"max_concurrent_requests"`
 }
 
 func (p *BuddyProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -42,6 +74,41 @@ func (p *BuddyProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp
 				MarkdownDescription: "The OAuth2 token or Personal Access Token. Can be specified with the `BUDDY_TOKEN` environment variable.",
 				Sensitive:           true,
 				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("token_file"), path.MatchRoot("token_command"), path.MatchRoot("oidc_token")),
+				},
+			},
+			"token_file": schema.StringAttribute{
+				MarkdownDescription: "Path to the file with the OAuth2 token or Personal Access Token. The file is read each time the provider is configured. Can be specified with the `BUDDY_TOKEN_FILE` environment variable.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("token"), path.MatchRoot("token_command"), path.MatchRoot("oidc_token")),
+				},
+			},
+			"token_command": schema.StringAttribute{
+				MarkdownDescription: "The command (credential helper) which prints the token to the standard output. It's run in the shell each time the provider is configured. Can be specified with the `BUDDY_TOKEN_COMMAND` environment variable.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("token"), path.MatchRoot("token_file"), path.MatchRoot("oidc_token")),
+				},
+			},
+			"oidc_token": schema.StringAttribute{
+				MarkdownDescription: "The OIDC JWT issued by the CI (e.g. GitHub Actions, GitLab CI) which is exchanged for a short-lived Buddy token at the `/oauth2/token` endpoint ([RFC 8693](https://www.rfc-editor.org/rfc/rfc8693) token exchange). Can be specified with the `BUDDY_OIDC_TOKEN` environment variable.",
+				Sensitive:           true,
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("token"), path.MatchRoot("token_file"), path.MatchRoot("token_command")),
+				},
+			},
+			"domain": schema.StringAttribute{
+				MarkdownDescription: "The default workspace's URL handle used by resources and data sources which don't set it. Changing it forces replacement of such resources. Can be specified with the `BUDDY_DOMAIN` environment variable.",
+				Optional:            true,
+				Validators:          util.StringValidatorsDomain(),
+			},
+			"default_tags": schema.SetAttribute{
+				MarkdownDescription: "The list of tags added to every taggable resource (`buddy_pipeline`, `buddy_target`, `buddy_environment`, `buddy_sandbox`). Resources expose the merged tags in `tags_all` attribute",
+				ElementType:         types.StringType,
+				Optional:            true,
 			},
 			"base_url": schema.StringAttribute{
 				MarkdownDescription: "The Buddy API base url. You may need to set this to your Buddy On-Premises API endpoint. Can be specified with the `BUDDY_BASE_URL` environment variable. Default: `https://api.buddy.works`",
@@ -51,10 +118,84 @@ func (p *BuddyProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp
 				MarkdownDescription: "Disable SSL verification of API calls. You may need to set this to `true` if you are using Buddy On-Premises without signed certificate. Can be specified with the `BUDDY_INSECURE` environmental variable",
 				Optional:            true,
 			},
+			"ca_cert_file": schema.StringAttribute{
+				MarkdownDescription: "Path to the PEM encoded CA certificate used to verify the Buddy API certificate. You may need to set this if you are using Buddy On-Premises with certificate signed by internal CA. Can be specified with the `BUDDY_CA_CERT` environmental variable (path or PEM content)",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("ca_cert_pem")),
+				},
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				MarkdownDescription: "The PEM encoded CA certificate used to verify the Buddy API certificate. Can be specified with the `BUDDY_CA_CERT` environmental variable (path or PEM content)",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("ca_cert_file")),
+				},
+			},
+			"client_cert": schema.StringAttribute{
+				MarkdownDescription: "The PEM encoded client certificate or path to it for mutual TLS authentication. Requires `client_key`. Can be specified with the `BUDDY_CLIENT_CERT` environmental variable (path or PEM content)",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_key")),
+				},
+			},
+			"client_key": schema.StringAttribute{
+				MarkdownDescription: "The PEM encoded client private key or path to it for mutual TLS authentication. Requires `client_cert`. Can be specified with the `BUDDY_CLIENT_KEY` environmental variable (path or PEM content)",
+				Optional:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_cert")),
+				},
+			},
+			"proxy_url": schema.StringAttribute{
+				MarkdownDescription: "The proxy url used for the Buddy API calls, e.g. `http://proxy.example.com:3128`. Takes precedence over `HTTP_PROXY` and `HTTPS_PROXY` environment variables. Can be specified with the `BUDDY_PROXY_URL` environmental variable",
+				Optional:            true,
+			},
+			"no_proxy": schema.StringAttribute{
+				MarkdownDescription: "Comma-separated list of hosts which are called without the proxy. Used only with `proxy_url`. Can be specified with the `BUDDY_NO_PROXY` environmental variable",
+				Optional:            true,
+			},
+			"proxy_username": schema.StringAttribute{
+				MarkdownDescription: "The username used to authenticate with the proxy. Can be specified with the `BUDDY_PROXY_USERNAME` environmental variable",
+				Optional:            true,
+			},
+			"proxy_password": schema.StringAttribute{
+				MarkdownDescription: "The password used to authenticate with the proxy. Can be specified with the `BUDDY_PROXY_PASSWORD` environmental variable",
+				Optional:            true,
+				Sensitive:           true,
+			},
 			"timeout": schema.Int64Attribute{
 				MarkdownDescription: "The Buddy API client timeout in seconds. Can be specified with the `BUDDY_TIMEOUT` environmental variable. Default: 30s",
 				Optional:            true,
 			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: "The maximum number of retries of the Buddy API calls that failed with `429` or `5xx` status. Non-idempotent requests are retried only when it's safe. Can be specified with the `BUDDY_MAX_RETRIES` environmental variable. Default: 3",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"retry_max_wait": schema.Int64Attribute{
+				MarkdownDescription: "The maximum wait time in seconds between retries of the Buddy API calls. `Retry-After` header is honored up to this value. Can be specified with the `BUDDY_RETRY_MAX_WAIT` environmental variable. Default: 30s",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"requests_per_second": schema.Float64Attribute{
+				MarkdownDescription: "The maximum number of the Buddy API calls per second made by the provider (token-bucket limiter shared by all resources and data sources). Can be specified with the `BUDDY_REQUESTS_PER_SECOND` environmental variable. Default: 0 (no limit)",
+				Optional:            true,
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0),
+				},
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				MarkdownDescription: "The maximum number of the Buddy API calls made by the provider at the same time. Can be specified with the `BUDDY_MAX_CONCURRENT_REQUESTS` environmental variable. Default: 0 (no limit)",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
 		},
 	}
 }
@@ -72,6 +213,27 @@ func (p *BuddyProvider) Configure(ctx context.Context, req provider.ConfigureReq
 			"The provider cannot create the Buddy API client as there is unknown configuration value for the Buddy Token",
 		)
 	}
+	if config.Domain.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("domain"),
+			"Unknown Buddy default workspace domain",
+			"The provider cannot create the Buddy API client as there is unknown configuration value for the Buddy domain attribute",
+		)
+	}
+	if config.TokenFile.IsUnknown() || config.TokenCommand.IsUnknown() || config.OidcToken.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("token"),
+			"Unknown Buddy Token source",
+			"The provider cannot create the Buddy API client as there is unknown configuration value for the Buddy token_file, token_command or oidc_token attribute",
+		)
+	}
+	if config.DefaultTags.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("default_tags"),
+			"Unknown Buddy default tags",
+			"The provider cannot create the Buddy API client as there is unknown configuration value for the Buddy default_tags attribute",
+		)
+	}
 	if config.BaseUrl.IsUnknown() {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("base_url"),
@@ -86,6 +248,27 @@ func (p *BuddyProvider) Configure(ctx context.Context, req provider.ConfigureReq
 			"The provider cannot create the Buddy API client as there is unknown configuration value for the Buddy insecure attribute",
 		)
 	}
+	if config.CaCertFile.IsUnknown() || config.CaCertPem.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ca_cert_pem"),
+			"Unknown Buddy CA certificate for the API endpoint",
+			"The provider cannot create the Buddy API client as there is unknown configuration value for the Buddy ca_cert_file or ca_cert_pem attribute",
+		)
+	}
+	if config.ClientCert.IsUnknown() || config.ClientKey.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("client_cert"),
+			"Unknown Buddy client certificate for the API endpoint",
+			"The provider cannot create the Buddy API client as there is unknown configuration value for the Buddy client_cert or client_key attribute",
+		)
+	}
+	if config.ProxyUrl.IsUnknown() || config.NoProxy.IsUnknown() || config.ProxyUsername.IsUnknown() || config.ProxyPassword.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("proxy_url"),
+			"Unknown Buddy proxy for the API endpoint",
+			"The provider cannot create the Buddy API client as there is unknown configuration value for the Buddy proxy attributes",
+		)
+	}
 	if config.Timeout.IsUnknown() {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("timeout"),
@@ -93,13 +276,72 @@ func (p *BuddyProvider) Configure(ctx context.Context, req provider.ConfigureReq
 			"The provider cannot create the Buddy API client as there is unknown configuration value for the Buddy timeout attribute",
 		)
 	}
+	if config.MaxRetries.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_retries"),
+			"Unknown Buddy max retries value for the API endpoint",
+			"The provider cannot create the Buddy API client as there is unknown configuration value for the Buddy max_retries attribute",
+		)
+	}
+	if config.RetryMaxWait.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("retry_max_wait"),
+			"Unknown Buddy retry max wait value for the API endpoint",
+			"The provider cannot create the Buddy API client as there is unknown configuration value for the Buddy retry_max_wait attribute",
+		)
+	}
+	if config.RequestsPerSecond.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("requests_per_second"),
+			"Unknown Buddy requests per second value for the API endpoint",
+			"The provider cannot create the Buddy API client as there is unknown configuration value for the Buddy requests_per_second attribute",
+		)
+	}
+	if config.MaxConcurrentRequests.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_concurrent_requests"),
+			"Unknown Buddy max concurrent requests value for the API endpoint",
+			"The provider cannot create the Buddy API client as there is unknown configuration value for the Buddy max_concurrent_requests attribute",
+		)
+	}
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	token := os.Getenv("BUDDY_TOKEN")
-	if !config.Token.IsNull() {
-		token = config.Token.ValueString()
+	tokenSource, tokenValue, d := util.ResolveTokenSource(config.Token, config.TokenFile, config.TokenCommand, config.OidcToken)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	token := ""
+	switch tokenSource {
+	case "token":
+		token = tokenValue
+	case "token_file":
+		var err error
+		token, err = util.ReadTokenFile(tokenValue)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("token_file"), "Failed to read Buddy Token file", fmt.Sprintf("The provider cannot read the token file: %s", err.Error()))
+			return
+		}
+	case "token_command":
+		var err error
+		token, err = util.RunTokenCommand(ctx, tokenValue)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("token_command"), "Failed to run Buddy Token command", fmt.Sprintf("The provider cannot get the token from the token command: %s", err.Error()))
+			return
+		}
+	}
+	domain := os.Getenv("BUDDY_DOMAIN")
+	if !config.Domain.IsNull() {
+		domain = config.Domain.ValueString()
+	}
+	var defaultTags []string
+	if !config.DefaultTags.IsNull() {
+		resp.Diagnostics.Append(config.DefaultTags.ElementsAs(ctx, &defaultTags, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	}
 	baseUrl := os.Getenv("BUDDY_BASE_URL")
 	if !config.BaseUrl.IsNull() {
@@ -109,6 +351,62 @@ func (p *BuddyProvider) Configure(ctx context.Context, req provider.ConfigureReq
 	if !config.Insecure.IsNull() {
 		insecure = config.Insecure.ValueBool()
 	}
+	caCert, err := util.ReadPemOrFile(os.Getenv("BUDDY_CA_CERT"))
+	if err != nil {
+		resp.Diagnostics.AddError("Wrong value in BUDDY_CA_CERT env variable", fmt.Sprintf("The provider cannot read the CA certificate from the BUDDY_CA_CERT env variable: %s", err.Error()))
+		return
+	}
+	if !config.CaCertPem.IsNull() {
+		caCert = config.CaCertPem.ValueString()
+	}
+	if !config.CaCertFile.IsNull() {
+		b, err := os.ReadFile(config.CaCertFile.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("ca_cert_file"), "Failed to read CA certificate file", fmt.Sprintf("The provider cannot read the CA certificate file: %s", err.Error()))
+			return
+		}
+		caCert = string(b)
+	}
+	clientCert, err := util.ReadPemOrFile(os.Getenv("BUDDY_CLIENT_CERT"))
+	if err != nil {
+		resp.Diagnostics.AddError("Wrong value in BUDDY_CLIENT_CERT env variable", fmt.Sprintf("The provider cannot read the client certificate from the BUDDY_CLIENT_CERT env variable: %s", err.Error()))
+		return
+	}
+	clientKey, err := util.ReadPemOrFile(os.Getenv("BUDDY_CLIENT_KEY"))
+	if err != nil {
+		resp.Diagnostics.AddError("Wrong value in BUDDY_CLIENT_KEY env variable", fmt.Sprintf("The provider cannot read the client key from the BUDDY_CLIENT_KEY env variable: %s", err.Error()))
+		return
+	}
+	if !config.ClientCert.IsNull() {
+		clientCert, err = util.ReadPemOrFile(config.ClientCert.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("client_cert"), "Failed to read client certificate file", fmt.Sprintf("The provider cannot read the client certificate file: %s", err.Error()))
+			return
+		}
+	}
+	if !config.ClientKey.IsNull() {
+		clientKey, err = util.ReadPemOrFile(config.ClientKey.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("client_key"), "Failed to read client key file", fmt.Sprintf("The provider cannot read the client key file: %s", err.Error()))
+			return
+		}
+	}
+	proxyUrl := os.Getenv("BUDDY_PROXY_URL")
+	if !config.ProxyUrl.IsNull() {
+		proxyUrl = config.ProxyUrl.ValueString()
+	}
+	noProxy := os.Getenv("BUDDY_NO_PROXY")
+	if !config.NoProxy.IsNull() {
+		noProxy = config.NoProxy.ValueString()
+	}
+	proxyUsername := os.Getenv("BUDDY_PROXY_USERNAME")
+	if !config.ProxyUsername.IsNull() {
+		proxyUsername = config.ProxyUsername.ValueString()
+	}
+	proxyPassword := os.Getenv("BUDDY_PROXY_PASSWORD")
+	if !config.ProxyPassword.IsNull() {
+		proxyPassword = config.ProxyPassword.ValueString()
+	}
 	timeout := 30
 	t := os.Getenv("BUDDY_TIMEOUT")
 	if t != "" {
@@ -122,14 +420,104 @@ func (p *BuddyProvider) Configure(ctx context.Context, req provider.ConfigureReq
 	if !config.Timeout.IsNull() {
 		timeout = int(config.Timeout.ValueInt64())
 	}
+	maxRetries := 3
+	mr := os.Getenv("BUDDY_MAX_RETRIES")
+	if mr != "" {
+		var err error
+		maxRetries, err = strconv.Atoi(mr)
+		if err != nil || maxRetries < 0 {
+			resp.Diagnostics.AddError("Wrong value in BUDDY_MAX_RETRIES env variable", "The provider cannot create the Buddy API client as there is wrong value for the BUDDY_MAX_RETRIES env variable")
+			return
+		}
+	}
+	if !config.MaxRetries.IsNull() {
+		maxRetries = int(config.MaxRetries.ValueInt64())
+	}
+	retryMaxWait := 30
+	rmw := os.Getenv("BUDDY_RETRY_MAX_WAIT")
+	if rmw != "" {
+		var err error
+		retryMaxWait, err = strconv.Atoi(rmw)
+		if err != nil || retryMaxWait < 1 {
+			resp.Diagnostics.AddError("Wrong value in BUDDY_RETRY_MAX_WAIT env variable", "The provider cannot create the Buddy API client as there is wrong value for the BUDDY_RETRY_MAX_WAIT env variable")
+			return
+		}
+	}
+	if !config.RetryMaxWait.IsNull() {
+		retryMaxWait = int(config.RetryMaxWait.ValueInt64())
+	}
+	requestsPerSecond := float64(0)
+	rps := os.Getenv("BUDDY_REQUESTS_PER_SECOND")
+	if rps != "" {
+		var err error
+		requestsPerSecond, err = strconv.ParseFloat(rps, 64)
+		if err != nil || requestsPerSecond < 0 {
+			resp.Diagnostics.AddError("Wrong value in BUDDY_REQUESTS_PER_SECOND env variable", "The provider cannot create the Buddy API client as there is wrong value for the BUDDY_REQUESTS_PER_SECOND env variable")
+			return
+		}
+	}
+	if !config.RequestsPerSecond.IsNull() {
+		requestsPerSecond = config.RequestsPerSecond.ValueFloat64()
+	}
+	maxConcurrentRequests := 0
+	mcr := os.Getenv("BUDDY_MAX_CONCURRENT_REQUESTS")
+	if mcr != "" {
+		var err error
+		maxConcurrentRequests, err = strconv.Atoi(mcr)
+		if err != nil || maxConcurrentRequests < 0 {
+			resp.Diagnostics.AddError("Wrong value in BUDDY_MAX_CONCURRENT_REQUESTS env variable", "The provider cannot create the Buddy API client as there is wrong value for the BUDDY_MAX_CONCURRENT_REQUESTS env variable")
+			return
+		}
+	}
+	if !config.MaxConcurrentRequests.IsNull() {
+		maxConcurrentRequests = int(config.MaxConcurrentRequests.ValueInt64())
+	}
 
-	client, err := buddy.NewClientWithTimeout(token, baseUrl, insecure, time.Duration(timeout)*time.Second)
+	httpClient, err := util.NewHttpClient(ctx, &util.HttpClientConfig{
+		Token:   token,
+		Timeout: time.Duration(timeout) * time.Second,
+		Tls: &util.TlsConfig{
+			Insecure:   insecure,
+			CaCertPem:  caCert,
+			ClientCert: clientCert,
+			ClientKey:  clientKey,
+		},
+		Proxy: &util.ProxyConfig{
+			Url:      proxyUrl,
+			NoProxy:  noProxy,
+			Username: proxyUsername,
+			Password: proxyPassword,
+		},
+		MaxRetries:            maxRetries,
+		RetryMaxWait:          time.Duration(retryMaxWait) * time.Second,
+		RequestsPerSecond:     requestsPerSecond,
+		MaxConcurrentRequests: maxConcurrentRequests,
+		WrapTransport:         p.wrapTransport,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create HTTP client of Buddy Client", fmt.Sprintf("The provider failed to configure TLS or proxy from the given configuration: %s", err.Error()))
+		return
+	}
+	if tokenSource == "oidc_token" {
+		token, err = util.ExchangeOidcToken(ctx, httpClient, baseUrl, tokenValue)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("oidc_token"), "Failed to exchange OIDC token for Buddy Token", fmt.Sprintf("The provider cannot exchange the OIDC token: %s", err.Error()))
+			return
+		}
+	}
+	client, err := buddy.NewClientWithHttpClient(token, baseUrl, httpClient)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to create Buddy Client from provider configuration", fmt.Sprintf("The provider failed to create a new Buddy Client from the giver configuration: %s", err.Error()))
 		return
 	}
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	data := &util.ProviderData{
+		Client:      client,
+		Domain:      domain,
+		DefaultTags: defaultTags,
+	}
+	resp.DataSourceData = data
+	resp.ResourceData = data
+	resp.EphemeralResourceData = data
 }
 
 func (p *BuddyProvider) Resources(_ context.Context) []func() resource.Resource {
@@ -153,6 +541,10 @@ func (p *BuddyProvider) Resources(_ context.Context) []func() resource.Resource
 		buddyresource.NewVariableSshResource,
 		buddyresource.NewWebhookResource,
 		buddyresource.NewPipelineResource,
+		buddyresource.NewPipelineActionResource,
+		buddyresource.NewPipelineExecutionResource,
+		buddyresource.NewPipelineYamlResource,
+		buddyresource.NewPipelineStatusResource,
 		buddyresource.NewSandboxResource,
 		buddyresource.NewSandboxStatusResource,
 		buddyresource.NewEnvironmentResource,
@@ -189,6 +581,10 @@ func (p *BuddyProvider) DataSources(_ context.Context) []func() datasource.DataS
 		buddysource.NewWorkspacesSource,
 		buddysource.NewPipelineSource,
 		buddysource.NewPipelinesSource,
+		buddysource.NewPipelineActionSource,
+		buddysource.NewPipelineActionsSource,
+		buddysource.NewPipelineExecutionsSource,
+		buddysource.NewPipelineYamlSource,
 		buddysource.NewSandboxesSource,
 		buddysource.NewSandboxSource,
 		buddysource.NewEnvironmentsSource,
@@ -197,6 +593,20 @@ func (p *BuddyProvider) DataSources(_ context.Context) []func() datasource.DataS
 	}
 }
 
+func (p *BuddyProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		buddyephemeral.NewTokenEphemeral,
+	}
+}
+
+func (p *BuddyProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		buddyfunction.NewParseIdFunction,
+		buddyfunction.NewPipelineActionIdFunction,
+		buddyfunction.NewPipelineIdFunction,
+	}
+}
+
 func New(version string) func() provider.Provider {
 	return func() provider.Provider {
 		return &BuddyProvider{
@@ -204,3 +614,13 @@ func New(version string) func() provider.Provider {
 		}
 	}
 }
+
+// NewWithTransport returns provider which wraps the base transport of the Buddy API client (e.g. to record and replay acceptance tests)
+func NewWithTransport(version string, wrapTransport func(http.RoundTripper) http.RoundTripper) func() provider.Provider {
+	return func() provider.Provider {
+		return &BuddyProvider{
+			version:       version,
+			wrapTransport: wrapTransport,
+		}
+	}
+}
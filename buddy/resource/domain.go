@@ -19,6 +19,7 @@ var (
 	_ resource.Resource                = &domainResource{}
 	_ resource.ResourceWithConfigure   = &domainResource{}
 	_ resource.ResourceWithImportState = &domainResource{}
+	_ resource.ResourceWithModifyPlan  = &domainResource{}
 )
 
 func NewDomainResource() resource.Resource {
@@ -26,7 +27,8 @@ func NewDomainResource() resource.Resource {
 }
 
 type domainResource struct {
-	client *buddy.Client
+	client        *buddy.Client
+	defaultDomain string
 }
 
 type domainResourceModel struct {
@@ -71,10 +73,12 @@ func (r *domainResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				},
 			},
 			"workspace_domain": schema.StringAttribute{
-				MarkdownDescription: "The workspace's URL handle",
-				Required:            true,
+				MarkdownDescription: "The workspace's URL handle. Defaults to the provider's `domain`",
+				Optional:            true,
+				Computed:            true,
 				Validators:          util.StringValidatorsDomain(),
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
@@ -112,7 +116,13 @@ func (r *domainResource) Configure(_ context.Context, req resource.ConfigureRequ
 	if req.ProviderData == nil {
 		return
 	}
-	r.client = req.ProviderData.(*buddy.Client)
+	p := req.ProviderData.(*util.ProviderData)
+	r.client = p.Client
+	r.defaultDomain = p.Domain
+}
+
+func (r *domainResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	util.ModifyPlanDomain(ctx, path.Root("workspace_domain"), r.defaultDomain, req, resp)
 }
 
 func (r *domainResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -21,6 +21,7 @@ var (
 	_ resource.Resource                = &domainRecordResource{}
 	_ resource.ResourceWithConfigure   = &domainRecordResource{}
 	_ resource.ResourceWithImportState = &domainRecordResource{}
+	_ resource.ResourceWithModifyPlan  = &domainRecordResource{}
 )
 
 func NewDomainRecordResource() resource.Resource {
@@ -28,7 +29,8 @@ func NewDomainRecordResource() resource.Resource {
 }
 
 type domainRecordResource struct {
-	client *buddy.Client
+	client        *buddy.Client
+	defaultDomain string
 }
 
 type domainRecordResourceModel struct {
@@ -90,10 +92,12 @@ func (r *domainRecordResource) Schema(_ context.Context, _ resource.SchemaReques
 				},
 			},
 			"workspace_domain": schema.StringAttribute{
-				MarkdownDescription: "The workspace's URL handle",
-				Required:            true,
+				MarkdownDescription: "The workspace's URL handle. Defaults to the provider's `domain`",
+				Optional:            true,
+				Computed:            true,
 				Validators:          util.StringValidatorsDomain(),
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
@@ -170,7 +174,13 @@ func (r *domainRecordResource) Configure(_ context.Context, req resource.Configu
 	if req.ProviderData == nil {
 		return
 	}
-	r.client = req.ProviderData.(*buddy.Client)
+	p := req.ProviderData.(*util.ProviderData)
+	r.client = p.Client
+	r.defaultDomain = p.Domain
+}
+
+func (r *domainRecordResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	util.ModifyPlanDomain(ctx, path.Root("workspace_domain"), r.defaultDomain, req, resp)
 }
 
 func (r *domainRecordResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -17,9 +17,11 @@ import (
 )
 
 var (
-	_ resource.Resource                = &environmentResource{}
-	_ resource.ResourceWithConfigure   = &environmentResource{}
-	_ resource.ResourceWithImportState = &environmentResource{}
+	_ resource.Resource                 = &environmentResource{}
+	_ resource.ResourceWithConfigure    = &environmentResource{}
+	_ resource.ResourceWithImportState  = &environmentResource{}
+	_ resource.ResourceWithModifyPlan   = &environmentResource{}
+	_ resource.ResourceWithUpgradeState = &environmentResource{}
 )
 
 func NewEnvironmentResource() resource.Resource {
@@ -27,7 +29,9 @@ func NewEnvironmentResource() resource.Resource {
 }
 
 type environmentResource struct {
-	client *buddy.Client
+	client        *buddy.Client
+	defaultDomain string
+	defaultTags   []string
 }
 
 func (e *environmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
@@ -38,7 +42,15 @@ func (e *environmentResource) Configure(_ context.Context, req resource.Configur
 	if req.ProviderData == nil {
 		return
 	}
-	e.client = req.ProviderData.(*buddy.Client)
+	p := req.ProviderData.(*util.ProviderData)
+	e.client = p.Client
+	e.defaultDomain = p.Domain
+	e.defaultTags = p.DefaultTags
+}
+
+func (e *environmentResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	util.ModifyPlanDomain(ctx, path.Root("domain"), e.defaultDomain, req, resp)
+	util.ModifyPlanTags(ctx, e.defaultTags, req, resp)
 }
 
 type environmentResourceModel struct {
@@ -61,10 +73,11 @@ type environmentResourceModel struct {
 	BaseEnvironments        types.Set    `tfsdk:"base_environments"`
 	Project                 types.Set    `tfsdk:"project"`
 	Tags                    types.Set    `tfsdk:"tags"`
+	TagsAll                 types.Set    `tfsdk:"tags_all"`
 	Permissions             types.Set    `tfsdk:"permissions"`
 }
 
-func (r *environmentResourceModel) loadAPI(ctx context.Context, domain string, environment *buddy.Environment) diag.Diagnostics {
+func (r *environmentResourceModel) loadAPI(ctx context.Context, domain string, environment *buddy.Environment, defaultTags []string) diag.Diagnostics {
 	var diags diag.Diagnostics
 	// zeby ograniczyc breaking change zostawmy id jako 3 elementowe z pustym projektem (nie istotnym teraz)
 	r.ID = types.StringValue(util.ComposeTripleId(domain, "", environment.Id))
@@ -85,7 +98,7 @@ func (r *environmentResourceModel) loadAPI(ctx context.Context, domain string, e
 	r.CreateDate = types.StringValue(environment.CreateDate)
 	r.Scope = types.StringValue(environment.Scope)
 	r.BaseOnly = types.BoolValue(environment.BaseOnly)
-	tags, d := types.SetValueFrom(ctx, types.StringType, &environment.Tags)
+	tags, tagsAll, d := util.TagsModelFromApi(ctx, &r.Tags, environment.Tags, defaultTags)
 	diags.Append(d...)
 	var envProjects []*buddy.Project
 	if environment.Project != nil {
@@ -94,6 +107,7 @@ func (r *environmentResourceModel) loadAPI(ctx context.Context, domain string, e
 		envProjects = []*buddy.Project{}
 	}
 	r.Tags = tags
+	r.TagsAll = tagsAll
 	projects, d := util.ProjectsModelFromApi(ctx, &envProjects)
 	diags.Append(d...)
 	r.Project = projects
@@ -117,6 +131,7 @@ func (e *environmentResource) Metadata(_ context.Context, req resource.MetadataR
 
 func (e *environmentResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
 		MarkdownDescription: "Create and manage an environment\n\n" +
 			"Token scopes required: `WORKSPACE`, `ENVIRONMENT_MANAGE`, `ENVIRONMENT_INFO`",
 		Attributes: map[string]schema.Attribute{
@@ -128,10 +143,12 @@ func (e *environmentResource) Schema(_ context.Context, _ resource.SchemaRequest
 				},
 			},
 			"domain": schema.StringAttribute{
-				MarkdownDescription: "The workspace's URL handle",
-				Required:            true,
+				MarkdownDescription: "The workspace's URL handle. Defaults to the provider's `domain`",
+				Optional:            true,
+				Computed:            true,
 				Validators:          util.StringValidatorsDomain(),
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
@@ -234,6 +251,11 @@ func (e *environmentResource) Schema(_ context.Context, _ resource.SchemaRequest
 				Optional:            true,
 				Computed:            true,
 			},
+			"tags_all": schema.SetAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "The environment's list of tags including the provider's `default_tags`",
+				Computed:            true,
+			},
 		},
 		Blocks: map[string]schema.Block{
 			"allowed_pipeline": schema.SetNestedBlock{
@@ -347,14 +369,12 @@ func (e *environmentResource) Create(ctx context.Context, req resource.CreateReq
 	if !data.BaseOnly.IsNull() && !data.BaseOnly.IsUnknown() {
 		ops.BaseOnly = data.BaseOnly.ValueBoolPointer()
 	}
-	if !data.Tags.IsNull() && !data.Tags.IsUnknown() {
-		tags, d := util.StringSetToApi(ctx, &data.Tags)
-		resp.Diagnostics.Append(d...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-		ops.Tags = tags
+	tags, d := util.TagsToApi(ctx, &req.Config, nil, &data.Tags, &data.TagsAll)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	ops.Tags = tags
 	if !data.Permissions.IsNull() && !data.Permissions.IsUnknown() {
 		permissions, d := util.EnvironmentPermissionsModelToApi(ctx, &data.Permissions)
 		resp.Diagnostics.Append(d...)
@@ -402,7 +422,7 @@ func (e *environmentResource) Create(ctx context.Context, req resource.CreateReq
 		resp.Diagnostics.Append(util.NewDiagnosticApiError("create environment", err))
 		return
 	}
-	resp.Diagnostics.Append(data.loadAPI(ctx, domain, environment)...)
+	resp.Diagnostics.Append(data.loadAPI(ctx, domain, environment, e.defaultTags)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -429,7 +449,7 @@ func (e *environmentResource) Read(ctx context.Context, req resource.ReadRequest
 		resp.Diagnostics.Append(util.NewDiagnosticApiError("get environment", err))
 		return
 	}
-	resp.Diagnostics.Append(data.loadAPI(ctx, domain, environment)...)
+	resp.Diagnostics.Append(data.loadAPI(ctx, domain, environment, e.defaultTags)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -470,14 +490,12 @@ func (e *environmentResource) Update(ctx context.Context, req resource.UpdateReq
 	if !data.BaseOnly.IsNull() && !data.BaseOnly.IsUnknown() {
 		ops.BaseOnly = data.BaseOnly.ValueBoolPointer()
 	}
-	if !data.Tags.IsNull() && !data.Tags.IsUnknown() {
-		tags, d := util.StringSetToApi(ctx, &data.Tags)
-		resp.Diagnostics.Append(d...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-		ops.Tags = tags
+	tags, d := util.TagsToApi(ctx, &req.Config, &req.State, &data.Tags, &data.TagsAll)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	ops.Tags = tags
 	if !data.Permissions.IsNull() && !data.Permissions.IsUnknown() {
 		permissions, d := util.EnvironmentPermissionsModelToApi(ctx, &data.Permissions)
 		resp.Diagnostics.Append(d...)
@@ -525,7 +543,7 @@ func (e *environmentResource) Update(ctx context.Context, req resource.UpdateReq
 		resp.Diagnostics.Append(util.NewDiagnosticApiError("update environment", err))
 		return
 	}
-	resp.Diagnostics.Append(data.loadAPI(ctx, domain, environment)...)
+	resp.Diagnostics.Append(data.loadAPI(ctx, domain, environment, e.defaultTags)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -548,3 +566,21 @@ func (e *environmentResource) Delete(ctx context.Context, req resource.DeleteReq
 		resp.Diagnostics.Append(util.NewDiagnosticApiError("delete environment", err))
 	}
 }
+
+func (e *environmentResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	var s resource.SchemaResponse
+	e.Schema(ctx, resource.SchemaRequest{}, &s)
+	return map[int64]resource.StateUpgrader{
+		0: util.UpgradeRawState(ctx, s.Schema, upgradeEnvironmentStateV0),
+	}
+}
+
+// upgradeEnvironmentStateV0 upgrades state saved before v1.43.0 (boolean access flags)
+func upgradeEnvironmentStateV0(state map[string]interface{}) {
+	util.UpgradeRawStateBoolToAccessLevel(state, "all_pipelines_allowed", "pipelines_access_level", buddy.EnvironmentAccessLevelUseOnly, buddy.EnvironmentAccessLevelDenied)
+	util.UpgradeRawStateBoolToAccessLevel(state, "all_environments_allowed", "environments_access_level", buddy.EnvironmentAccessLevelUseOnly, buddy.EnvironmentAccessLevelDenied)
+	util.UpgradeRawStateBlockDefault(state, "allowed_pipeline", "access_level", buddy.EnvironmentAccessLevelUseOnly)
+	util.UpgradeRawStateBlockDefault(state, "allowed_environment", "access_level", buddy.EnvironmentAccessLevelUseOnly)
+	// before v1.39.0 allowed pipelines were kept as list of IDs, there is no way to map them to the project & identifier
+	delete(state, "allowed_pipelines")
+}
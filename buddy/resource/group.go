@@ -18,6 +18,7 @@ var (
 	_ resource.Resource                = &groupResource{}
 	_ resource.ResourceWithConfigure   = &groupResource{}
 	_ resource.ResourceWithImportState = &groupResource{}
+	_ resource.ResourceWithModifyPlan  = &groupResource{}
 )
 
 func NewGroupResource() resource.Resource {
@@ -25,7 +26,8 @@ func NewGroupResource() resource.Resource {
 }
 
 type groupResource struct {
-	client *buddy.Client
+	client        *buddy.Client
+	defaultDomain string
 }
 
 type groupResourceModel struct {
@@ -80,10 +82,12 @@ func (r *groupResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				},
 			},
 			"domain": schema.StringAttribute{
-				MarkdownDescription: "The workspace's URL handle",
-				Required:            true,
+				MarkdownDescription: "The workspace's URL handle. Defaults to the provider's `domain`",
+				Optional:            true,
+				Computed:            true,
 				Validators:          util.StringValidatorsDomain(),
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
@@ -124,7 +128,13 @@ func (r *groupResource) Configure(_ context.Context, req resource.ConfigureReque
 	if req.ProviderData == nil {
 		return
 	}
-	r.client = req.ProviderData.(*buddy.Client)
+	p := req.ProviderData.(*util.ProviderData)
+	r.client = p.Client
+	r.defaultDomain = p.Domain
+}
+
+func (r *groupResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	util.ModifyPlanDomain(ctx, path.Root("domain"), r.defaultDomain, req, resp)
 }
 
 func (r *groupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -20,6 +20,7 @@ var (
 	_ resource.Resource                = &groupMemberResource{}
 	_ resource.ResourceWithConfigure   = &groupMemberResource{}
 	_ resource.ResourceWithImportState = &groupMemberResource{}
+	_ resource.ResourceWithModifyPlan  = &groupMemberResource{}
 )
 
 func NewGroupMemberResource() resource.Resource {
@@ -27,7 +28,8 @@ func NewGroupMemberResource() resource.Resource {
 }
 
 type groupMemberResource struct {
-	client *buddy.Client
+	client        *buddy.Client
+	defaultDomain string
 }
 
 type groupMemberResourceModel struct {
@@ -92,10 +94,12 @@ func (r *groupMemberResource) Schema(_ context.Context, _ resource.SchemaRequest
 				},
 			},
 			"domain": schema.StringAttribute{
-				MarkdownDescription: "The workspace's URL handle",
-				Required:            true,
+				MarkdownDescription: "The workspace's URL handle. Defaults to the provider's `domain`",
+				Optional:            true,
+				Computed:            true,
 				Validators:          util.StringValidatorsDomain(),
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
@@ -154,7 +158,13 @@ func (r *groupMemberResource) Configure(_ context.Context, req resource.Configur
 	if req.ProviderData == nil {
 		return
 	}
-	r.client = req.ProviderData.(*buddy.Client)
+	p := req.ProviderData.(*util.ProviderData)
+	r.client = p.Client
+	r.defaultDomain = p.Domain
+}
+
+func (r *groupMemberResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	util.ModifyPlanDomain(ctx, path.Root("domain"), r.defaultDomain, req, resp)
 }
 
 func (r *groupMemberResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
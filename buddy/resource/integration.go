@@ -11,14 +11,17 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"terraform-provider-buddy/buddy/util"
 )
 
 var (
-	_ resource.Resource                = &integrationResource{}
-	_ resource.ResourceWithConfigure   = &integrationResource{}
-	_ resource.ResourceWithImportState = &integrationResource{}
+	_ resource.Resource                   = &integrationResource{}
+	_ resource.ResourceWithConfigure      = &integrationResource{}
+	_ resource.ResourceWithImportState    = &integrationResource{}
+	_ resource.ResourceWithModifyPlan     = &integrationResource{}
+	_ resource.ResourceWithValidateConfig = &integrationResource{}
 )
 
 func NewIntegrationResource() resource.Resource {
@@ -26,7 +29,8 @@ func NewIntegrationResource() resource.Resource {
 }
 
 type integrationResource struct {
-	client *buddy.Client
+	client        *buddy.Client
+	defaultDomain string
 }
 
 type integrationResourceModel struct {
@@ -58,6 +62,13 @@ type integrationResourceModel struct {
 	GoogleProject       types.String `tfsdk:"google_project"`
 	IntegrationId       types.String `tfsdk:"integration_id"`
 	HtmlUrl             types.String `tfsdk:"html_url"`
+	TokenWo             types.String `tfsdk:"token_wo"`
+	PartnerTokenWo      types.String `tfsdk:"partner_token_wo"`
+	AccessKeyWo         types.String `tfsdk:"access_key_wo"`
+	SecretKeyWo         types.String `tfsdk:"secret_key_wo"`
+	PasswordWo          types.String `tfsdk:"password_wo"`
+	ApiKeyWo            types.String `tfsdk:"api_key_wo"`
+	SecretsWoVersion    types.Int64  `tfsdk:"secrets_wo_version"`
 }
 
 func (r *integrationResourceModel) decomposeId() (string, string, error) {
@@ -92,6 +103,13 @@ func (r *integrationResourceModel) loadAPI(ctx context.Context, domain string, i
 	r.HtmlUrl = types.StringValue(integration.HtmlUrl)
 	r.IntegrationId = types.StringValue(integration.HashId)
 	r.Identifier = types.StringValue(integration.Identifier)
+	// write-only values are never stored in the state
+	r.TokenWo = types.StringNull()
+	r.PartnerTokenWo = types.StringNull()
+	r.AccessKeyWo = types.StringNull()
+	r.SecretKeyWo = types.StringNull()
+	r.PasswordWo = types.StringNull()
+	r.ApiKeyWo = types.StringNull()
 	// rest of the attributes are not returned by api
 	return diags
 }
@@ -113,10 +131,12 @@ func (r *integrationResource) Schema(_ context.Context, _ resource.SchemaRequest
 				},
 			},
 			"domain": schema.StringAttribute{
-				MarkdownDescription: "The workspace's URL handle",
-				Required:            true,
+				MarkdownDescription: "The workspace's URL handle. Defaults to the provider's `domain`",
+				Optional:            true,
+				Computed:            true,
 				Validators:          util.StringValidatorsDomain(),
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
@@ -276,6 +296,70 @@ func (r *integrationResource) Schema(_ context.Context, _ resource.SchemaRequest
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"token_wo": schema.StringAttribute{
+				MarkdownDescription: "The integration's write-only token. It's never stored in the state. Requires Terraform 1.11 or later. Provide instead of `token`",
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("token")),
+					stringvalidator.AlsoRequires(path.MatchRoot("secrets_wo_version")),
+				},
+			},
+			"partner_token_wo": schema.StringAttribute{
+				MarkdownDescription: "The integration's write-only partner token. It's never stored in the state. Requires Terraform 1.11 or later. Provide instead of `partner_token`",
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("partner_token")),
+					stringvalidator.AlsoRequires(path.MatchRoot("secrets_wo_version")),
+				},
+			},
+			"access_key_wo": schema.StringAttribute{
+				MarkdownDescription: "The integration's write-only access key. It's never stored in the state. Requires Terraform 1.11 or later. Provide instead of `access_key`",
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("access_key")),
+					stringvalidator.AlsoRequires(path.MatchRoot("secrets_wo_version")),
+				},
+			},
+			"secret_key_wo": schema.StringAttribute{
+				MarkdownDescription: "The integration's write-only secret key. It's never stored in the state. Requires Terraform 1.11 or later. Provide instead of `secret_key`",
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("secret_key")),
+					stringvalidator.AlsoRequires(path.MatchRoot("secrets_wo_version")),
+				},
+			},
+			"password_wo": schema.StringAttribute{
+				MarkdownDescription: "The integration's write-only password. It's never stored in the state. Requires Terraform 1.11 or later. Provide instead of `password`",
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("password")),
+					stringvalidator.AlsoRequires(path.MatchRoot("secrets_wo_version")),
+				},
+			},
+			"api_key_wo": schema.StringAttribute{
+				MarkdownDescription: "The integration's write-only API key. It's never stored in the state. Requires Terraform 1.11 or later. Provide instead of `api_key`",
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("api_key")),
+					stringvalidator.AlsoRequires(path.MatchRoot("secrets_wo_version")),
+				},
+			},
+			"secrets_wo_version": schema.Int64Attribute{
+				MarkdownDescription: "The version of the write-only secrets. Change it to update the integration's secrets provided with `*_wo` attributes",
+				Optional:            true,
+			},
 			"integration_id": schema.StringAttribute{
 				MarkdownDescription: "The integration's ID",
 				Computed:            true,
@@ -352,7 +436,17 @@ func (r *integrationResource) Configure(_ context.Context, req resource.Configur
 	if req.ProviderData == nil {
 		return
 	}
-	r.client = req.ProviderData.(*buddy.Client)
+	p := req.ProviderData.(*util.ProviderData)
+	r.client = p.Client
+	r.defaultDomain = p.Domain
+}
+
+func (r *integrationResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(util.ValidateIntegrationConfig(ctx, &req.Config)...)
+}
+
+func (r *integrationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	util.ModifyPlanDomain(ctx, path.Root("domain"), r.defaultDomain, req, resp)
 }
 
 func (r *integrationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -413,7 +507,6 @@ func (r *integrationResource) Create(ctx context.Context, req resource.CreateReq
 	}
 	if !data.PartnerToken.IsNull() && !data.PartnerToken.IsUnknown() {
 		ops.PartnerToken = data.PartnerToken.ValueStringPointer()
-		authType = buddy.IntegrationAuthTypeTokenAppExtension
 	}
 	if !data.AccessKey.IsNull() && !data.AccessKey.IsUnknown() {
 		ops.AccessKey = data.AccessKey.ValueStringPointer()
@@ -453,6 +546,13 @@ func (r *integrationResource) Create(ctx context.Context, req resource.CreateReq
 		}
 		ops.RoleAssumptions = roles
 	}
+	resp.Diagnostics.Append(integrationWriteOnlyToApi(ctx, &req.Config, &ops)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if ops.PartnerToken != nil {
+		authType = buddy.IntegrationAuthTypeTokenAppExtension
+	}
 	if authType != "" {
 		ops.AuthType = &authType
 	}
@@ -554,7 +654,6 @@ func (r *integrationResource) Update(ctx context.Context, req resource.UpdateReq
 	}
 	if !data.PartnerToken.IsNull() && !data.PartnerToken.IsUnknown() {
 		ops.PartnerToken = data.PartnerToken.ValueStringPointer()
-		authType = buddy.IntegrationAuthTypeTokenAppExtension
 	}
 	if !data.AccessKey.IsNull() && !data.AccessKey.IsUnknown() {
 		ops.AccessKey = data.AccessKey.ValueStringPointer()
@@ -594,6 +693,13 @@ func (r *integrationResource) Update(ctx context.Context, req resource.UpdateReq
 		}
 		ops.RoleAssumptions = roles
 	}
+	resp.Diagnostics.Append(integrationWriteOnlyToApi(ctx, &req.Config, &ops)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if ops.PartnerToken != nil {
+		authType = buddy.IntegrationAuthTypeTokenAppExtension
+	}
 	if authType != "" {
 		ops.AuthType = &authType
 	}
@@ -629,3 +735,23 @@ func (r *integrationResource) Delete(ctx context.Context, req resource.DeleteReq
 func (r *integrationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
+
+// integrationWriteOnlyToApi sets secrets provided with write-only attributes
+func integrationWriteOnlyToApi(ctx context.Context, config *tfsdk.Config, ops *buddy.IntegrationOps) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for attr, field := range map[string]**string{
+		"token_wo":         &ops.Token,
+		"partner_token_wo": &ops.PartnerToken,
+		"access_key_wo":    &ops.AccessKey,
+		"secret_key_wo":    &ops.SecretKey,
+		"password_wo":      &ops.Password,
+		"api_key_wo":       &ops.ApiKey,
+	} {
+		v, d := util.WriteOnlyStringToApi(ctx, config, path.Root(attr))
+		diags.Append(d...)
+		if v != nil {
+			*field = v
+		}
+	}
+	return diags
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"strconv"
+	"strings"
 	"terraform-provider-buddy/buddy/util"
 )
 
@@ -17,6 +18,7 @@ var (
 	_ resource.Resource                = &memberResource{}
 	_ resource.ResourceWithConfigure   = &memberResource{}
 	_ resource.ResourceWithImportState = &memberResource{}
+	_ resource.ResourceWithModifyPlan  = &memberResource{}
 )
 
 func NewMemberResource() resource.Resource {
@@ -24,7 +26,8 @@ func NewMemberResource() resource.Resource {
 }
 
 type memberResource struct {
-	client *buddy.Client
+	client        *buddy.Client
+	defaultDomain string
 }
 
 type memberResourceModel struct {
@@ -85,10 +88,12 @@ func (r *memberResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				},
 			},
 			"domain": schema.StringAttribute{
-				MarkdownDescription: "The workspace's URL handle",
-				Required:            true,
+				MarkdownDescription: "The workspace's URL handle. Defaults to the provider's `domain`",
+				Optional:            true,
+				Computed:            true,
 				Validators:          util.StringValidatorsDomain(),
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
@@ -142,7 +147,13 @@ func (r *memberResource) Configure(_ context.Context, req resource.ConfigureRequ
 	if req.ProviderData == nil {
 		return
 	}
-	r.client = req.ProviderData.(*buddy.Client)
+	p := req.ProviderData.(*util.ProviderData)
+	r.client = p.Client
+	r.defaultDomain = p.Domain
+}
+
+func (r *memberResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	util.ModifyPlanDomain(ctx, path.Root("domain"), r.defaultDomain, req, resp)
 }
 
 func (r *memberResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -254,6 +265,23 @@ func (r *memberResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	}
 }
 
+// ImportState accepts `domain:member_id` or `domain:email`
 func (r *memberResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	domain, email, err := util.DecomposeDoubleId(req.ID)
+	if err != nil || !strings.Contains(email, "@") {
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
+	members, _, err := r.client.MemberService.GetListAll(domain)
+	if err != nil {
+		resp.Diagnostics.Append(util.NewDiagnosticApiError("get members", err))
+		return
+	}
+	for _, m := range members.Members {
+		if strings.EqualFold(m.Email, email) {
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), util.ComposeDoubleId(domain, strconv.Itoa(m.Id)))...)
+			return
+		}
+	}
+	resp.Diagnostics.Append(util.NewDiagnosticImportNotFound("member", email))
 }
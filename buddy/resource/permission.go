@@ -19,6 +19,7 @@ var (
 	_ resource.Resource                = &permissionResource{}
 	_ resource.ResourceWithConfigure   = &permissionResource{}
 	_ resource.ResourceWithImportState = &permissionResource{}
+	_ resource.ResourceWithModifyPlan  = &permissionResource{}
 )
 
 func NewPermissionResource() resource.Resource {
@@ -26,7 +27,8 @@ func NewPermissionResource() resource.Resource {
 }
 
 type permissionResource struct {
-	client *buddy.Client
+	client        *buddy.Client
+	defaultDomain string
 }
 
 type permissionResourceModel struct {
@@ -91,10 +93,12 @@ func (r *permissionResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				},
 			},
 			"domain": schema.StringAttribute{
-				MarkdownDescription: "The workspace's URL handle",
-				Required:            true,
+				MarkdownDescription: "The workspace's URL handle. Defaults to the provider's `domain`",
+				Optional:            true,
+				Computed:            true,
 				Validators:          util.StringValidatorsDomain(),
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
@@ -185,7 +189,13 @@ func (r *permissionResource) Configure(_ context.Context, req resource.Configure
 	if req.ProviderData == nil {
 		return
 	}
-	r.client = req.ProviderData.(*buddy.Client)
+	p := req.ProviderData.(*util.ProviderData)
+	r.client = p.Client
+	r.defaultDomain = p.Domain
+}
+
+func (r *permissionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	util.ModifyPlanDomain(ctx, path.Root("domain"), r.defaultDomain, req, resp)
 }
 
 func (r *permissionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -20,9 +20,11 @@ import (
 )
 
 var (
-	_ resource.Resource                = &pipelineResource{}
-	_ resource.ResourceWithConfigure   = &pipelineResource{}
-	_ resource.ResourceWithImportState = &pipelineResource{}
+	_ resource.Resource                   = &pipelineResource{}
+	_ resource.ResourceWithConfigure      = &pipelineResource{}
+	_ resource.ResourceWithImportState    = &pipelineResource{}
+	_ resource.ResourceWithModifyPlan     = &pipelineResource{}
+	_ resource.ResourceWithValidateConfig = &pipelineResource{}
 )
 
 func NewPipelineResource() resource.Resource {
@@ -30,7 +32,9 @@ func NewPipelineResource() resource.Resource {
 }
 
 type pipelineResource struct {
-	client *buddy.Client
+	client        *buddy.Client
+	defaultDomain string
+	defaultTags   []string
 }
 
 type pipelineResourceModel struct {
@@ -65,6 +69,7 @@ type pipelineResourceModel struct {
 	DoNotCreateCommitStatus   types.Bool   `tfsdk:"do_not_create_commit_status"`
 	CloneDepth                types.Int64  `tfsdk:"clone_depth"`
 	Paused                    types.Bool   `tfsdk:"paused"`
+	IgnoreStatusChanges       types.Bool   `tfsdk:"ignore_status_changes"`
 	PauseOnRepeatedFailures   types.Int64  `tfsdk:"pause_on_repeated_failures"`
 	IgnoreFailOnProjectStatus types.Bool   `tfsdk:"ignore_fail_on_project_status"`
 	ExecutionMessageTemplate  types.String `tfsdk:"execution_message_template"`
@@ -79,18 +84,25 @@ type pipelineResourceModel struct {
 	Project                   types.Set    `tfsdk:"project"`
 	Refs                      types.Set    `tfsdk:"refs"`
 	Tags                      types.Set    `tfsdk:"tags"`
+	TagsAll                   types.Set    `tfsdk:"tags_all"`
 	Events                    types.Set    `tfsdk:"event"`
 	TriggerConditions         types.Set    `tfsdk:"trigger_condition"`
 	Permissions               types.Set    `tfsdk:"permissions"`
 	Loop                      types.Set    `tfsdk:"loop"`
 }
 
-func (r *pipelineResourceModel) loadAPI(ctx context.Context, domain string, projectName string, pipeline *buddy.Pipeline) diag.Diagnostics {
+func (r *pipelineResourceModel) loadAPI(ctx context.Context, domain string, projectName string, pipeline *buddy.Pipeline, defaultTags []string) diag.Diagnostics {
 	var diags diag.Diagnostics
 	r.ID = types.StringValue(util.ComposeTripleId(domain, projectName, strconv.Itoa(pipeline.Id)))
 	r.Domain = types.StringValue(domain)
 	r.ProjectName = types.StringValue(projectName)
 	r.HtmlUrl = types.StringValue(pipeline.HtmlUrl)
+	tags, tagsAll, d := util.TagsModelFromApi(ctx, &r.Tags, pipeline.Tags, defaultTags)
+	diags.Append(d...)
+	if !r.Tags.IsNull() || len(tags.Elements()) > 0 {
+		r.Tags = tags
+	}
+	r.TagsAll = tagsAll
 	r.Name = types.StringValue(pipeline.Name)
 	r.Identifier = types.StringValue(pipeline.Identifier)
 	r.GitConfigRef = types.StringValue(pipeline.GitConfigRef)
@@ -174,10 +186,12 @@ func (r *pipelineResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 				},
 			},
 			"domain": schema.StringAttribute{
-				MarkdownDescription: "The workspace's URL handle",
-				Required:            true,
+				MarkdownDescription: "The workspace's URL handle. Defaults to the provider's `domain`",
+				Optional:            true,
+				Computed:            true,
 				Validators:          util.StringValidatorsDomain(),
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
@@ -376,6 +390,10 @@ func (r *pipelineResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 				Optional:            true,
 				Computed:            true,
 			},
+			"ignore_status_changes": schema.BoolAttribute{
+				MarkdownDescription: "Set to `true` if the pipeline's `paused`, `disabled` and `disabling_reason` are managed by `buddy_pipeline_status`. Then they are not sent to the API and can't be set",
+				Optional:            true,
+			},
 			"pause_on_repeated_failures": schema.Int64Attribute{
 				MarkdownDescription: "The pipeline's max failed executions before it is paused. Restricted to schedule",
 				Optional:            true,
@@ -471,6 +489,11 @@ func (r *pipelineResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 				MarkdownDescription: "The pipeline's list of tags. Only for `Buddy Enterprise`",
 				Optional:            true,
 			},
+			"tags_all": schema.SetAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "The pipeline's list of tags including the provider's `default_tags`",
+				Computed:            true,
+			},
 			"loop": schema.SetAttribute{
 				ElementType:         types.StringType,
 				MarkdownDescription: "Specify multiple variables to create a multi-dimensional matrix. A pipeline will run for each possible combination of the variables",
@@ -536,60 +559,7 @@ func (r *pipelineResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 			"trigger_condition": schema.SetNestedBlock{
 				MarkdownDescription: "The pipeline's list of trigger conditions",
 				NestedObject: schema.NestedBlockObject{
-					Attributes: map[string]schema.Attribute{
-						"condition": schema.StringAttribute{
-							Required: true,
-							Validators: []validator.String{
-								stringvalidator.OneOf(
-									buddy.PipelineTriggerConditionOnChange,
-									buddy.PipelineTriggerConditionOnChangeAtPath,
-									buddy.PipelineTriggerConditionVarIs,
-									buddy.PipelineTriggerConditionVarIsNot,
-									buddy.PipelineTriggerConditionVarContains,
-									buddy.PipelineTriggerConditionVarNotContains,
-									buddy.PipelineTriggerConditionDateTime,
-									buddy.PipelineTriggerConditionSuccessPipeline,
-									buddy.PipelineTriggerConditionTriggeringUserIsNotInGroup,
-									buddy.PipelineTriggerConditionTriggeringUserIsInGroup,
-									buddy.PipelineTriggerConditionTriggeringUserIs,
-									buddy.PipelineTriggerConditionTriggeringUserIsNot,
-								),
-							},
-						},
-						"paths": schema.SetAttribute{
-							ElementType: types.StringType,
-							Optional:    true,
-						},
-						"variable_key": schema.StringAttribute{
-							Optional: true,
-						},
-						"variable_value": schema.StringAttribute{
-							Optional: true,
-						},
-						"hours": schema.SetAttribute{
-							ElementType: types.Int64Type,
-							Optional:    true,
-						},
-						"days": schema.SetAttribute{
-							ElementType: types.Int64Type,
-							Optional:    true,
-						},
-						"timezone": schema.StringAttribute{
-							Optional: true,
-						},
-						"project_name": schema.StringAttribute{
-							Optional: true,
-						},
-						"pipeline_name": schema.StringAttribute{
-							Optional: true,
-						},
-						"trigger_user": schema.StringAttribute{
-							Optional: true,
-						},
-						"trigger_group": schema.StringAttribute{
-							Optional: true,
-						},
-					},
+					Attributes: util.ResourceTriggerConditionModelAttributes(),
 				},
 			},
 		},
@@ -600,7 +570,19 @@ func (r *pipelineResource) Configure(_ context.Context, req resource.ConfigureRe
 	if req.ProviderData == nil {
 		return
 	}
-	r.client = req.ProviderData.(*buddy.Client)
+	p := req.ProviderData.(*util.ProviderData)
+	r.client = p.Client
+	r.defaultDomain = p.Domain
+	r.defaultTags = p.DefaultTags
+}
+
+func (r *pipelineResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(util.ValidatePipelineConfig(ctx, &req.Config)...)
+}
+
+func (r *pipelineResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	util.ModifyPlanDomain(ctx, path.Root("domain"), r.defaultDomain, req, resp)
+	util.ModifyPlanTags(ctx, r.defaultTags, req, resp)
 }
 
 func (r *pipelineResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -643,14 +625,12 @@ func (r *pipelineResource) Create(ctx context.Context, req resource.CreateReques
 		}
 		ops.Loop = loop
 	}
-	if !data.Tags.IsNull() && !data.Tags.IsUnknown() {
-		tags, d := util.StringSetToApi(ctx, &data.Tags)
-		resp.Diagnostics.Append(d...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-		ops.Tags = tags
+	tags, d := util.TagsToApi(ctx, &req.Config, nil, &data.Tags, &data.TagsAll)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	ops.Tags = tags
 	if !data.Events.IsNull() && !data.Events.IsUnknown() {
 		events, d := util.EventsModelToApi(ctx, &data.Events)
 		resp.Diagnostics.Append(d...)
@@ -692,7 +672,7 @@ func (r *pipelineResource) Create(ctx context.Context, req resource.CreateReques
 	if !data.CloneDepth.IsNull() && !data.CloneDepth.IsUnknown() {
 		ops.CloneDepth = util.PointerInt(data.CloneDepth.ValueInt64())
 	}
-	if !data.Paused.IsNull() && !data.Paused.IsUnknown() {
+	if !data.Paused.IsNull() && !data.Paused.IsUnknown() && !data.IgnoreStatusChanges.ValueBool() {
 		ops.Paused = data.Paused.ValueBoolPointer()
 	}
 	if !data.PauseOnRepeatedFailures.IsNull() && !data.PauseOnRepeatedFailures.IsUnknown() {
@@ -749,10 +729,10 @@ func (r *pipelineResource) Create(ctx context.Context, req resource.CreateReques
 		}
 		ops.RemoteParameters = remoteParams
 	}
-	if !data.Disabled.IsNull() && !data.Disabled.IsUnknown() {
+	if !data.Disabled.IsNull() && !data.Disabled.IsUnknown() && !data.IgnoreStatusChanges.ValueBool() {
 		ops.Disabled = data.Disabled.ValueBoolPointer()
 	}
-	if !data.DisablingReason.IsNull() && !data.DisablingReason.IsUnknown() {
+	if !data.DisablingReason.IsNull() && !data.DisablingReason.IsUnknown() && !data.IgnoreStatusChanges.ValueBool() {
 		ops.DisabledReason = data.DisablingReason.ValueStringPointer()
 	}
 	if !data.TriggerConditions.IsNull() && !data.TriggerConditions.IsUnknown() {
@@ -768,7 +748,7 @@ func (r *pipelineResource) Create(ctx context.Context, req resource.CreateReques
 		resp.Diagnostics.Append(util.NewDiagnosticApiError("create pipeline", err))
 		return
 	}
-	resp.Diagnostics.Append(data.loadAPI(ctx, domain, projectName, pipeline)...)
+	resp.Diagnostics.Append(data.loadAPI(ctx, domain, projectName, pipeline, r.defaultTags)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -796,7 +776,7 @@ func (r *pipelineResource) Read(ctx context.Context, req resource.ReadRequest, r
 		resp.Diagnostics.Append(util.NewDiagnosticApiError("get pipeline", err))
 		return
 	}
-	resp.Diagnostics.Append(data.loadAPI(ctx, domain, projectName, pipeline)...)
+	resp.Diagnostics.Append(data.loadAPI(ctx, domain, projectName, pipeline, r.defaultTags)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -844,14 +824,12 @@ func (r *pipelineResource) Update(ctx context.Context, req resource.UpdateReques
 		}
 		ops.Loop = loop
 	}
-	if !data.Tags.IsNull() && !data.Tags.IsUnknown() {
-		tags, d := util.StringSetToApi(ctx, &data.Tags)
-		resp.Diagnostics.Append(d...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-		ops.Tags = tags
+	tags, d := util.TagsToApi(ctx, &req.Config, &req.State, &data.Tags, &data.TagsAll)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	ops.Tags = tags
 	if !data.Events.IsNull() && !data.Events.IsUnknown() {
 		events, d := util.EventsModelToApi(ctx, &data.Events)
 		resp.Diagnostics.Append(d...)
@@ -910,7 +888,7 @@ func (r *pipelineResource) Update(ctx context.Context, req resource.UpdateReques
 	if !data.CloneDepth.IsNull() && !data.CloneDepth.IsUnknown() {
 		ops.CloneDepth = util.PointerInt(data.CloneDepth.ValueInt64())
 	}
-	if !data.Paused.IsNull() && !data.Paused.IsUnknown() {
+	if !data.Paused.IsNull() && !data.Paused.IsUnknown() && !data.IgnoreStatusChanges.ValueBool() {
 		ops.Paused = data.Paused.ValueBoolPointer()
 	}
 	if !data.IgnoreFailOnProjectStatus.IsNull() && !data.IgnoreFailOnProjectStatus.IsUnknown() {
@@ -964,10 +942,10 @@ func (r *pipelineResource) Update(ctx context.Context, req resource.UpdateReques
 		}
 		ops.RemoteParameters = remoteParams
 	}
-	if !data.Disabled.IsNull() && !data.Disabled.IsUnknown() {
+	if !data.Disabled.IsNull() && !data.Disabled.IsUnknown() && !data.IgnoreStatusChanges.ValueBool() {
 		ops.Disabled = data.Disabled.ValueBoolPointer()
 	}
-	if !data.DisablingReason.IsNull() && !data.DisablingReason.IsUnknown() {
+	if !data.DisablingReason.IsNull() && !data.DisablingReason.IsUnknown() && !data.IgnoreStatusChanges.ValueBool() {
 		ops.DisabledReason = data.DisablingReason.ValueStringPointer()
 	}
 	pipeline, _, err := r.client.PipelineService.Update(domain, projectName, pipelineId, &ops)
@@ -975,7 +953,7 @@ func (r *pipelineResource) Update(ctx context.Context, req resource.UpdateReques
 		resp.Diagnostics.Append(util.NewDiagnosticApiError("update pipeline", err))
 		return
 	}
-	resp.Diagnostics.Append(data.loadAPI(ctx, domain, projectName, pipeline)...)
+	resp.Diagnostics.Append(data.loadAPI(ctx, domain, projectName, pipeline, r.defaultTags)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -999,6 +977,23 @@ func (r *pipelineResource) Delete(ctx context.Context, req resource.DeleteReques
 	}
 }
 
+// ImportState accepts `domain:project_name:pipeline_id` or `domain:project_name:pipeline_identifier`
 func (r *pipelineResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	domain, projectName, pipeline, err := util.DecomposeTripleId(req.ID)
+	if err != nil || util.IsNumericId(pipeline) {
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
+	pipelines, _, err := r.client.PipelineService.GetListAll(domain, projectName)
+	if err != nil {
+		resp.Diagnostics.Append(util.NewDiagnosticApiError("get pipelines", err))
+		return
+	}
+	for _, p := range pipelines.Pipelines {
+		if p.Identifier == pipeline {
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), util.ComposeTripleId(domain, projectName, strconv.Itoa(p.Id)))...)
+			return
+		}
+	}
+	resp.Diagnostics.Append(util.NewDiagnosticImportNotFound("pipeline", pipeline))
 }
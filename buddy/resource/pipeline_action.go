@@ -0,0 +1,435 @@
+package resource
+
+import (
+	"context"
+	"github.com/buddy/api-go-sdk/buddy"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"strconv"
+	"terraform-provider-buddy/buddy/util"
+)
+
+var (
+	_ resource.Resource                = &pipelineActionResource{}
+	_ resource.ResourceWithConfigure   = &pipelineActionResource{}
+	_ resource.ResourceWithImportState = &pipelineActionResource{}
+	_ resource.ResourceWithModifyPlan  = &pipelineActionResource{}
+)
+
+func NewPipelineActionResource() resource.Resource {
+	return &pipelineActionResource{}
+}
+
+type pipelineActionResource struct {
+	client        *buddy.Client
+	defaultDomain string
+}
+
+type pipelineActionResourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	Domain            types.String `tfsdk:"domain"`
+	ProjectName       types.String `tfsdk:"project_name"`
+	PipelineId        types.Int64  `tfsdk:"pipeline_id"`
+	ActionId          types.Int64  `tfsdk:"action_id"`
+	HtmlUrl           types.String `tfsdk:"html_url"`
+	Name              types.String `tfsdk:"name"`
+	Type              types.String `tfsdk:"type"`
+	AfterActionId     types.Int64  `tfsdk:"after_action_id"`
+	TriggerTime       types.String `tfsdk:"trigger_time"`
+	ExecuteCommands   types.List   `tfsdk:"execute_commands"`
+	Shell             types.String `tfsdk:"shell"`
+	DockerImageName   types.String `tfsdk:"docker_image_name"`
+	DockerImageTag    types.String `tfsdk:"docker_image_tag"`
+	Disabled          types.Bool   `tfsdk:"disabled"`
+	Variables         types.Set    `tfsdk:"variable"`
+	TriggerConditions types.Set    `tfsdk:"trigger_condition"`
+}
+
+func (r *pipelineActionResourceModel) decomposeId() (string, string, int, int, error) {
+	domain, projectName, pid, aid, err := util.DecomposeQuadrupleId(r.ID.ValueString())
+	if err != nil {
+		return "", "", 0, 0, err
+	}
+	pipelineId, err := strconv.Atoi(pid)
+	if err != nil {
+		return "", "", 0, 0, err
+	}
+	actionId, err := strconv.Atoi(aid)
+	if err != nil {
+		return "", "", 0, 0, err
+	}
+	return domain, projectName, pipelineId, actionId, nil
+}
+
+func (r *pipelineActionResourceModel) loadAPI(ctx context.Context, domain string, projectName string, pipelineId int, action *buddy.PipelineAction) diag.Diagnostics {
+	var diags diag.Diagnostics
+	r.ID = types.StringValue(util.ComposeQuadrupleId(domain, projectName, strconv.Itoa(pipelineId), strconv.Itoa(action.Id)))
+	r.Domain = types.StringValue(domain)
+	r.ProjectName = types.StringValue(projectName)
+	r.PipelineId = types.Int64Value(int64(pipelineId))
+	r.ActionId = types.Int64Value(int64(action.Id))
+	r.HtmlUrl = types.StringValue(action.HtmlUrl)
+	r.Name = types.StringValue(action.Name)
+	r.Type = types.StringValue(action.Type)
+	r.TriggerTime = types.StringValue(action.TriggerTime)
+	r.Shell = types.StringValue(action.Shell)
+	r.DockerImageName = types.StringValue(action.DockerImageName)
+	r.DockerImageTag = types.StringValue(action.DockerImageTag)
+	r.Disabled = types.BoolValue(action.Disabled)
+	// keep configured empty list
+	if len(action.ExecuteCommands) > 0 || (!r.ExecuteCommands.IsNull() && !r.ExecuteCommands.IsUnknown()) {
+		ec, d := types.ListValueFrom(ctx, types.StringType, &action.ExecuteCommands)
+		diags.Append(d...)
+		r.ExecuteCommands = ec
+	} else {
+		r.ExecuteCommands = types.ListNull(types.StringType)
+	}
+	variables, d := util.PipelineActionVariablesModelFromApi(ctx, &r.Variables, action.Variables)
+	diags.Append(d...)
+	r.Variables = variables
+	triggerConditions, d := util.TriggerConditionsModelFromApi(ctx, &r.TriggerConditions, action.TriggerConditions)
+	diags.Append(d...)
+	r.TriggerConditions = triggerConditions
+	return diags
+}
+
+// afterActionId returns ID of the action which precedes the action in the pipeline or null if it's the first one
+func (r *pipelineActionResource) afterActionId(domain string, projectName string, pipelineId int, actionId int) (types.Int64, error) {
+	actions, _, err := r.client.PipelineActionService.GetList(domain, projectName, pipelineId)
+	if err != nil {
+		return types.Int64Null(), err
+	}
+	for i, a := range actions.Actions {
+		if a.Id == actionId && i > 0 {
+			return types.Int64Value(int64(actions.Actions[i-1].Id)), nil
+		}
+	}
+	return types.Int64Null(), nil
+}
+
+// lastActionId returns ID of the last action in the pipeline other than the action or 0 if there is none
+func (r *pipelineActionResource) lastActionId(domain string, projectName string, pipelineId int, actionId int) (int, error) {
+	actions, _, err := r.client.PipelineActionService.GetList(domain, projectName, pipelineId)
+	if err != nil {
+		return 0, err
+	}
+	for i := len(actions.Actions) - 1; i >= 0; i-- {
+		if actions.Actions[i].Id != actionId {
+			return actions.Actions[i].Id, nil
+		}
+	}
+	return 0, nil
+}
+
+// toApi sets ops from the plan. Variables and run conditions present in the state but removed from the plan are cleared
+func (r *pipelineActionResourceModel) toApi(ctx context.Context, ops *buddy.PipelineActionOps, state *pipelineActionResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	ops.Name = r.Name.ValueStringPointer()
+	if !r.AfterActionId.IsNull() && !r.AfterActionId.IsUnknown() {
+		ops.AfterActionId = util.PointerInt(r.AfterActionId.ValueInt64())
+	}
+	if !r.TriggerTime.IsNull() && !r.TriggerTime.IsUnknown() {
+		ops.TriggerTime = r.TriggerTime.ValueStringPointer()
+	}
+	if !r.ExecuteCommands.IsNull() && !r.ExecuteCommands.IsUnknown() {
+		ec, d := util.StringListToApi(ctx, &r.ExecuteCommands)
+		diags.Append(d...)
+		ops.ExecuteCommands = ec
+	}
+	if !r.Shell.IsNull() && !r.Shell.IsUnknown() {
+		ops.Shell = r.Shell.ValueStringPointer()
+	}
+	if !r.DockerImageName.IsNull() && !r.DockerImageName.IsUnknown() {
+		ops.DockerImageName = r.DockerImageName.ValueStringPointer()
+	}
+	if !r.DockerImageTag.IsNull() && !r.DockerImageTag.IsUnknown() {
+		ops.DockerImageTag = r.DockerImageTag.ValueStringPointer()
+	}
+	if !r.Disabled.IsNull() && !r.Disabled.IsUnknown() {
+		ops.Disabled = r.Disabled.ValueBoolPointer()
+	}
+	if !r.Variables.IsNull() && !r.Variables.IsUnknown() {
+		variables, d := util.PipelineActionVariablesModelToApi(ctx, &r.Variables)
+		diags.Append(d...)
+		ops.Variables = variables
+	} else if state != nil && !state.Variables.IsNull() && len(state.Variables.Elements()) > 0 {
+		ops.Variables = &[]*buddy.Variable{}
+	}
+	if !r.TriggerConditions.IsNull() && !r.TriggerConditions.IsUnknown() {
+		tc, d := util.TriggerConditionsModelToApi(ctx, &r.TriggerConditions)
+		diags.Append(d...)
+		ops.TriggerConditions = tc
+	} else if state != nil && !state.TriggerConditions.IsNull() && len(state.TriggerConditions.Elements()) > 0 {
+		ops.TriggerConditions = &[]*buddy.PipelineTriggerCondition{}
+	}
+	return diags
+}
+
+func (r *pipelineActionResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pipeline_action"
+}
+
+func (r *pipelineActionResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Create and manage a pipeline action\n\n" +
+			"Token scopes required: `WORKSPACE`, `EXECUTION_MANAGE`, `EXECUTION_INFO`",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The Terraform resource identifier for this item",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"domain": schema.StringAttribute{
+				MarkdownDescription: "The workspace's URL handle. Defaults to the provider's `domain`",
+				Optional:            true,
+				Computed:            true,
+				Validators:          util.StringValidatorsDomain(),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"project_name": schema.StringAttribute{
+				MarkdownDescription: "The project's name",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"pipeline_id": schema.Int64Attribute{
+				MarkdownDescription: "The pipeline's ID",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"action_id": schema.Int64Attribute{
+				MarkdownDescription: "The action's ID",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"html_url": schema.StringAttribute{
+				MarkdownDescription: "The action's URL",
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The action's name",
+				Required:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "The action's type. For example: `BUILD`, `SSH_COMMAND`, `SFTP`, `DOCKERFILE`",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"after_action_id": schema.Int64Attribute{
+				MarkdownDescription: "The ID of the action after which this action is placed. If not set the action is added at the end of the pipeline. Removing it moves the action to the end of the pipeline",
+				Optional:            true,
+			},
+			"trigger_time": schema.StringAttribute{
+				MarkdownDescription: "Specifies when the action should be run. Allowed: `ON_EVERY_EXECUTION`, `ON_FAILURE`, `ON_BACK_TO_SUCCESS`",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						buddy.PipelineActionTriggerTimeOnEveryExecution,
+						buddy.PipelineActionTriggerTimeOnFailure,
+						buddy.PipelineActionTriggerTimeOnBackToSuccess,
+					),
+				},
+			},
+			"execute_commands": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "The commands that will be executed",
+				Optional:            true,
+			},
+			"shell": schema.StringAttribute{
+				MarkdownDescription: "The shell that will be used to execute commands. For example: `SH`, `BASH`",
+				Optional:            true,
+				Computed:            true,
+			},
+			"docker_image_name": schema.StringAttribute{
+				MarkdownDescription: "The name of the Docker image the action is run in",
+				Optional:            true,
+				Computed:            true,
+			},
+			"docker_image_tag": schema.StringAttribute{
+				MarkdownDescription: "The tag of the Docker image the action is run in",
+				Optional:            true,
+				Computed:            true,
+			},
+			"disabled": schema.BoolAttribute{
+				MarkdownDescription: "Defines whether or not the action is disabled",
+				Optional:            true,
+				Computed:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			// singular form for compatibility
+			"variable": schema.SetNestedBlock{
+				MarkdownDescription: "The action's list of variables",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: util.ResourcePipelineActionVariableModelAttributes(),
+				},
+			},
+			// singular form for compatibility
+			"trigger_condition": schema.SetNestedBlock{
+				MarkdownDescription: "The action's list of run conditions",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: util.ResourceTriggerConditionModelAttributes(),
+				},
+			},
+		},
+	}
+}
+
+func (r *pipelineActionResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	p := req.ProviderData.(*util.ProviderData)
+	r.client = p.Client
+	r.defaultDomain = p.Domain
+}
+
+func (r *pipelineActionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	util.ModifyPlanDomain(ctx, path.Root("domain"), r.defaultDomain, req, resp)
+}
+
+func (r *pipelineActionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *pipelineActionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	domain := data.Domain.ValueString()
+	projectName := data.ProjectName.ValueString()
+	pipelineId := int(data.PipelineId.ValueInt64())
+	ops := buddy.PipelineActionOps{
+		Type: data.Type.ValueStringPointer(),
+	}
+	resp.Diagnostics.Append(data.toApi(ctx, &ops, nil)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	action, _, err := r.client.PipelineActionService.Create(domain, projectName, pipelineId, &ops)
+	if err != nil {
+		resp.Diagnostics.Append(util.NewDiagnosticApiError("create pipeline action", err))
+		return
+	}
+	resp.Diagnostics.Append(data.loadAPI(ctx, domain, projectName, pipelineId, action)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *pipelineActionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *pipelineActionResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	domain, projectName, pipelineId, actionId, err := data.decomposeId()
+	if err != nil {
+		resp.Diagnostics.Append(util.NewDiagnosticDecomposeError("pipeline action", err))
+		return
+	}
+	action, httpResp, err := r.client.PipelineActionService.Get(domain, projectName, pipelineId, actionId)
+	if err != nil {
+		if util.IsResourceNotFound(httpResp, err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(util.NewDiagnosticApiError("get pipeline action", err))
+		return
+	}
+	// position is compared only when managed or imported
+	if !data.AfterActionId.IsNull() || data.Name.IsNull() {
+		data.AfterActionId, err = r.afterActionId(domain, projectName, pipelineId, actionId)
+		if err != nil {
+			resp.Diagnostics.Append(util.NewDiagnosticApiError("get pipeline actions", err))
+			return
+		}
+	}
+	resp.Diagnostics.Append(data.loadAPI(ctx, domain, projectName, pipelineId, action)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *pipelineActionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *pipelineActionResourceModel
+	var state *pipelineActionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	domain, projectName, pipelineId, actionId, err := data.decomposeId()
+	if err != nil {
+		resp.Diagnostics.Append(util.NewDiagnosticDecomposeError("pipeline action", err))
+		return
+	}
+	ops := buddy.PipelineActionOps{}
+	resp.Diagnostics.Append(data.toApi(ctx, &ops, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// removed position moves the action back to the end of the pipeline
+	if data.AfterActionId.IsNull() && !state.AfterActionId.IsNull() {
+		lastId, err := r.lastActionId(domain, projectName, pipelineId, actionId)
+		if err != nil {
+			resp.Diagnostics.Append(util.NewDiagnosticApiError("get pipeline actions", err))
+			return
+		}
+		if lastId > 0 {
+			ops.AfterActionId = &lastId
+		}
+	}
+	action, _, err := r.client.PipelineActionService.Update(domain, projectName, pipelineId, actionId, &ops)
+	if err != nil {
+		resp.Diagnostics.Append(util.NewDiagnosticApiError("update pipeline action", err))
+		return
+	}
+	resp.Diagnostics.Append(data.loadAPI(ctx, domain, projectName, pipelineId, action)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *pipelineActionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *pipelineActionResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	domain, projectName, pipelineId, actionId, err := data.decomposeId()
+	if err != nil {
+		resp.Diagnostics.Append(util.NewDiagnosticDecomposeError("pipeline action", err))
+		return
+	}
+	_, err = r.client.PipelineActionService.Delete(domain, projectName, pipelineId, actionId)
+	if err != nil {
+		resp.Diagnostics.Append(util.NewDiagnosticApiError("delete pipeline action", err))
+	}
+}
+
+func (r *pipelineActionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
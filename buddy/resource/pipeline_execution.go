@@ -0,0 +1,400 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"github.com/buddy/api-go-sdk/buddy"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int32default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"strconv"
+	"terraform-provider-buddy/buddy/util"
+	"time"
+)
+
+var (
+	_ resource.Resource                = &pipelineExecutionResource{}
+	_ resource.ResourceWithConfigure   = &pipelineExecutionResource{}
+	_ resource.ResourceWithImportState = &pipelineExecutionResource{}
+	_ resource.ResourceWithModifyPlan  = &pipelineExecutionResource{}
+)
+
+// pipelineExecutionPollInterval is the time between checks of the execution's status
+const pipelineExecutionPollInterval = 5 * time.Second
+
+func NewPipelineExecutionResource() resource.Resource {
+	return &pipelineExecutionResource{}
+}
+
+type pipelineExecutionResource struct {
+	client        *buddy.Client
+	defaultDomain string
+}
+
+type pipelineExecutionResourceModel struct {
+	ID                   types.String `tfsdk:"id"`
+	Domain               types.String `tfsdk:"domain"`
+	ProjectName          types.String `tfsdk:"project_name"`
+	PipelineId           types.Int64  `tfsdk:"pipeline_id"`
+	Branch               types.String `tfsdk:"branch"`
+	Revision             types.String `tfsdk:"revision"`
+	Comment              types.String `tfsdk:"comment"`
+	ClearCache           types.Bool   `tfsdk:"clear_cache"`
+	Variables            types.Set    `tfsdk:"variable"`
+	Triggers             types.Map    `tfsdk:"triggers"`
+	WaitForFinish        types.Bool   `tfsdk:"wait_for_finish"`
+	WaitForFinishTimeout types.Int32  `tfsdk:"wait_for_finish_timeout"`
+	ExecutionId          types.Int64  `tfsdk:"execution_id"`
+	Status               types.String `tfsdk:"status"`
+	StartDate            types.String `tfsdk:"start_date"`
+	FinishDate           types.String `tfsdk:"finish_date"`
+	HtmlUrl              types.String `tfsdk:"html_url"`
+}
+
+func (r *pipelineExecutionResourceModel) decomposeId() (string, string, int, int, error) {
+	domain, projectName, pid, eid, err := util.DecomposeQuadrupleId(r.ID.ValueString())
+	if err != nil {
+		return "", "", 0, 0, err
+	}
+	pipelineId, err := strconv.Atoi(pid)
+	if err != nil {
+		return "", "", 0, 0, err
+	}
+	executionId, err := strconv.Atoi(eid)
+	if err != nil {
+		return "", "", 0, 0, err
+	}
+	return domain, projectName, pipelineId, executionId, nil
+}
+
+func (r *pipelineExecutionResourceModel) loadAPI(domain string, projectName string, pipelineId int, execution *buddy.Execution) {
+	r.ID = types.StringValue(util.ComposeQuadrupleId(domain, projectName, strconv.Itoa(pipelineId), strconv.Itoa(execution.Id)))
+	r.Domain = types.StringValue(domain)
+	r.ProjectName = types.StringValue(projectName)
+	r.PipelineId = types.Int64Value(int64(pipelineId))
+	r.ExecutionId = types.Int64Value(int64(execution.Id))
+	r.Status = types.StringValue(execution.Status)
+	r.StartDate = types.StringValue(execution.StartDate)
+	r.FinishDate = types.StringValue(execution.FinishDate)
+	r.HtmlUrl = types.StringValue(execution.HtmlUrl)
+	if r.WaitForFinish.IsNull() || r.WaitForFinish.IsUnknown() {
+		r.WaitForFinish = types.BoolValue(true)
+	}
+	if r.WaitForFinishTimeout.IsNull() || r.WaitForFinishTimeout.IsUnknown() {
+		r.WaitForFinishTimeout = types.Int32Value(600)
+	}
+}
+
+func (r *pipelineExecutionResourceModel) toApi(ctx context.Context, ops *buddy.ExecutionOps) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if !r.Branch.IsNull() && !r.Branch.IsUnknown() {
+		ops.Branch = &buddy.ExecutionBranch{
+			Name: r.Branch.ValueString(),
+		}
+	}
+	if !r.Revision.IsNull() && !r.Revision.IsUnknown() {
+		ops.ToRevision = &buddy.ExecutionRevision{
+			Revision: r.Revision.ValueString(),
+		}
+	}
+	if !r.Comment.IsNull() && !r.Comment.IsUnknown() {
+		ops.Comment = r.Comment.ValueStringPointer()
+	}
+	if !r.ClearCache.IsNull() && !r.ClearCache.IsUnknown() {
+		ops.ClearCache = r.ClearCache.ValueBoolPointer()
+	}
+	if !r.Variables.IsNull() && !r.Variables.IsUnknown() {
+		variables, d := util.PipelineExecutionVariablesModelToApi(ctx, &r.Variables)
+		diags.Append(d...)
+		ops.Variables = variables
+	}
+	return diags
+}
+
+func (r *pipelineExecutionResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pipeline_execution"
+}
+
+func (r *pipelineExecutionResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Run a pipeline and optionally wait until the run is finished. Changing any of the run's arguments or `triggers` runs the pipeline again\n\n" +
+			"Token scopes required: `WORKSPACE`, `EXECUTION_RUN`, `EXECUTION_INFO`",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The Terraform resource identifier for this item",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"domain": schema.StringAttribute{
+				MarkdownDescription: "The workspace's URL handle. Defaults to the provider's `domain`",
+				Optional:            true,
+				Computed:            true,
+				Validators:          util.StringValidatorsDomain(),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"project_name": schema.StringAttribute{
+				MarkdownDescription: "The project's name",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"pipeline_id": schema.Int64Attribute{
+				MarkdownDescription: "The pipeline's ID",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"branch": schema.StringAttribute{
+				MarkdownDescription: "The name of the branch to run the pipeline on. Defaults to the pipeline's branch",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"revision": schema.StringAttribute{
+				MarkdownDescription: "The revision to run the pipeline on. Defaults to the branch's HEAD",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"comment": schema.StringAttribute{
+				MarkdownDescription: "The run's comment",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"clear_cache": schema.BoolAttribute{
+				MarkdownDescription: "Defines whether or not to clear the pipeline's cache before the run",
+				Optional:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary map of values that, when changed, runs the pipeline again",
+				ElementType:         types.StringType,
+				Optional:            true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"wait_for_finish": schema.BoolAttribute{
+				MarkdownDescription: "Wait until the run is finished. If the run fails or is terminated the apply fails and the resource is tainted",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"wait_for_finish_timeout": schema.Int32Attribute{
+				MarkdownDescription: "Seconds to wait until the run is finished",
+				Optional:            true,
+				Computed:            true,
+				Default:             int32default.StaticInt32(600),
+				Validators: []validator.Int32{
+					int32validator.AtLeast(1),
+				},
+			},
+			"execution_id": schema.Int64Attribute{
+				MarkdownDescription: "The run's ID",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "The run's status",
+				Computed:            true,
+			},
+			"start_date": schema.StringAttribute{
+				MarkdownDescription: "The run's start date",
+				Computed:            true,
+			},
+			"finish_date": schema.StringAttribute{
+				MarkdownDescription: "The run's finish date",
+				Computed:            true,
+			},
+			"html_url": schema.StringAttribute{
+				MarkdownDescription: "The run's URL",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			// singular form for compatibility
+			"variable": schema.SetNestedBlock{
+				MarkdownDescription: "The run's list of variables",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: util.ResourcePipelineExecutionVariableModelAttributes(),
+				},
+			},
+		},
+	}
+}
+
+func (r *pipelineExecutionResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	p := req.ProviderData.(*util.ProviderData)
+	r.client = p.Client
+	r.defaultDomain = p.Domain
+}
+
+func (r *pipelineExecutionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	util.ModifyPlanDomain(ctx, path.Root("domain"), r.defaultDomain, req, resp)
+}
+
+func (r *pipelineExecutionResource) waitForFinish(ctx context.Context, domain string, projectName string, pipelineId int, executionId int, timeout int32) (*buddy.Execution, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	deadline := time.Now().Add(time.Duration(timeout) * time.Second)
+	for {
+		execution, _, err := r.client.ExecutionService.Get(domain, projectName, pipelineId, executionId)
+		if err != nil {
+			diags.Append(util.NewDiagnosticApiError("get pipeline execution", err))
+			return nil, diags
+		}
+		if util.IsPipelineExecutionFinished(execution.Status) {
+			return execution, diags
+		}
+		if time.Now().After(deadline) {
+			diags.Append(util.NewDiagnosticPipelineExecutionTimeout(fmt.Sprintf("pipeline execution is still %s:\n%s", execution.Status, execution.HtmlUrl)))
+			return execution, diags
+		}
+		select {
+		case <-ctx.Done():
+			diags.Append(util.NewDiagnosticPipelineExecutionTimeout(ctx.Err().Error()))
+			return execution, diags
+		case <-time.After(pipelineExecutionPollInterval):
+		}
+	}
+}
+
+func (r *pipelineExecutionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *pipelineExecutionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	domain := data.Domain.ValueString()
+	projectName := data.ProjectName.ValueString()
+	pipelineId := int(data.PipelineId.ValueInt64())
+	tflog.Debug(ctx, "Running pipeline", map[string]interface{}{
+		"domain":       domain,
+		"project_name": projectName,
+		"pipeline_id":  pipelineId,
+	})
+	ops := buddy.ExecutionOps{}
+	resp.Diagnostics.Append(data.toApi(ctx, &ops)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	execution, _, err := r.client.ExecutionService.Run(domain, projectName, pipelineId, &ops)
+	if err != nil {
+		resp.Diagnostics.Append(util.NewDiagnosticApiError("run pipeline", err))
+		return
+	}
+	if data.WaitForFinish.ValueBool() {
+		e, d := r.waitForFinish(ctx, domain, projectName, pipelineId, execution.Id, data.WaitForFinishTimeout.ValueInt32())
+		resp.Diagnostics.Append(d...)
+		if e != nil {
+			execution = e
+		}
+		if !d.HasError() && util.IsPipelineExecutionFailed(execution.Status) {
+			resp.Diagnostics.Append(util.NewDiagnosticPipelineExecutionFailed(execution.Status, execution.HtmlUrl))
+		}
+	}
+	// state is saved even if the run failed so the resource is tainted and the next apply runs the pipeline again
+	data.loadAPI(domain, projectName, pipelineId, execution)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *pipelineExecutionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *pipelineExecutionResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	domain, projectName, pipelineId, executionId, err := data.decomposeId()
+	if err != nil {
+		resp.Diagnostics.Append(util.NewDiagnosticDecomposeError("pipeline execution", err))
+		return
+	}
+	tflog.Debug(ctx, "Reading pipeline execution", map[string]interface{}{
+		"domain":       domain,
+		"project_name": projectName,
+		"pipeline_id":  pipelineId,
+		"execution_id": executionId,
+	})
+	execution, httpResp, err := r.client.ExecutionService.Get(domain, projectName, pipelineId, executionId)
+	if err != nil {
+		if util.IsResourceNotFound(httpResp, err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(util.NewDiagnosticApiError("get pipeline execution", err))
+		return
+	}
+	data.loadAPI(domain, projectName, pipelineId, execution)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *pipelineExecutionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// only wait_for_finish and wait_for_finish_timeout can be updated in place
+	var data *pipelineExecutionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	domain, projectName, pipelineId, executionId, err := data.decomposeId()
+	if err != nil {
+		resp.Diagnostics.Append(util.NewDiagnosticDecomposeError("pipeline execution", err))
+		return
+	}
+	execution, _, err := r.client.ExecutionService.Get(domain, projectName, pipelineId, executionId)
+	if err != nil {
+		resp.Diagnostics.Append(util.NewDiagnosticApiError("get pipeline execution", err))
+		return
+	}
+	if data.WaitForFinish.ValueBool() && !util.IsPipelineExecutionFinished(execution.Status) {
+		e, d := r.waitForFinish(ctx, domain, projectName, pipelineId, executionId, data.WaitForFinishTimeout.ValueInt32())
+		resp.Diagnostics.Append(d...)
+		if e != nil {
+			execution = e
+		}
+		if !d.HasError() && util.IsPipelineExecutionFailed(execution.Status) {
+			resp.Diagnostics.Append(util.NewDiagnosticPipelineExecutionFailed(execution.Status, execution.HtmlUrl))
+		}
+	}
+	data.loadAPI(domain, projectName, pipelineId, execution)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *pipelineExecutionResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+	// do nothing, finished runs stay in the pipeline's history
+}
+
+func (r *pipelineExecutionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
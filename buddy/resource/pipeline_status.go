@@ -0,0 +1,392 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"github.com/buddy/api-go-sdk/buddy"
+	"github.com/hashicorp/terraform-plugin-framework-validators/boolvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"slices"
+	"strconv"
+	"strings"
+	"terraform-provider-buddy/buddy/util"
+)
+
+var (
+	_ resource.Resource                   = &pipelineStatusResource{}
+	_ resource.ResourceWithConfigure      = &pipelineStatusResource{}
+	_ resource.ResourceWithImportState    = &pipelineStatusResource{}
+	_ resource.ResourceWithModifyPlan     = &pipelineStatusResource{}
+	_ resource.ResourceWithValidateConfig = &pipelineStatusResource{}
+)
+
+// pipelineStatusTagsPrefix marks the pipelines selector in ID as tags instead of pipelines IDs
+const pipelineStatusTagsPrefix = "tags:"
+
+type pipelineStatusResourceModel struct {
+	ID                  types.String `tfsdk:"id"`
+	Domain              types.String `tfsdk:"domain"`
+	ProjectName         types.String `tfsdk:"project_name"`
+	PipelineIds         types.Set    `tfsdk:"pipeline_ids"`
+	Tags                types.Set    `tfsdk:"tags"`
+	Paused              types.Bool   `tfsdk:"paused"`
+	Disabled            types.Bool   `tfsdk:"disabled"`
+	DisablingReason     types.String `tfsdk:"disabling_reason"`
+	SelectedPipelineIds types.Set    `tfsdk:"selected_pipeline_ids"`
+}
+
+// decomposeId returns pipelines selected by IDs or tags from `domain:project_name:1,2` or `domain:project_name:tags:a,b`
+func (r *pipelineStatusResourceModel) decomposeId() (string, string, []int, []string, error) {
+	domain, projectName, selector, err := util.DecomposeTripleId(r.ID.ValueString())
+	if err != nil {
+		return "", "", nil, nil, err
+	}
+	if strings.HasPrefix(selector, pipelineStatusTagsPrefix) {
+		tags := strings.Split(strings.TrimPrefix(selector, pipelineStatusTagsPrefix), ",")
+		if slices.Contains(tags, "") {
+			return "", "", nil, nil, errors.New("empty tag")
+		}
+		return domain, projectName, nil, tags, nil
+	}
+	var pipelineIds []int
+	for _, pid := range strings.Split(selector, ",") {
+		pipelineId, err := strconv.Atoi(pid)
+		if err != nil {
+			return "", "", nil, nil, err
+		}
+		pipelineIds = append(pipelineIds, pipelineId)
+	}
+	return domain, projectName, pipelineIds, nil, nil
+}
+
+func (r *pipelineStatusResourceModel) selectors(ctx context.Context) ([]int, []string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var pipelineIds []int
+	var tags []string
+	if !r.PipelineIds.IsNull() && !r.PipelineIds.IsUnknown() {
+		diags.Append(r.PipelineIds.ElementsAs(ctx, &pipelineIds, false)...)
+	}
+	if !r.Tags.IsNull() && !r.Tags.IsUnknown() {
+		diags.Append(r.Tags.ElementsAs(ctx, &tags, false)...)
+	}
+	return pipelineIds, tags, diags
+}
+
+func (r *pipelineStatusResourceModel) loadAPI(ctx context.Context, domain string, projectName string, pipelineIds []int, tags []string, pipelines []*buddy.Pipeline) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var selector string
+	if len(tags) > 0 {
+		slices.Sort(tags)
+		selector = pipelineStatusTagsPrefix + strings.Join(tags, ",")
+		t, d := types.SetValueFrom(ctx, types.StringType, &tags)
+		diags.Append(d...)
+		r.Tags = t
+		r.PipelineIds = types.SetNull(types.Int64Type)
+	} else {
+		slices.Sort(pipelineIds)
+		ids := make([]string, len(pipelineIds))
+		for i, id := range pipelineIds {
+			ids[i] = strconv.Itoa(id)
+		}
+		selector = strings.Join(ids, ",")
+		p, d := types.SetValueFrom(ctx, types.Int64Type, &pipelineIds)
+		diags.Append(d...)
+		r.PipelineIds = p
+		r.Tags = types.SetNull(types.StringType)
+	}
+	r.ID = types.StringValue(util.ComposeTripleId(domain, projectName, selector))
+	r.Domain = types.StringValue(domain)
+	r.ProjectName = types.StringValue(projectName)
+	selected := make([]int, len(pipelines))
+	var paused, disabled []bool
+	var reasons []string
+	for i, p := range pipelines {
+		selected[i] = p.Id
+		paused = append(paused, p.Paused)
+		disabled = append(disabled, p.Disabled)
+		if p.Disabled {
+			reasons = append(reasons, p.DisabledReason)
+		}
+	}
+	s, d := types.SetValueFrom(ctx, types.Int64Type, &selected)
+	diags.Append(d...)
+	r.SelectedPipelineIds = s
+	// imported resource has no status to compare with
+	imported := r.Paused.IsNull() && r.Disabled.IsNull()
+	if len(pipelines) > 0 {
+		if imported || !r.Paused.IsNull() {
+			r.Paused = pipelineStatusBoolFromApi(r.Paused, paused)
+		}
+		if imported || !r.Disabled.IsNull() {
+			r.Disabled = pipelineStatusBoolFromApi(r.Disabled, disabled)
+		}
+		if !r.DisablingReason.IsNull() && r.Disabled.ValueBool() {
+			r.DisablingReason = pipelineStatusStringFromApi(reasons)
+		}
+	}
+	return diags
+}
+
+// pipelineStatusBoolFromApi returns status shared by all pipelines or the opposite of the current one so differences are planned
+func pipelineStatusBoolFromApi(current types.Bool, values []bool) types.Bool {
+	for _, v := range values[1:] {
+		if v != values[0] {
+			return types.BoolValue(!current.ValueBool())
+		}
+	}
+	return types.BoolValue(values[0])
+}
+
+// pipelineStatusStringFromApi returns value shared by all pipelines or empty string which differs from any configured one
+func pipelineStatusStringFromApi(values []string) types.String {
+	if len(values) == 0 {
+		return types.StringValue("")
+	}
+	for _, v := range values[1:] {
+		if v != values[0] {
+			return types.StringValue("")
+		}
+	}
+	return types.StringValue(values[0])
+}
+
+func NewPipelineStatusResource() resource.Resource {
+	return &pipelineStatusResource{}
+}
+
+type pipelineStatusResource struct {
+	client        *buddy.Client
+	defaultDomain string
+}
+
+func (r *pipelineStatusResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pipeline_status"
+}
+
+func (r *pipelineStatusResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	p := req.ProviderData.(*util.ProviderData)
+	r.client = p.Client
+	r.defaultDomain = p.Domain
+}
+
+func (r *pipelineStatusResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var disabled types.Bool
+	var reason types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("disabled"), &disabled)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("disabling_reason"), &reason)...)
+	if resp.Diagnostics.HasError() || reason.IsNull() || disabled.IsUnknown() {
+		return
+	}
+	if !disabled.ValueBool() {
+		resp.Diagnostics.AddAttributeError(path.Root("disabling_reason"), "Invalid attribute combination", "`disabling_reason` can be set only when `disabled` is true")
+	}
+}
+
+func (r *pipelineStatusResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	util.ModifyPlanDomain(ctx, path.Root("domain"), r.defaultDomain, req, resp)
+}
+
+// getPipelines returns pipelines selected by IDs or by any of the tags
+func (r *pipelineStatusResource) getPipelines(domain string, projectName string, pipelineIds []int, tags []string) ([]*buddy.Pipeline, error) {
+	pipelines, _, err := r.client.PipelineService.GetListAll(domain, projectName)
+	if err != nil {
+		return nil, err
+	}
+	var result []*buddy.Pipeline
+	for _, p := range pipelines.Pipelines {
+		if slices.Contains(pipelineIds, p.Id) || slices.ContainsFunc(p.Tags, func(t string) bool {
+			return slices.Contains(tags, t)
+		}) {
+			result = append(result, p)
+		}
+	}
+	return result, nil
+}
+
+func (r *pipelineStatusResource) update(ctx context.Context, diag *diag.Diagnostics, plan *tfsdk.Plan, state *tfsdk.State) {
+	var data *pipelineStatusResourceModel
+	diag.Append(plan.Get(ctx, &data)...)
+	if diag.HasError() {
+		return
+	}
+	domain := data.Domain.ValueString()
+	projectName := data.ProjectName.ValueString()
+	pipelineIds, tags, d := data.selectors(ctx)
+	diag.Append(d...)
+	if diag.HasError() {
+		return
+	}
+	pipelines, err := r.getPipelines(domain, projectName, pipelineIds, tags)
+	if err != nil {
+		diag.Append(util.NewDiagnosticApiError("get pipelines", err))
+		return
+	}
+	if len(pipelines) == 0 || (len(pipelineIds) > 0 && len(pipelines) != len(pipelineIds)) {
+		diag.Append(util.NewDiagnosticApiNotFound("pipeline"))
+		return
+	}
+	for i, p := range pipelines {
+		ops := buddy.PipelineOps{}
+		changed := false
+		if !data.Paused.IsNull() && data.Paused.ValueBool() != p.Paused {
+			ops.Paused = data.Paused.ValueBoolPointer()
+			changed = true
+		}
+		if !data.Disabled.IsNull() && data.Disabled.ValueBool() != p.Disabled {
+			ops.Disabled = data.Disabled.ValueBoolPointer()
+			changed = true
+		}
+		if !data.DisablingReason.IsNull() && data.DisablingReason.ValueString() != p.DisabledReason {
+			ops.DisabledReason = data.DisablingReason.ValueStringPointer()
+			changed = true
+		}
+		if !changed {
+			continue
+		}
+		ops.Name = &p.Name
+		pipeline, _, err := r.client.PipelineService.Update(domain, projectName, p.Id, &ops)
+		if err != nil {
+			diag.Append(util.NewDiagnosticApiError("update pipeline", err))
+			return
+		}
+		pipelines[i] = pipeline
+	}
+	diag.Append(data.loadAPI(ctx, domain, projectName, pipelineIds, tags, pipelines)...)
+	if diag.HasError() {
+		return
+	}
+	diag.Append(state.Set(ctx, &data)...)
+}
+
+func (r *pipelineStatusResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	r.update(ctx, &resp.Diagnostics, &req.Plan, &resp.State)
+}
+
+func (r *pipelineStatusResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *pipelineStatusResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	domain, projectName, pipelineIds, tags, err := data.decomposeId()
+	if err != nil {
+		resp.Diagnostics.Append(util.NewDiagnosticDecomposeError("pipeline status", err))
+		return
+	}
+	pipelines, err := r.getPipelines(domain, projectName, pipelineIds, tags)
+	if err != nil {
+		resp.Diagnostics.Append(util.NewDiagnosticApiError("get pipelines", err))
+		return
+	}
+	resp.Diagnostics.Append(data.loadAPI(ctx, domain, projectName, pipelineIds, tags, pipelines)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *pipelineStatusResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	r.update(ctx, &resp.Diagnostics, &req.Plan, &resp.State)
+}
+
+func (r *pipelineStatusResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+	// do nothing
+}
+
+func (r *pipelineStatusResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func (r *pipelineStatusResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manage paused and disabled status of one or many pipelines, e.g. to freeze all deployments of a project. " +
+			"Set `ignore_status_changes` in `buddy_pipeline` of the selected pipelines. Destroying the resource doesn't change the pipelines\n\n" +
+			"Token scopes required: `WORKSPACE`, `EXECUTION_MANAGE`, `EXECUTION_INFO`",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The Terraform resource identifier for this item",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"domain": schema.StringAttribute{
+				MarkdownDescription: "The workspace's URL handle. Defaults to the provider's `domain`",
+				Optional:            true,
+				Computed:            true,
+				Validators:          util.StringValidatorsDomain(),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"project_name": schema.StringAttribute{
+				MarkdownDescription: "The project's name",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"pipeline_ids": schema.SetAttribute{
+				MarkdownDescription: "The IDs of the pipelines to manage",
+				ElementType:         types.Int64Type,
+				Optional:            true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ExactlyOneOf(path.MatchRoot("tags")),
+				},
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+			},
+			"tags": schema.SetAttribute{
+				MarkdownDescription: "Manage pipelines with any of the tags. Pipelines tagged later are managed after the next apply",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+			},
+			"paused": schema.BoolAttribute{
+				MarkdownDescription: "Defines whether or not the pipelines are paused",
+				Optional:            true,
+				Validators: []validator.Bool{
+					boolvalidator.AtLeastOneOf(path.MatchRoot("disabled")),
+				},
+			},
+			"disabled": schema.BoolAttribute{
+				MarkdownDescription: "Defines whether or not the pipelines can be run",
+				Optional:            true,
+			},
+			"disabling_reason": schema.StringAttribute{
+				MarkdownDescription: "The pipelines' disabling reason. Requires `disabled` set to `true`",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"selected_pipeline_ids": schema.SetAttribute{
+				MarkdownDescription: "The IDs of the managed pipelines",
+				ElementType:         types.Int64Type,
+				Computed:            true,
+			},
+		},
+	}
+}
@@ -0,0 +1,350 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"github.com/buddy/api-go-sdk/buddy"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"strconv"
+	"terraform-provider-buddy/buddy/util"
+)
+
+var (
+	_ resource.Resource                   = &pipelineYamlResource{}
+	_ resource.ResourceWithConfigure      = &pipelineYamlResource{}
+	_ resource.ResourceWithImportState    = &pipelineYamlResource{}
+	_ resource.ResourceWithModifyPlan     = &pipelineYamlResource{}
+	_ resource.ResourceWithValidateConfig = &pipelineYamlResource{}
+)
+
+func NewPipelineYamlResource() resource.Resource {
+	return &pipelineYamlResource{}
+}
+
+type pipelineYamlResource struct {
+	client        *buddy.Client
+	defaultDomain string
+}
+
+type pipelineYamlResourceModel struct {
+	ID          types.String   `tfsdk:"id"`
+	Domain      types.String   `tfsdk:"domain"`
+	ProjectName types.String   `tfsdk:"project_name"`
+	Yaml        util.YamlValue `tfsdk:"yaml"`
+	PipelineId  types.Int64    `tfsdk:"pipeline_id"`
+	Name        types.String   `tfsdk:"name"`
+	HtmlUrl     types.String   `tfsdk:"html_url"`
+	ActionIds   types.Map      `tfsdk:"action_ids"`
+}
+
+func (r *pipelineYamlResourceModel) decomposeId() (string, string, int, error) {
+	domain, projectName, pid, err := util.DecomposeTripleId(r.ID.ValueString())
+	if err != nil {
+		return "", "", 0, err
+	}
+	pipelineId, err := strconv.Atoi(pid)
+	if err != nil {
+		return "", "", 0, err
+	}
+	return domain, projectName, pipelineId, nil
+}
+
+func (r *pipelineYamlResourceModel) loadAPI(ctx context.Context, domain string, projectName string, pipeline *buddy.Pipeline, actions *buddy.PipelineActions, yaml string) diag.Diagnostics {
+	r.ID = types.StringValue(util.ComposeTripleId(domain, projectName, strconv.Itoa(pipeline.Id)))
+	r.Domain = types.StringValue(domain)
+	r.ProjectName = types.StringValue(projectName)
+	r.PipelineId = types.Int64Value(int64(pipeline.Id))
+	r.Name = types.StringValue(pipeline.Name)
+	r.HtmlUrl = types.StringValue(pipeline.HtmlUrl)
+	var diags diag.Diagnostics
+	// keep configured yaml unless the pipeline was changed outside of terraform
+	if r.Yaml.IsNull() || r.Yaml.IsUnknown() || !pipelineYamlContains(yaml, r.Yaml.ValueString()) {
+		r.Yaml = util.NewYamlValue(yaml)
+	}
+	ids := map[string]int64{}
+	for _, a := range actions.Actions {
+		if _, ok := ids[a.Name]; ok {
+			diags.AddWarning("Duplicate action name", fmt.Sprintf("Pipeline has more than one action named `%s`, `action_ids` contains only the first one", a.Name))
+			continue
+		}
+		ids[a.Name] = int64(a.Id)
+	}
+	actionIds, d := types.MapValueFrom(ctx, types.Int64Type, &ids)
+	diags.Append(d...)
+	r.ActionIds = actionIds
+	return diags
+}
+
+// pipelineYamlContains checks whether the pipeline from the API contains the configured one, which can be a single mapping
+func pipelineYamlContains(got string, want string) bool {
+	list, err := util.PipelineYamlList(want)
+	if err != nil {
+		return false
+	}
+	return util.YamlContains(got, list)
+}
+
+func (r *pipelineYamlResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pipeline_yaml"
+}
+
+func (r *pipelineYamlResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Create and manage a pipeline, actions included, from YAML\n\n" +
+			"Token scopes required: `WORKSPACE`, `EXECUTION_MANAGE`, `EXECUTION_INFO`",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The Terraform resource identifier for this item",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"domain": schema.StringAttribute{
+				MarkdownDescription: "The workspace's URL handle. Defaults to the provider's `domain`",
+				Optional:            true,
+				Computed:            true,
+				Validators:          util.StringValidatorsDomain(),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"project_name": schema.StringAttribute{
+				MarkdownDescription: "The project's name",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"yaml": schema.StringAttribute{
+				CustomType:          util.YamlType{},
+				MarkdownDescription: "The pipeline's YAML definition with exactly one pipeline, as a list or a single mapping. Action names must be unique. Changes of keys order, quoting or whitespace are ignored",
+				Required:            true,
+			},
+			"pipeline_id": schema.Int64Attribute{
+				MarkdownDescription: "The pipeline's ID",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The pipeline's name",
+				Computed:            true,
+			},
+			"html_url": schema.StringAttribute{
+				MarkdownDescription: "The pipeline's URL",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"action_ids": schema.MapAttribute{
+				MarkdownDescription: "The pipeline's actions IDs by action name",
+				ElementType:         types.Int64Type,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *pipelineYamlResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	p := req.ProviderData.(*util.ProviderData)
+	r.client = p.Client
+	r.defaultDomain = p.Domain
+}
+
+func (r *pipelineYamlResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var yaml util.YamlValue
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("yaml"), &yaml)...)
+	if resp.Diagnostics.HasError() || yaml.IsNull() || yaml.IsUnknown() {
+		return
+	}
+	count, err := util.YamlPipelinesCount(yaml.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("yaml"), "Invalid attribute value", fmt.Sprintf("`yaml` is not a valid YAML: %s", err.Error()))
+		return
+	}
+	if count != 1 {
+		resp.Diagnostics.AddAttributeError(path.Root("yaml"), "Invalid attribute value", fmt.Sprintf("`yaml` must define exactly one pipeline, got: %d", count))
+		return
+	}
+	name, err := util.YamlDuplicateActionName(yaml.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("yaml"), "Invalid attribute value", fmt.Sprintf("`yaml` is not a valid pipeline definition: %s", err.Error()))
+		return
+	}
+	if name != "" {
+		resp.Diagnostics.AddAttributeError(path.Root("yaml"), "Invalid attribute value", fmt.Sprintf("`yaml` action names must be unique, got more than one `%s` action", name))
+	}
+}
+
+func (r *pipelineYamlResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	util.ModifyPlanDomain(ctx, path.Root("domain"), r.defaultDomain, req, resp)
+}
+
+func (r *pipelineYamlResource) read(domain string, projectName string, pipelineId int) (*buddy.PipelineActions, string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	pipelineYaml, _, err := r.client.PipelineService.GetYaml(domain, projectName, pipelineId)
+	if err != nil {
+		diags.Append(util.NewDiagnosticApiError("get pipeline yaml", err))
+		return nil, "", diags
+	}
+	yaml, err := util.DecodePipelineYaml(pipelineYaml.Yaml)
+	if err == nil {
+		yaml, err = util.NormalizeYaml(yaml)
+	}
+	if err != nil {
+		diags.AddError("Invalid pipeline yaml", err.Error())
+		return nil, "", diags
+	}
+	actions, _, err := r.client.PipelineActionService.GetList(domain, projectName, pipelineId)
+	if err != nil {
+		diags.Append(util.NewDiagnosticApiError("get pipeline actions", err))
+		return nil, "", diags
+	}
+	return actions, yaml, diags
+}
+
+func (r *pipelineYamlResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *pipelineYamlResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	domain := data.Domain.ValueString()
+	projectName := data.ProjectName.ValueString()
+	tflog.Debug(ctx, "Creating pipeline from yaml", map[string]interface{}{
+		"domain":       domain,
+		"project_name": projectName,
+	})
+	list, err := util.PipelineYamlList(data.Yaml.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("yaml"), "Invalid attribute value", err.Error())
+		return
+	}
+	yaml := util.EncodePipelineYaml(list)
+	pipeline, _, err := r.client.PipelineService.CreateYaml(domain, projectName, &buddy.PipelineYamlOps{
+		Yaml: &yaml,
+	})
+	if err != nil {
+		resp.Diagnostics.Append(util.NewDiagnosticApiError("create pipeline from yaml", err))
+		return
+	}
+	actions, y, d := r.read(domain, projectName, pipeline.Id)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(data.loadAPI(ctx, domain, projectName, pipeline, actions, y)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *pipelineYamlResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *pipelineYamlResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	domain, projectName, pipelineId, err := data.decomposeId()
+	if err != nil {
+		resp.Diagnostics.Append(util.NewDiagnosticDecomposeError("pipeline yaml", err))
+		return
+	}
+	tflog.Debug(ctx, "Reading pipeline yaml", map[string]interface{}{
+		"domain":       domain,
+		"project_name": projectName,
+		"pipeline_id":  pipelineId,
+	})
+	pipeline, httpResp, err := r.client.PipelineService.Get(domain, projectName, pipelineId)
+	if err != nil {
+		if util.IsResourceNotFound(httpResp, err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(util.NewDiagnosticApiError("get pipeline", err))
+		return
+	}
+	actions, yaml, d := r.read(domain, projectName, pipelineId)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(data.loadAPI(ctx, domain, projectName, pipeline, actions, yaml)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *pipelineYamlResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *pipelineYamlResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	domain, projectName, pipelineId, err := data.decomposeId()
+	if err != nil {
+		resp.Diagnostics.Append(util.NewDiagnosticDecomposeError("pipeline yaml", err))
+		return
+	}
+	list, err := util.PipelineYamlList(data.Yaml.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("yaml"), "Invalid attribute value", err.Error())
+		return
+	}
+	yaml := util.EncodePipelineYaml(list)
+	pipeline, _, err := r.client.PipelineService.UpdateYaml(domain, projectName, pipelineId, &buddy.PipelineYamlOps{
+		Yaml: &yaml,
+	})
+	if err != nil {
+		resp.Diagnostics.Append(util.NewDiagnosticApiError("update pipeline from yaml", err))
+		return
+	}
+	actions, y, d := r.read(domain, projectName, pipeline.Id)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(data.loadAPI(ctx, domain, projectName, pipeline, actions, y)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *pipelineYamlResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *pipelineYamlResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	domain, projectName, pipelineId, err := data.decomposeId()
+	if err != nil {
+		resp.Diagnostics.Append(util.NewDiagnosticDecomposeError("pipeline yaml", err))
+		return
+	}
+	_, err = r.client.PipelineService.Delete(domain, projectName, pipelineId)
+	if err != nil {
+		resp.Diagnostics.Append(util.NewDiagnosticApiError("delete pipeline", err))
+	}
+}
+
+func (r *pipelineYamlResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -85,7 +85,7 @@ func (r *profileResource) Configure(_ context.Context, req resource.ConfigureReq
 	if req.ProviderData == nil {
 		return
 	}
-	r.client = req.ProviderData.(*buddy.Client)
+	r.client = req.ProviderData.(*util.ProviderData).Client
 }
 
 func (r *profileResource) update(ctx context.Context, diagnostics *diag.Diagnostics, plan *tfsdk.Plan, state *tfsdk.State) {
@@ -73,7 +73,7 @@ func (r *profileEmailResource) Configure(_ context.Context, req resource.Configu
 	if req.ProviderData == nil {
 		return
 	}
-	r.client = req.ProviderData.(*buddy.Client)
+	r.client = req.ProviderData.(*util.ProviderData).Client
 }
 
 func (r *profileEmailResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
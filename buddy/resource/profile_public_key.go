@@ -83,7 +83,7 @@ func (r *profilePublicKeyResource) Configure(_ context.Context, req resource.Con
 	if req.ProviderData == nil {
 		return
 	}
-	r.client = req.ProviderData.(*buddy.Client)
+	r.client = req.ProviderData.(*util.ProviderData).Client
 }
 
 func (r *profilePublicKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -19,6 +19,7 @@ var (
 	_ resource.Resource                = &projectGroupResource{}
 	_ resource.ResourceWithConfigure   = &projectGroupResource{}
 	_ resource.ResourceWithImportState = &projectGroupResource{}
+	_ resource.ResourceWithModifyPlan  = &projectGroupResource{}
 )
 
 func NewProjectGroupResource() resource.Resource {
@@ -26,7 +27,8 @@ func NewProjectGroupResource() resource.Resource {
 }
 
 type projectGroupResource struct {
-	client *buddy.Client
+	client        *buddy.Client
+	defaultDomain string
 }
 
 type projectGroupResourceModel struct {
@@ -84,10 +86,12 @@ func (r *projectGroupResource) Schema(_ context.Context, _ resource.SchemaReques
 				},
 			},
 			"domain": schema.StringAttribute{
-				MarkdownDescription: "The workspace's URL handle",
-				Required:            true,
+				MarkdownDescription: "The workspace's URL handle. Defaults to the provider's `domain`",
+				Optional:            true,
+				Computed:            true,
 				Validators:          util.StringValidatorsDomain(),
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
@@ -133,7 +137,13 @@ func (r *projectGroupResource) Configure(_ context.Context, req resource.Configu
 	if req.ProviderData == nil {
 		return
 	}
-	r.client = req.ProviderData.(*buddy.Client)
+	p := req.ProviderData.(*util.ProviderData)
+	r.client = p.Client
+	r.defaultDomain = p.Domain
+}
+
+func (r *projectGroupResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	util.ModifyPlanDomain(ctx, path.Root("domain"), r.defaultDomain, req, resp)
 }
 
 func (r *projectGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -19,6 +19,7 @@ var (
 	_ resource.Resource                = &projectMemberResource{}
 	_ resource.ResourceWithConfigure   = &projectMemberResource{}
 	_ resource.ResourceWithImportState = &projectMemberResource{}
+	_ resource.ResourceWithModifyPlan  = &projectMemberResource{}
 )
 
 func NewProjectMemberResource() resource.Resource {
@@ -26,7 +27,8 @@ func NewProjectMemberResource() resource.Resource {
 }
 
 type projectMemberResource struct {
-	client *buddy.Client
+	client        *buddy.Client
+	defaultDomain string
 }
 
 type projectMemberResourceModel struct {
@@ -92,10 +94,12 @@ func (r *projectMemberResource) Schema(_ context.Context, _ resource.SchemaReque
 				},
 			},
 			"domain": schema.StringAttribute{
-				MarkdownDescription: "The workspace's URL handle",
-				Required:            true,
+				MarkdownDescription: "The workspace's URL handle. Defaults to the provider's `domain`",
+				Optional:            true,
+				Computed:            true,
 				Validators:          util.StringValidatorsDomain(),
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
@@ -157,7 +161,13 @@ func (r *projectMemberResource) Configure(_ context.Context, req resource.Config
 	if req.ProviderData == nil {
 		return
 	}
-	r.client = req.ProviderData.(*buddy.Client)
+	p := req.ProviderData.(*util.ProviderData)
+	r.client = p.Client
+	r.defaultDomain = p.Domain
+}
+
+func (r *projectMemberResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	util.ModifyPlanDomain(ctx, path.Root("domain"), r.defaultDomain, req, resp)
 }
 
 func (r *projectMemberResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
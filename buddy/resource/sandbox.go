@@ -3,6 +3,8 @@ package resource
 import (
 	"context"
 	"github.com/buddy/api-go-sdk/buddy"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -21,9 +23,11 @@ import (
 )
 
 var (
-	_ resource.Resource                = &sandboxResource{}
-	_ resource.ResourceWithConfigure   = &sandboxResource{}
-	_ resource.ResourceWithImportState = &sandboxResource{}
+	_ resource.Resource                 = &sandboxResource{}
+	_ resource.ResourceWithConfigure    = &sandboxResource{}
+	_ resource.ResourceWithImportState  = &sandboxResource{}
+	_ resource.ResourceWithModifyPlan   = &sandboxResource{}
+	_ resource.ResourceWithUpgradeState = &sandboxResource{}
 )
 
 type sandboxResourceModel struct {
@@ -43,9 +47,12 @@ type sandboxResourceModel struct {
 	Resources                types.String `tfsdk:"resources"`
 	Timeout                  types.Int32  `tfsdk:"timeout"`
 	Tags                     types.Set    `tfsdk:"tags"`
+	TagsAll                  types.Set    `tfsdk:"tags_all"`
 	AppCommands              types.Set    `tfsdk:"app_commands"`
 	Apps                     types.Set    `tfsdk:"apps"`
 	Endpoints                types.Map    `tfsdk:"endpoints"`
+	EndpointsWo              types.Map    `tfsdk:"endpoints_wo"`
+	EndpointsWoVersion       types.Int64  `tfsdk:"endpoints_wo_version"`
 	WaitForRunning           types.Bool   `tfsdk:"wait_for_running"`
 	WaitForRunningTimeout    types.Int32  `tfsdk:"wait_for_running_timeout"`
 	WaitForConfigured        types.Bool   `tfsdk:"wait_for_configured"`
@@ -63,7 +70,7 @@ func (r *sandboxResourceModel) decomposeId() (string, string, error) {
 	return domain, sandboxId, nil
 }
 
-func (r *sandboxResourceModel) loadAPI(ctx context.Context, domain string, sandbox *buddy.Sandbox, waitForRunning bool, waitForRunningTimeout int32, waitForConfigured bool, waitForConfiguredTimeout int32, waitForApps bool, waitForAppsTimeout int32) diag.Diagnostics {
+func (r *sandboxResourceModel) loadAPI(ctx context.Context, domain string, sandbox *buddy.Sandbox, waitForRunning bool, waitForRunningTimeout int32, waitForConfigured bool, waitForConfiguredTimeout int32, waitForApps bool, waitForAppsTimeout int32, defaultTags []string) diag.Diagnostics {
 	var diags diag.Diagnostics
 	r.ID = types.StringValue(util.ComposeDoubleId(domain, sandbox.Id))
 	r.Domain = types.StringValue(domain)
@@ -79,12 +86,15 @@ func (r *sandboxResourceModel) loadAPI(ctx context.Context, domain string, sandb
 	r.AppDir = types.StringValue(sandbox.AppDir)
 	r.Os = types.StringValue(sandbox.Os)
 	r.Resources = types.StringValue(sandbox.Resources)
-	tags, d := types.SetValueFrom(ctx, types.StringType, &sandbox.Tags)
+	tags, tagsAll, d := util.TagsModelFromApi(ctx, &r.Tags, sandbox.Tags, defaultTags)
 	diags.Append(d...)
 	r.Tags = tags
+	r.TagsAll = tagsAll
 	endpoints, d := util.SandboxEndpointsFromApi(ctx, &sandbox.Endpoints)
 	diags.Append(d...)
 	r.Endpoints = endpoints
+	// write-only values are never stored in the state
+	r.EndpointsWo = util.SandboxEndpointsWriteOnlyNull()
 	apps, d := util.SandboxAppsFromApi(ctx, &sandbox.Apps)
 	diags.Append(d...)
 	r.Apps = apps
@@ -103,7 +113,9 @@ func NewSandboxResource() resource.Resource {
 }
 
 type sandboxResource struct {
-	client *buddy.Client
+	client        *buddy.Client
+	defaultDomain string
+	defaultTags   []string
 }
 
 func (r *sandboxResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -112,6 +124,7 @@ func (r *sandboxResource) Metadata(_ context.Context, req resource.MetadataReque
 
 func (r *sandboxResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
 		MarkdownDescription: "Create and manage a sandbox\n\n" +
 			"Token scopes required: `WORKSPACE`, `SANDBOX_MANAGE`, `SANDBOX_INFO`",
 		Attributes: map[string]schema.Attribute{
@@ -123,10 +136,12 @@ func (r *sandboxResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				},
 			},
 			"domain": schema.StringAttribute{
-				MarkdownDescription: "The workspace's URL handle",
-				Required:            true,
+				MarkdownDescription: "The workspace's URL handle. Defaults to the provider's `domain`",
+				Optional:            true,
+				Computed:            true,
 				Validators:          util.StringValidatorsDomain(),
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
@@ -245,6 +260,11 @@ func (r *sandboxResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Optional:            true,
 				Computed:            true,
 			},
+			"tags_all": schema.SetAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "The sandbox's list of tags including the provider's `default_tags`",
+				Computed:            true,
+			},
 			"endpoints": schema.MapNestedAttribute{
 				MarkdownDescription: "The sandbox's map of endpoints",
 				Optional:            true,
@@ -253,6 +273,24 @@ func (r *sandboxResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 					Attributes: util.ResourceSandboxEndpointModelAttributes(),
 				},
 			},
+			"endpoints_wo": schema.MapNestedAttribute{
+				MarkdownDescription: "The sandbox's map of endpoints write-only secrets. Keys must match `endpoints` keys. It's never stored in the state. Requires Terraform 1.11 or later",
+				Optional:            true,
+				WriteOnly:           true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: util.ResourceSandboxEndpointWriteOnlyModelAttributes(),
+				},
+				Validators: []validator.Map{
+					mapvalidator.AlsoRequires(path.MatchRoot("endpoints_wo_version")),
+				},
+			},
+			"endpoints_wo_version": schema.Int64Attribute{
+				MarkdownDescription: "The `endpoints_wo` version. Change it to update the sandbox's endpoints secrets",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("endpoints_wo")),
+				},
+			},
 			"wait_for_running": schema.BoolAttribute{
 				MarkdownDescription: "Wait until sandbox is running",
 				Optional:            true,
@@ -332,7 +370,15 @@ func (r *sandboxResource) Configure(_ context.Context, req resource.ConfigureReq
 	if req.ProviderData == nil {
 		return
 	}
-	r.client = req.ProviderData.(*buddy.Client)
+	p := req.ProviderData.(*util.ProviderData)
+	r.client = p.Client
+	r.defaultDomain = p.Domain
+	r.defaultTags = p.DefaultTags
+}
+
+func (r *sandboxResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	util.ModifyPlanDomain(ctx, path.Root("domain"), r.defaultDomain, req, resp)
+	util.ModifyPlanTags(ctx, r.defaultTags, req, resp)
 }
 
 func (r *sandboxResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -385,20 +431,22 @@ func (r *sandboxResource) Create(ctx context.Context, req resource.CreateRequest
 	if !data.Resources.IsUnknown() && !data.Resources.IsNull() {
 		ops.Resources = data.Resources.ValueStringPointer()
 	}
-	if !data.Tags.IsUnknown() && !data.Tags.IsNull() {
-		tags, d := util.StringSetToApi(ctx, &data.Tags)
-		resp.Diagnostics.Append(d...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-		ops.Tags = tags
+	tags, d := util.TagsToApi(ctx, &req.Config, nil, &data.Tags, &data.TagsAll)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	ops.Tags = tags
 	if !data.Endpoints.IsUnknown() && !data.Endpoints.IsNull() {
 		endpoints, d := util.SandboxEndpointsToApi(ctx, &data.Endpoints)
 		resp.Diagnostics.Append(d...)
 		if resp.Diagnostics.HasError() {
 			return
 		}
+		resp.Diagnostics.Append(util.SandboxEndpointsWriteOnlyToApi(ctx, &req.Config, path.Root("endpoints_wo"), endpoints)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 		ops.Endpoints = endpoints
 	}
 	sandbox, _, err := r.client.SandboxService.Create(domain, projectName, &ops)
@@ -430,7 +478,7 @@ func (r *sandboxResource) Create(ctx context.Context, req resource.CreateRequest
 		}
 		sandbox = sb
 	}
-	resp.Diagnostics.Append(data.loadAPI(ctx, domain, sandbox, waitForRunning, waitForRunningTimeout, waitForConfigured, waitForConfiguredTimeout, waitForApps, waitForAppsTimeout)...)
+	resp.Diagnostics.Append(data.loadAPI(ctx, domain, sandbox, waitForRunning, waitForRunningTimeout, waitForConfigured, waitForConfiguredTimeout, waitForApps, waitForAppsTimeout, r.defaultTags)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -526,7 +574,7 @@ func (r *sandboxResource) Read(ctx context.Context, req resource.ReadRequest, re
 	waitForConfiguredTimeout := data.WaitForConfiguredTimeout.ValueInt32()
 	waitForApps := data.WaitForApps.ValueBool()
 	waitForAppsTimeout := data.WaitForAppsTimeout.ValueInt32()
-	resp.Diagnostics.Append(data.loadAPI(ctx, domain, sandbox, waitForRunning, waitForRunningTimeout, waitForConfigured, waitForConfiguredTimeout, waitForApps, waitForAppsTimeout)...)
+	resp.Diagnostics.Append(data.loadAPI(ctx, domain, sandbox, waitForRunning, waitForRunningTimeout, waitForConfigured, waitForConfiguredTimeout, waitForApps, waitForAppsTimeout, r.defaultTags)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -588,23 +636,25 @@ func (r *sandboxResource) Update(ctx context.Context, req resource.UpdateRequest
 	if !data.Resources.IsUnknown() && !data.Resources.IsNull() {
 		ops.Resources = data.Resources.ValueStringPointer()
 	}
-	if !data.Tags.IsUnknown() && !data.Tags.IsNull() {
-		tags, d := util.StringSetToApi(ctx, &data.Tags)
-		resp.Diagnostics.Append(d...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-		ops.Tags = tags
+	tags, d := util.TagsToApi(ctx, &req.Config, &req.State, &data.Tags, &data.TagsAll)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	ops.Tags = tags
 	if !data.Endpoints.IsUnknown() && !data.Endpoints.IsNull() {
 		endpoints, d := util.SandboxEndpointsToApi(ctx, &data.Endpoints)
 		resp.Diagnostics.Append(d...)
 		if resp.Diagnostics.HasError() {
 			return
 		}
+		resp.Diagnostics.Append(util.SandboxEndpointsWriteOnlyToApi(ctx, &req.Config, path.Root("endpoints_wo"), endpoints)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 		ops.Endpoints = endpoints
 	}
-	_, d := r.waitForRunning(domain, sandboxId, false, waitForRunningTimeout)
+	_, d = r.waitForRunning(domain, sandboxId, false, waitForRunningTimeout)
 	resp.Diagnostics.Append(d...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -643,7 +693,7 @@ func (r *sandboxResource) Update(ctx context.Context, req resource.UpdateRequest
 		}
 		sandbox = sb
 	}
-	resp.Diagnostics.Append(data.loadAPI(ctx, domain, sandbox, waitForRunning, waitForRunningTimeout, waitForConfigured, waitForConfiguredTimeout, waitForApps, waitForAppsTimeout)...)
+	resp.Diagnostics.Append(data.loadAPI(ctx, domain, sandbox, waitForRunning, waitForRunningTimeout, waitForConfigured, waitForConfiguredTimeout, waitForApps, waitForAppsTimeout, r.defaultTags)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -670,3 +720,33 @@ func (r *sandboxResource) Delete(ctx context.Context, req resource.DeleteRequest
 func (r *sandboxResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
+
+func (r *sandboxResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	var s resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &s)
+	return map[int64]resource.StateUpgrader{
+		0: util.UpgradeRawState(ctx, s.Schema, upgradeSandboxStateV0),
+	}
+}
+
+// upgradeSandboxStateV0 upgrades state saved before v1.41.0 (single app command)
+func upgradeSandboxStateV0(state map[string]interface{}) {
+	if cmd, ok := state["run_command"].(string); ok && cmd != "" && state["app_commands"] == nil {
+		state["app_commands"] = []interface{}{cmd}
+	}
+	delete(state, "run_command")
+	util.UpgradeRawStateRename(state, "first_boot_commands", "install_commands")
+	util.UpgradeRawStateRename(state, "wait_for_app", "wait_for_apps")
+	util.UpgradeRawStateRename(state, "wait_for_app_timeout", "wait_for_apps_timeout")
+	// wait flags are not refreshed from the API, so they need the schema's defaults
+	for _, name := range []string{"wait_for_running", "wait_for_configured", "wait_for_apps"} {
+		if state[name] == nil {
+			state[name] = false
+		}
+	}
+	for _, name := range []string{"wait_for_running_timeout", "wait_for_configured_timeout", "wait_for_apps_timeout"} {
+		if state[name] == nil {
+			state[name] = 120
+		}
+	}
+}
@@ -23,6 +23,7 @@ var (
 	_ resource.Resource                = &sandboxStatusResource{}
 	_ resource.ResourceWithConfigure   = &sandboxStatusResource{}
 	_ resource.ResourceWithImportState = &sandboxStatusResource{}
+	_ resource.ResourceWithModifyPlan  = &sandboxStatusResource{}
 )
 
 type sandboxStatusResourceModel struct {
@@ -64,7 +65,8 @@ func NewSandboxStatusResource() resource.Resource {
 }
 
 type sandboxStatusResource struct {
-	client *buddy.Client
+	client        *buddy.Client
+	defaultDomain string
 }
 
 func (r *sandboxStatusResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -75,7 +77,13 @@ func (r *sandboxStatusResource) Configure(_ context.Context, req resource.Config
 	if req.ProviderData == nil {
 		return
 	}
-	r.client = req.ProviderData.(*buddy.Client)
+	p := req.ProviderData.(*util.ProviderData)
+	r.client = p.Client
+	r.defaultDomain = p.Domain
+}
+
+func (r *sandboxStatusResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	util.ModifyPlanDomain(ctx, path.Root("domain"), r.defaultDomain, req, resp)
 }
 
 func (r *sandboxStatusResource) update(ctx context.Context, diag *diag.Diagnostics, plan *tfsdk.Plan, state *tfsdk.State) {
@@ -189,10 +197,12 @@ func (r *sandboxStatusResource) Schema(_ context.Context, _ resource.SchemaReque
 				},
 			},
 			"domain": schema.StringAttribute{
-				MarkdownDescription: "The workspace's URL handle",
-				Required:            true,
+				MarkdownDescription: "The workspace's URL handle. Defaults to the provider's `domain`",
+				Optional:            true,
+				Computed:            true,
 				Validators:          util.StringValidatorsDomain(),
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
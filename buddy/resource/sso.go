@@ -3,6 +3,7 @@ package resource
 import (
 	"context"
 	"github.com/buddy/api-go-sdk/buddy"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -20,6 +21,7 @@ var (
 	_ resource.Resource                = &ssoResource{}
 	_ resource.ResourceWithConfigure   = &ssoResource{}
 	_ resource.ResourceWithImportState = &ssoResource{}
+	_ resource.ResourceWithModifyPlan  = &ssoResource{}
 )
 
 func NewSsoResource() resource.Resource {
@@ -27,22 +29,25 @@ func NewSsoResource() resource.Resource {
 }
 
 type ssoResource struct {
-	client *buddy.Client
+	client        *buddy.Client
+	defaultDomain string
 }
 
 type ssoResourceModel struct {
-	ID            types.String `tfsdk:"id"`
-	Domain        types.String `tfsdk:"domain"`
-	Type          types.String `tfsdk:"type"`
-	SsoUrl        types.String `tfsdk:"sso_url"`
-	Issuer        types.String `tfsdk:"issuer"`
-	ClientId      types.String `tfsdk:"client_id"`
-	ClientSecret  types.String `tfsdk:"client_secret"`
-	Certificate   types.String `tfsdk:"certificate"`
-	Signature     types.String `tfsdk:"signature"`
-	Digest        types.String `tfsdk:"digest"`
-	RequireForAll types.Bool   `tfsdk:"require_for_all"`
-	HtmlUrl       types.String `tfsdk:"html_url"`
+	ID                    types.String `tfsdk:"id"`
+	Domain                types.String `tfsdk:"domain"`
+	Type                  types.String `tfsdk:"type"`
+	SsoUrl                types.String `tfsdk:"sso_url"`
+	Issuer                types.String `tfsdk:"issuer"`
+	ClientId              types.String `tfsdk:"client_id"`
+	ClientSecret          types.String `tfsdk:"client_secret"`
+	ClientSecretWo        types.String `tfsdk:"client_secret_wo"`
+	ClientSecretWoVersion types.Int64  `tfsdk:"client_secret_wo_version"`
+	Certificate           types.String `tfsdk:"certificate"`
+	Signature             types.String `tfsdk:"signature"`
+	Digest                types.String `tfsdk:"digest"`
+	RequireForAll         types.Bool   `tfsdk:"require_for_all"`
+	HtmlUrl               types.String `tfsdk:"html_url"`
 }
 
 func (r *ssoResourceModel) loadAPI(domain string, sso *buddy.Sso) {
@@ -54,6 +59,8 @@ func (r *ssoResourceModel) loadAPI(domain string, sso *buddy.Sso) {
 	r.Certificate = types.StringValue(sso.Certificate)
 	r.Signature = types.StringValue(sso.SignatureMethod)
 	r.Digest = types.StringValue(sso.DigestMethod)
+	// write-only values are never stored in the state
+	r.ClientSecretWo = types.StringNull()
 	r.RequireForAll = types.BoolValue(sso.RequireSsoForAllMembers)
 	r.HtmlUrl = types.StringValue(sso.HtmlUrl)
 }
@@ -76,10 +83,12 @@ func (r *ssoResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 				},
 			},
 			"domain": schema.StringAttribute{
-				MarkdownDescription: "The workspace's URL handle",
-				Required:            true,
+				MarkdownDescription: "The workspace's URL handle. Defaults to the provider's `domain`",
+				Optional:            true,
+				Computed:            true,
 				Validators:          util.StringValidatorsDomain(),
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
@@ -133,6 +142,23 @@ func (r *ssoResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 				Sensitive:           true,
 				Optional:            true,
 			},
+			"client_secret_wo": schema.StringAttribute{
+				MarkdownDescription: "The OIDC application's write-only Client Secret. It's never stored in the state. Requires Terraform 1.11 or later. Provide instead of `client_secret`",
+				Sensitive:           true,
+				Optional:            true,
+				WriteOnly:           true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("client_secret")),
+					stringvalidator.AlsoRequires(path.MatchRoot("client_secret_wo_version")),
+				},
+			},
+			"client_secret_wo_version": schema.Int64Attribute{
+				MarkdownDescription: "The `client_secret_wo` version. Change it to update the OIDC application's Client Secret",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("client_secret_wo")),
+				},
+			},
 			"html_url": schema.StringAttribute{
 				MarkdownDescription: "The Sso's URL",
 				Computed:            true,
@@ -145,10 +171,16 @@ func (r *ssoResource) Configure(_ context.Context, req resource.ConfigureRequest
 	if req.ProviderData == nil {
 		return
 	}
-	r.client = req.ProviderData.(*buddy.Client)
+	p := req.ProviderData.(*util.ProviderData)
+	r.client = p.Client
+	r.defaultDomain = p.Domain
+}
+
+func (r *ssoResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	util.ModifyPlanDomain(ctx, path.Root("domain"), r.defaultDomain, req, resp)
 }
 
-func (r *ssoResource) updateSso(ctx context.Context, diag *diag.Diagnostics, plan *tfsdk.Plan, state *tfsdk.State) {
+func (r *ssoResource) updateSso(ctx context.Context, diag *diag.Diagnostics, plan *tfsdk.Plan, config *tfsdk.Config, state *tfsdk.State) {
 	var data *ssoResourceModel
 	diag.Append(plan.Get(ctx, &data)...)
 	if diag.HasError() {
@@ -171,6 +203,14 @@ func (r *ssoResource) updateSso(ctx context.Context, diag *diag.Diagnostics, pla
 	} else {
 		ops.ClientId = data.ClientId.ValueStringPointer()
 		ops.ClientSecret = data.ClientSecret.ValueStringPointer()
+		clientSecretWo, d := util.WriteOnlyStringToApi(ctx, config, path.Root("client_secret_wo"))
+		diag.Append(d...)
+		if diag.HasError() {
+			return
+		}
+		if clientSecretWo != nil {
+			ops.ClientSecret = clientSecretWo
+		}
 	}
 	if !data.RequireForAll.IsNull() && !data.RequireForAll.IsUnknown() {
 		ops.RequireSsoForAllMembers = data.RequireForAll.ValueBoolPointer()
@@ -185,11 +225,11 @@ func (r *ssoResource) updateSso(ctx context.Context, diag *diag.Diagnostics, pla
 }
 
 func (r *ssoResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	r.updateSso(ctx, &resp.Diagnostics, &req.Plan, &resp.State)
+	r.updateSso(ctx, &resp.Diagnostics, &req.Plan, &req.Config, &resp.State)
 }
 
 func (r *ssoResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	r.updateSso(ctx, &resp.Diagnostics, &req.Plan, &resp.State)
+	r.updateSso(ctx, &resp.Diagnostics, &req.Plan, &req.Config, &resp.State)
 }
 
 func (r *ssoResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
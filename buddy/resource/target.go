@@ -13,14 +13,18 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"terraform-provider-buddy/buddy/util"
 )
 
 var (
-	_ resource.Resource                = &targetResource{}
-	_ resource.ResourceWithConfigure   = &targetResource{}
-	_ resource.ResourceWithImportState = &targetResource{}
+	_ resource.Resource                   = &targetResource{}
+	_ resource.ResourceWithConfigure      = &targetResource{}
+	_ resource.ResourceWithImportState    = &targetResource{}
+	_ resource.ResourceWithModifyPlan     = &targetResource{}
+	_ resource.ResourceWithUpgradeState   = &targetResource{}
+	_ resource.ResourceWithValidateConfig = &targetResource{}
 )
 
 func NewTargetResource() resource.Resource {
@@ -28,7 +32,9 @@ func NewTargetResource() resource.Resource {
 }
 
 type targetResource struct {
-	client *buddy.Client
+	client        *buddy.Client
+	defaultDomain string
+	defaultTags   []string
 }
 
 type targetResourceModel struct {
@@ -39,6 +45,7 @@ type targetResourceModel struct {
 	Name                 types.String `tfsdk:"name"`
 	Identifier           types.String `tfsdk:"identifier"`
 	Tags                 types.Set    `tfsdk:"tags"`
+	TagsAll              types.Set    `tfsdk:"tags_all"`
 	Type                 types.String `tfsdk:"type"`
 	Host                 types.String `tfsdk:"host"`
 	Scope                types.String `tfsdk:"scope"`
@@ -58,6 +65,10 @@ type targetResourceModel struct {
 	AllowedPipeline      types.Set    `tfsdk:"allowed_pipeline"`
 	SandboxesAccessLevel types.String `tfsdk:"sandboxes_access_level"`
 	AllowedSandboxes     types.Set    `tfsdk:"allowed_sandboxes"`
+	AuthPasswordWo       types.String `tfsdk:"auth_password_wo"`
+	AuthPassphraseWo     types.String `tfsdk:"auth_passphrase_wo"`
+	AuthKeyWo            types.String `tfsdk:"auth_key_wo"`
+	AuthWoVersion        types.Int64  `tfsdk:"auth_wo_version"`
 }
 
 func (m *targetResourceModel) decomposeId() (string, string, error) {
@@ -68,16 +79,17 @@ func (m *targetResourceModel) decomposeId() (string, string, error) {
 	return domain, environmentId, nil
 }
 
-func (m *targetResourceModel) loadAPI(ctx context.Context, domain string, target *buddy.Target) diag.Diagnostics {
+func (m *targetResourceModel) loadAPI(ctx context.Context, domain string, target *buddy.Target, defaultTags []string) diag.Diagnostics {
 	var diags diag.Diagnostics
 	m.ID = types.StringValue(util.ComposeDoubleId(domain, target.Id))
 	m.Domain = types.StringValue(domain)
 	m.HtmlUrl = types.StringValue(target.HtmlUrl)
 	m.TargetId = types.StringValue(target.Id)
 	m.Identifier = types.StringValue(target.Identifier)
-	tags, d := types.SetValueFrom(ctx, types.StringType, &target.Tags)
+	tags, tagsAll, d := util.TagsModelFromApi(ctx, &m.Tags, target.Tags, defaultTags)
 	diags.Append(d...)
 	m.Tags = tags
+	m.TagsAll = tagsAll
 	m.Name = types.StringValue(target.Name)
 	m.Type = types.StringValue(target.Type)
 	m.Host = types.StringValue(target.Host)
@@ -90,10 +102,14 @@ func (m *targetResourceModel) loadAPI(ctx context.Context, domain string, target
 	m.Disabled = types.BoolValue(target.Disabled)
 	m.PipelinesAccessLevel = types.StringValue(target.PipelinesAccessLevel)
 	m.SandboxesAccessLevel = types.StringValue(target.SandboxesAccessLevel)
+	// write-only values are never stored in the state
+	m.AuthPasswordWo = types.StringNull()
+	m.AuthPassphraseWo = types.StringNull()
+	m.AuthKeyWo = types.StringNull()
 	return diags
 }
 
-func (m *targetResourceModel) toOps(ctx context.Context) (*buddy.TargetOps, diag.Diagnostics) {
+func (m *targetResourceModel) toOps(ctx context.Context, config *tfsdk.Config, state *tfsdk.State) (*buddy.TargetOps, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	ops := buddy.TargetOps{}
 	if !m.Identifier.IsNull() && !m.Identifier.IsUnknown() {
@@ -122,11 +138,9 @@ func (m *targetResourceModel) toOps(ctx context.Context) (*buddy.TargetOps, diag
 	if !m.SandboxesAccessLevel.IsNull() && !m.SandboxesAccessLevel.IsUnknown() {
 		ops.SandboxesAccessLevel = m.SandboxesAccessLevel.ValueStringPointer()
 	}
-	if !m.Tags.IsNull() && !m.Tags.IsUnknown() {
-		tags, d := util.StringSetToApi(ctx, &m.Tags)
-		diags.Append(d...)
-		ops.Tags = tags
-	}
+	tags, d := util.TagsToApi(ctx, config, state, &m.Tags, &m.TagsAll)
+	diags.Append(d...)
+	ops.Tags = tags
 	if !m.Type.IsNull() && !m.Type.IsUnknown() {
 		ops.Type = m.Type.ValueStringPointer()
 	}
@@ -179,6 +193,9 @@ func (m *targetResourceModel) toOps(ctx context.Context) (*buddy.TargetOps, diag
 		diags.Append(d...)
 		ops.Auth = auth
 	}
+	if ops.Auth != nil {
+		diags.Append(util.TargetAuthWriteOnlyToApi(ctx, config, ops.Auth)...)
+	}
 	if !m.Proxy.IsNull() && !m.Proxy.IsUnknown() {
 		proxy, d := util.TargetProxyModelToApi(ctx, &m.Proxy)
 		diags.Append(d...)
@@ -193,6 +210,7 @@ func (r *targetResource) Metadata(_ context.Context, req resource.MetadataReques
 
 func (r *targetResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
 		MarkdownDescription: "Create and manage a target\n\n" +
 			"Token scope required: `WORKSPACE`, `TARGET_MANAGE`, `TARGET_INFO`",
 		Attributes: map[string]schema.Attribute{
@@ -204,10 +222,12 @@ func (r *targetResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				},
 			},
 			"domain": schema.StringAttribute{
-				MarkdownDescription: "The workspace's URL handle",
-				Required:            true,
+				MarkdownDescription: "The workspace's URL handle. Defaults to the provider's `domain`",
+				Optional:            true,
+				Computed:            true,
 				Validators:          util.StringValidatorsDomain(),
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
@@ -275,6 +295,11 @@ func (r *targetResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				Optional:            true,
 				Computed:            true,
 			},
+			"tags_all": schema.SetAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "The target's list of tags including the provider's `default_tags`",
+				Computed:            true,
+			},
 			"scope": schema.StringAttribute{
 				MarkdownDescription: "The target's scope. Set for `MATCH`",
 				Optional:            true,
@@ -331,6 +356,40 @@ func (r *targetResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				Optional:            true,
 				Computed:            true,
 			},
+			"auth_password_wo": schema.StringAttribute{
+				MarkdownDescription: "The target's auth write-only password. It's never stored in the state. Requires Terraform 1.11 or later. Provide along with `auth` block instead of `auth.password`",
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("auth").AtAnySetValue().AtName("password")),
+					stringvalidator.AlsoRequires(path.MatchRoot("auth_wo_version")),
+				},
+			},
+			"auth_passphrase_wo": schema.StringAttribute{
+				MarkdownDescription: "The target's auth write-only key passphrase. It's never stored in the state. Requires Terraform 1.11 or later. Provide along with `auth` block instead of `auth.passphrase`",
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("auth").AtAnySetValue().AtName("passphrase")),
+					stringvalidator.AlsoRequires(path.MatchRoot("auth_wo_version")),
+				},
+			},
+			"auth_key_wo": schema.StringAttribute{
+				MarkdownDescription: "The target's auth write-only SSH key. It's never stored in the state. Requires Terraform 1.11 or later. Provide along with `auth` block instead of `auth.key`",
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("auth").AtAnySetValue().AtName("key")),
+					stringvalidator.AlsoRequires(path.MatchRoot("auth_wo_version")),
+				},
+			},
+			"auth_wo_version": schema.Int64Attribute{
+				MarkdownDescription: "The version of the target's auth write-only secrets. Change it to update secrets provided with `auth_*_wo` attributes",
+				Optional:            true,
+			},
 		},
 		Blocks: map[string]schema.Block{
 			"permissions": schema.SetNestedBlock{
@@ -444,7 +503,19 @@ func (r *targetResource) Configure(_ context.Context, req resource.ConfigureRequ
 	if req.ProviderData == nil {
 		return
 	}
-	r.client = req.ProviderData.(*buddy.Client)
+	p := req.ProviderData.(*util.ProviderData)
+	r.client = p.Client
+	r.defaultDomain = p.Domain
+	r.defaultTags = p.DefaultTags
+}
+
+func (r *targetResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(util.ValidateTargetConfig(ctx, &req.Config)...)
+}
+
+func (r *targetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	util.ModifyPlanDomain(ctx, path.Root("domain"), r.defaultDomain, req, resp)
+	util.ModifyPlanTags(ctx, r.defaultTags, req, resp)
 }
 
 func (r *targetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -454,7 +525,7 @@ func (r *targetResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 	domain := data.Domain.ValueString()
-	ops, d := data.toOps(ctx)
+	ops, d := data.toOps(ctx, &req.Config, nil)
 	resp.Diagnostics.Append(d...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -464,7 +535,7 @@ func (r *targetResource) Create(ctx context.Context, req resource.CreateRequest,
 		resp.Diagnostics.Append(util.NewDiagnosticApiError("create target", err))
 		return
 	}
-	resp.Diagnostics.Append(data.loadAPI(ctx, domain, target)...)
+	resp.Diagnostics.Append(data.loadAPI(ctx, domain, target, r.defaultTags)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -491,7 +562,7 @@ func (r *targetResource) Read(ctx context.Context, req resource.ReadRequest, res
 		resp.Diagnostics.Append(util.NewDiagnosticApiError("get target", err))
 		return
 	}
-	resp.Diagnostics.Append(data.loadAPI(ctx, domain, target)...)
+	resp.Diagnostics.Append(data.loadAPI(ctx, domain, target, r.defaultTags)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -509,7 +580,7 @@ func (r *targetResource) Update(ctx context.Context, req resource.UpdateRequest,
 		resp.Diagnostics.Append(util.NewDiagnosticDecomposeError("target", err))
 		return
 	}
-	ops, d := data.toOps(ctx)
+	ops, d := data.toOps(ctx, &req.Config, &req.State)
 	resp.Diagnostics.Append(d...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -519,7 +590,7 @@ func (r *targetResource) Update(ctx context.Context, req resource.UpdateRequest,
 		resp.Diagnostics.Append(util.NewDiagnosticApiError("update target", err))
 		return
 	}
-	resp.Diagnostics.Append(data.loadAPI(ctx, domain, target)...)
+	resp.Diagnostics.Append(data.loadAPI(ctx, domain, target, r.defaultTags)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -544,6 +615,61 @@ func (r *targetResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	}
 }
 
+// ImportState accepts `domain:target_id`, `domain:target_identifier` or `domain:project_name:target_identifier`
 func (r *targetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	query := &buddy.TargetGetListQuery{}
+	domain, projectName, target, err := util.DecomposeTripleId(req.ID)
+	if err == nil {
+		query.ProjectName = projectName
+	} else {
+		domain, target, err = util.DecomposeDoubleId(req.ID)
+		if err != nil {
+			resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+			return
+		}
+	}
+	// ID has precedence over identifier
+	t, httpResp, err := r.client.TargetService.Get(domain, target)
+	if err == nil {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), util.ComposeDoubleId(domain, t.Id))...)
+		return
+	}
+	if !util.IsResourceNotFound(httpResp, err) {
+		resp.Diagnostics.Append(util.NewDiagnosticApiError("get target", err))
+		return
+	}
+	targets, _, err := r.client.TargetService.GetList(domain, query)
+	if err != nil {
+		resp.Diagnostics.Append(util.NewDiagnosticApiError("get targets", err))
+		return
+	}
+	var found []*buddy.Target
+	for _, t := range targets.Targets {
+		if t.Identifier == target {
+			found = append(found, t)
+		}
+	}
+	if len(found) == 0 {
+		resp.Diagnostics.Append(util.NewDiagnosticImportNotFound("target", target))
+		return
+	}
+	if len(found) > 1 {
+		resp.Diagnostics.Append(util.NewDiagnosticImportAmbiguous("target", target))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), util.ComposeDoubleId(domain, found[0].Id))...)
+}
+
+func (r *targetResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	var s resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &s)
+	return map[int64]resource.StateUpgrader{
+		0: util.UpgradeRawState(ctx, s.Schema, upgradeTargetStateV0),
+	}
+}
+
+// upgradeTargetStateV0 upgrades state saved before v1.43.0 (boolean access flag)
+func upgradeTargetStateV0(state map[string]interface{}) {
+	util.UpgradeRawStateBoolToAccessLevel(state, "all_pipelines_allowed", "pipelines_access_level", buddy.TargetPipelineAccessLevelUseOnly, buddy.TargetPipelineAccessLevelDenied)
+	util.UpgradeRawStateBlockDefault(state, "allowed_pipeline", "access_level", buddy.TargetPipelineAccessLevelUseOnly)
 }
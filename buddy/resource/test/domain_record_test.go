@@ -13,9 +13,9 @@ import (
 
 func TestAccDomainGeoRecord(t *testing.T) {
 	var record buddy.Record
-	workspaceDomain := util.UniqueString()
-	domain := util.UniqueString() + ".com"
-	name := util.UniqueString() + "." + domain
+	workspaceDomain := acc.UniqueString()
+	domain := acc.UniqueString() + ".com"
+	name := acc.UniqueString() + "." + domain
 	typ := "TXT"
 	ttl := 300
 	value := "A"
@@ -64,9 +64,9 @@ func TestAccDomainGeoRecord(t *testing.T) {
 
 func TestAccDomainRecord(t *testing.T) {
 	var record buddy.Record
-	workspaceDomain := util.UniqueString()
-	domain := util.UniqueString() + ".com"
-	name := util.UniqueString() + "." + domain
+	workspaceDomain := acc.UniqueString()
+	domain := acc.UniqueString() + ".com"
+	name := acc.UniqueString() + "." + domain
 	typ := "A"
 	ttl := 60
 	value := "1.1.1.1"
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/buddy/api-go-sdk/buddy"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"strconv"
 	"terraform-provider-buddy/buddy/acc"
@@ -19,12 +20,12 @@ var ignoreEnvImportVerify = []string{
 
 func TestAccEnvironmentPermissions(t *testing.T) {
 	var environment buddy.Environment
-	domain := util.UniqueString()
-	projectName := util.UniqueString()
+	domain := acc.UniqueString()
+	projectName := acc.UniqueString()
 	name := util.RandString(10)
 	email := util.RandEmail()
 	groupName := util.RandString(10)
-	identifier := util.UniqueString()
+	identifier := acc.UniqueString()
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
 			acc.PreCheck(t)
@@ -73,15 +74,15 @@ func TestAccEnvironmentPermissions(t *testing.T) {
 
 func TestAccEnvironmentWorkspace(t *testing.T) {
 	var environment buddy.Environment
-	domain := util.UniqueString()
+	domain := acc.UniqueString()
 	baseName := util.RandString(10)
-	baseIdentifier := util.UniqueString()
+	baseIdentifier := acc.UniqueString()
 	envProjName := util.RandString(10)
-	envProjIdentifier := util.UniqueString()
+	envProjIdentifier := acc.UniqueString()
 	pipName := util.RandString(10)
-	pipIdentifier := util.UniqueString()
+	pipIdentifier := acc.UniqueString()
 	name := util.RandString(10)
-	identifier := util.UniqueString()
+	identifier := acc.UniqueString()
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
 			acc.PreCheck(t)
@@ -110,12 +111,12 @@ func TestAccEnvironmentWorkspace(t *testing.T) {
 
 func TestAccEnvironmentSimple(t *testing.T) {
 	var environment buddy.Environment
-	domain := util.UniqueString()
-	projectName := util.UniqueString()
+	domain := acc.UniqueString()
+	projectName := acc.UniqueString()
 	name := util.RandString(10)
 	newName := util.RandString(10)
-	identifier := util.UniqueString()
-	newIdentifier := util.UniqueString()
+	identifier := acc.UniqueString()
+	newIdentifier := acc.UniqueString()
 	url := "https://" + util.RandString(10) + ".com"
 	newUrl := "https://" + util.RandString(10) + ".com"
 	tag := util.RandString(3)
@@ -156,6 +157,48 @@ func TestAccEnvironmentSimple(t *testing.T) {
 	})
 }
 
+func TestAccEnvironmentUpgradeFromV1_42(t *testing.T) {
+	var environment buddy.Environment
+	domain := acc.UniqueString()
+	projectName := acc.UniqueString()
+	name := util.RandString(10)
+	identifier := acc.UniqueString()
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acc.PreCheck(t)
+		},
+		CheckDestroy: testAccEnvironmentCheckDestroy,
+		Steps: []resource.TestStep{
+			// create env with the boolean access flags
+			{
+				ExternalProviders: map[string]resource.ExternalProvider{
+					"buddy": {
+						Source:            "buddy/buddy",
+						VersionConstraint: "1.42.0",
+					},
+				},
+				Config: testAccEnvironmentV1_42Config(domain, projectName, name, identifier),
+			},
+			// upgrade state
+			{
+				ProtoV6ProviderFactories: acc.ProviderFactories,
+				Config:                   testAccEnvironmentV1_43Config(domain, projectName, name, identifier),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("buddy_environment.env", plancheck.ResourceActionNoop),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					testAccEnvironmentGet("buddy_environment.env", &environment),
+					resource.TestCheckResourceAttr("buddy_environment.env", "pipelines_access_level", buddy.EnvironmentAccessLevelUseOnly),
+					resource.TestCheckResourceAttr("buddy_environment.env", "environments_access_level", buddy.EnvironmentAccessLevelDenied),
+					resource.TestCheckResourceAttr("buddy_environment.env", "identifier", identifier),
+				),
+			},
+		},
+	})
+}
+
 func testAccEnvironmentAttributes(n string, environment *buddy.Environment, name string, identifier string, url string, icon string, pipAccessLevel string, envAccessLevel string, scope string, baseOnly bool, baseEnvironment string, tag string, othersLevel string, userLevel string, groupLevel string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -623,6 +666,50 @@ resource "buddy_environment" "env" {
 `, domain, projectName, name, identifier, url, pipAccessLevel, envAccessLevel, baseOnly, icon, tag)
 }
 
+func testAccEnvironmentV1_42Config(domain string, projectName string, name string, identifier string) string {
+	return fmt.Sprintf(`
+resource "buddy_workspace" "foo" {
+    domain = "%s"
+}
+
+resource "buddy_project" "proj" {
+    domain = "${buddy_workspace.foo.domain}"
+    display_name = "%s"
+}
+
+resource "buddy_environment" "env" {
+    domain = "${buddy_workspace.foo.domain}"
+    project_name = "${buddy_project.proj.name}"
+    name = "%s"
+    identifier = "%s"
+    all_pipelines_allowed = true
+    all_environments_allowed = false
+}
+`, domain, projectName, name, identifier)
+}
+
+func testAccEnvironmentV1_43Config(domain string, projectName string, name string, identifier string) string {
+	return fmt.Sprintf(`
+resource "buddy_workspace" "foo" {
+    domain = "%s"
+}
+
+resource "buddy_project" "proj" {
+    domain = "${buddy_workspace.foo.domain}"
+    display_name = "%s"
+}
+
+resource "buddy_environment" "env" {
+    domain = "${buddy_workspace.foo.domain}"
+    project_name = "${buddy_project.proj.name}"
+    name = "%s"
+    identifier = "%s"
+    pipelines_access_level = "USE_ONLY"
+    environments_access_level = "DENIED"
+}
+`, domain, projectName, name, identifier)
+}
+
 func testAccEnvironmentCheckDestroy(s *terraform.State) error {
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "buddy_environment" {
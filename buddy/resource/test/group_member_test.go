@@ -13,7 +13,7 @@ import (
 
 func TestAccGroupMember(t *testing.T) {
 	var member buddy.Member
-	domain := util.UniqueString()
+	domain := acc.UniqueString()
 	groupNameA := util.RandString(5)
 	groupNameB := util.RandString(5)
 	memberEmailA := util.RandEmail()
@@ -14,7 +14,7 @@ import (
 func TestAccGroup(t *testing.T) {
 	var group buddy.Group
 	var permission buddy.Permission
-	domain := util.UniqueString()
+	domain := acc.UniqueString()
 	name := util.RandString(5)
 	newName := util.RandString(5)
 	newDescription := util.RandString(5)
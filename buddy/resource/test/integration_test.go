@@ -5,6 +5,8 @@ import (
 	"github.com/buddy/api-go-sdk/buddy"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"regexp"
 	"strconv"
 	"terraform-provider-buddy/buddy/acc"
 	"terraform-provider-buddy/buddy/util"
@@ -32,11 +34,11 @@ var ignoreImportVerify = []string{
 
 func TestAccIntegration_amazon_trusted(t *testing.T) {
 	var integration buddy.Integration
-	domain := util.UniqueString()
-	name := util.RandString(10)
+	domain := acc.UniqueString()
+	name := acc.UniqueString()
 	others := buddy.IntegrationPermissionManage
 	admins := buddy.IntegrationPermissionManage
-	newName := util.RandString(10)
+	newName := acc.UniqueString()
 	scope := buddy.IntegrationScopeWorkspace
 	newOthers := buddy.IntegrationPermissionUseOnly
 	perms := buddy.IntegrationPermissions{
@@ -83,9 +85,9 @@ func TestAccIntegration_amazon_trusted(t *testing.T) {
 
 func TestAccIntegration_amazon_oidc(t *testing.T) {
 	var integration buddy.Integration
-	domain := util.UniqueString()
-	name := util.RandString(10)
-	newName := util.RandString(10)
+	domain := acc.UniqueString()
+	name := acc.UniqueString()
+	newName := acc.UniqueString()
 	scope := buddy.IntegrationScopeWorkspace
 	audience := util.RandString(10)
 	newAudience := util.RandString(10)
@@ -154,9 +156,9 @@ func TestAccIntegration_amazon_oidc(t *testing.T) {
 
 func TestAccIntegration_amazon_default(t *testing.T) {
 	var integration buddy.Integration
-	domain := util.UniqueString()
-	name := util.RandString(10)
-	newName := util.RandString(10)
+	domain := acc.UniqueString()
+	name := acc.UniqueString()
+	newName := acc.UniqueString()
 	scope := buddy.IntegrationScopeWorkspace
 	identifier := util.RandString(10)
 	resource.Test(t, resource.TestCase{
@@ -195,9 +197,9 @@ func TestAccIntegration_amazon_default(t *testing.T) {
 
 func TestAccIntegration_amazon_recreate(t *testing.T) {
 	var integration buddy.Integration
-	domain := util.UniqueString()
-	name := util.RandString(10)
-	newName := util.RandString(10)
+	domain := acc.UniqueString()
+	name := acc.UniqueString()
+	newName := acc.UniqueString()
 	scope := buddy.IntegrationScopeWorkspace
 	identifier := util.RandString(10)
 	newIdentifier := util.RandString(10)
@@ -237,9 +239,9 @@ func TestAccIntegration_amazon_recreate(t *testing.T) {
 
 func TestAccIntegration_amazon(t *testing.T) {
 	var integration buddy.Integration
-	domain := util.UniqueString()
-	name := util.RandString(10)
-	newName := util.RandString(10)
+	domain := acc.UniqueString()
+	name := acc.UniqueString()
+	newName := acc.UniqueString()
 	scope := buddy.IntegrationScopeWorkspace
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
@@ -277,9 +279,9 @@ func TestAccIntegration_amazon(t *testing.T) {
 
 func TestAccIntegration_digitalocean(t *testing.T) {
 	var integration buddy.Integration
-	domain := util.UniqueString()
-	name := util.RandString(10)
-	newName := util.RandString(10)
+	domain := acc.UniqueString()
+	name := acc.UniqueString()
+	newName := acc.UniqueString()
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
 			acc.PreCheck(t)
@@ -314,11 +316,51 @@ func TestAccIntegration_digitalocean(t *testing.T) {
 	})
 }
 
+func TestAccIntegration_writeOnly(t *testing.T) {
+	var integration buddy.Integration
+	domain := acc.UniqueString()
+	name := acc.UniqueString()
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acc.PreCheck(t)
+		},
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		ProtoV6ProviderFactories: acc.ProviderFactories,
+		CheckDestroy:             testAccIntegrationCheckDestroy,
+		Steps: []resource.TestStep{
+			// create integration with write-only token
+			{
+				Config: testAccIntegrationDigitalOceanWriteOnly(domain, name, 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccIntegrationGet("buddy_integration.bar", &integration),
+					testAccIntegrationAttributes("buddy_integration.bar", &integration, name, buddy.IntegrationTypeDigitalOcean, "", buddy.IntegrationScopeWorkspace, false, "", nil, true, false),
+					resource.TestCheckNoResourceAttr("buddy_integration.bar", "token"),
+					resource.TestCheckNoResourceAttr("buddy_integration.bar", "token_wo"),
+					resource.TestCheckResourceAttr("buddy_integration.bar", "secrets_wo_version", "1"),
+				),
+			},
+			// switch to token
+			{
+				Config: testAccIntegrationDigitalOcean(domain, name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccIntegrationGet("buddy_integration.bar", &integration),
+					testAccIntegrationAttributes("buddy_integration.bar", &integration, name, buddy.IntegrationTypeDigitalOcean, "", buddy.IntegrationScopeWorkspace, false, "", nil, true, false),
+					resource.TestCheckResourceAttr("buddy_integration.bar", "token", "ABC"),
+					resource.TestCheckNoResourceAttr("buddy_integration.bar", "token_wo"),
+					resource.TestCheckNoResourceAttr("buddy_integration.bar", "secrets_wo_version"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccIntegration_shopify(t *testing.T) {
 	var integration buddy.Integration
-	domain := util.UniqueString()
-	name := util.RandString(10)
-	newName := util.RandString(10)
+	domain := acc.UniqueString()
+	name := acc.UniqueString()
+	newName := acc.UniqueString()
 	projectNameA := util.RandString(10)
 	projectNameB := util.RandString(10)
 	scope := buddy.IntegrationScopeProject
@@ -358,9 +400,9 @@ func TestAccIntegration_shopify(t *testing.T) {
 
 func TestAccIntegration_shopify_partner(t *testing.T) {
 	var integration buddy.Integration
-	domain := util.UniqueString()
-	name := util.RandString(10)
-	newName := util.RandString(10)
+	domain := acc.UniqueString()
+	name := acc.UniqueString()
+	newName := acc.UniqueString()
 	projectNameA := util.RandString(10)
 	projectNameB := util.RandString(10)
 	scope := buddy.IntegrationScopeProject
@@ -400,9 +442,9 @@ func TestAccIntegration_shopify_partner(t *testing.T) {
 
 func TestAccIntegration_gitlab(t *testing.T) {
 	var integration buddy.Integration
-	domain := util.UniqueString()
-	name := util.RandString(10)
-	newName := util.RandString(10)
+	domain := acc.UniqueString()
+	name := acc.UniqueString()
+	newName := acc.UniqueString()
 	scope := buddy.IntegrationScopeWorkspace
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
@@ -440,9 +482,9 @@ func TestAccIntegration_gitlab(t *testing.T) {
 
 func TestAccIntegration_github(t *testing.T) {
 	var integration buddy.Integration
-	domain := util.UniqueString()
-	name := util.RandString(10)
-	newName := util.RandString(10)
+	domain := acc.UniqueString()
+	name := acc.UniqueString()
+	newName := acc.UniqueString()
 	scope := buddy.IntegrationScopeWorkspace
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
@@ -480,9 +522,9 @@ func TestAccIntegration_github(t *testing.T) {
 
 func TestAccIntegration_rackspace(t *testing.T) {
 	var integration buddy.Integration
-	domain := util.UniqueString()
-	name := util.RandString(10)
-	newName := util.RandString(10)
+	domain := acc.UniqueString()
+	name := acc.UniqueString()
+	newName := acc.UniqueString()
 	scope := buddy.IntegrationScopeWorkspace
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
@@ -520,9 +562,9 @@ func TestAccIntegration_rackspace(t *testing.T) {
 
 func TestAccIntegration_cloudflare(t *testing.T) {
 	var integration buddy.Integration
-	domain := util.UniqueString()
-	name := util.RandString(10)
-	newName := util.RandString(10)
+	domain := acc.UniqueString()
+	name := acc.UniqueString()
+	newName := acc.UniqueString()
 	scope := buddy.IntegrationScopeWorkspace
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
@@ -560,9 +602,9 @@ func TestAccIntegration_cloudflare(t *testing.T) {
 
 func TestAccIntegration_upcloud(t *testing.T) {
 	var integration buddy.Integration
-	domain := util.UniqueString()
-	name := util.RandString(10)
-	newName := util.RandString(10)
+	domain := acc.UniqueString()
+	name := acc.UniqueString()
+	newName := acc.UniqueString()
 	scope := buddy.IntegrationScopeWorkspace
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
@@ -600,9 +642,9 @@ func TestAccIntegration_upcloud(t *testing.T) {
 
 func TestAccIntegration_stackHawk(t *testing.T) {
 	var integration buddy.Integration
-	domain := util.UniqueString()
-	name := util.RandString(10)
-	newName := util.RandString(10)
+	domain := acc.UniqueString()
+	name := acc.UniqueString()
+	newName := acc.UniqueString()
 	scope := buddy.IntegrationScopeWorkspace
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
@@ -640,9 +682,9 @@ func TestAccIntegration_stackHawk(t *testing.T) {
 
 func TestAccIntegration_google_oidc(t *testing.T) {
 	var integration buddy.Integration
-	domain := util.UniqueString()
-	name := util.RandString(10)
-	newName := util.RandString(10)
+	domain := acc.UniqueString()
+	name := acc.UniqueString()
+	newName := acc.UniqueString()
 	scope := buddy.IntegrationScopeWorkspace
 	audience := util.RandString(10)
 	newAudience := util.RandString(10)
@@ -684,9 +726,9 @@ func TestAccIntegration_google_oidc(t *testing.T) {
 
 func TestAccIntegration_azurecloud_oidc(t *testing.T) {
 	var integration buddy.Integration
-	domain := util.UniqueString()
-	name := util.RandString(10)
-	newName := util.RandString(10)
+	domain := acc.UniqueString()
+	name := acc.UniqueString()
+	newName := acc.UniqueString()
 	scope := buddy.IntegrationScopeWorkspace
 	audience := util.RandString(10)
 	newAudience := util.RandString(10)
@@ -726,9 +768,9 @@ func TestAccIntegration_azurecloud_oidc(t *testing.T) {
 
 func TestAccIntegration_azurecloud(t *testing.T) {
 	var integration buddy.Integration
-	domain := util.UniqueString()
-	name := util.RandString(10)
-	newName := util.RandString(10)
+	domain := acc.UniqueString()
+	name := acc.UniqueString()
+	newName := acc.UniqueString()
 	scope := buddy.IntegrationScopeWorkspace
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
@@ -1234,6 +1276,99 @@ resource "buddy_integration" "bar" {
 `, domain, name, buddy.IntegrationTypeDigitalOcean, buddy.IntegrationScopeWorkspace)
 }
 
+func testAccIntegrationDigitalOceanWriteOnly(domain string, name string, version int) string {
+	return fmt.Sprintf(`
+resource "buddy_workspace" "foo" {
+   domain = "%s"
+}
+
+resource "buddy_integration" "bar" {
+   domain = "${buddy_workspace.foo.domain}"
+   name = "%s"
+   type = "%s"
+   scope = "%s"
+   token_wo = "ABC"
+   secrets_wo_version = %d
+}
+`, domain, name, buddy.IntegrationTypeDigitalOcean, buddy.IntegrationScopeWorkspace, version)
+}
+
+func TestIntegrationValidateConfig(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acc.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccIntegrationValidateConfig(buddy.IntegrationTypeAmazon, buddy.IntegrationScopeWorkspace, `token = "abc"`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("`access_key` is required by `AMAZON` integration"),
+			},
+			{
+				Config:      testAccIntegrationValidateConfig(buddy.IntegrationTypeAmazon, buddy.IntegrationScopeWorkspace, `auth_type = "OIDC"`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("`role_assumption` is required by `AMAZON` integration"),
+			},
+			{
+				Config:      testAccIntegrationValidateConfig(buddy.IntegrationTypeGitHub, buddy.IntegrationScopeWorkspace, `token = "abc"`+"\n"+`shop = "abc"`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("`shop` is not supported by `GIT_HUB` integration"),
+			},
+			{
+				Config:      testAccIntegrationValidateConfig(buddy.IntegrationTypeGitHub, buddy.IntegrationScopeWorkspace, `token = "abc"`+"\n"+`auth_type = "OIDC"`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("`auth_type` is not supported by `GIT_HUB` integration"),
+			},
+			{
+				Config:      testAccIntegrationValidateConfig(buddy.IntegrationTypeAzureCloud, buddy.IntegrationScopeWorkspace, `auth_type = "TRUSTED"`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("`AZURE_CLOUD` integration supports auth types"),
+			},
+			{
+				Config:      testAccIntegrationValidateConfig(buddy.IntegrationTypeShopify, buddy.IntegrationScopeWorkspace, `token = "abc"`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("One of `shop`, `partner_token` is required"),
+			},
+			{
+				Config:      testAccIntegrationValidateConfig(buddy.IntegrationTypeGitLab, buddy.IntegrationScopeProject, `token = "abc"`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("`project_name` is required for the `PROJECT` scope"),
+			},
+		},
+	})
+}
+
+func TestIntegrationValidateConfigWriteOnly(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		ProtoV6ProviderFactories: acc.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccIntegrationValidateConfig(buddy.IntegrationTypeDigitalOcean, buddy.IntegrationScopeWorkspace, `token_wo = "abc"`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Invalid Attribute Combination"),
+			},
+			{
+				Config:      testAccIntegrationValidateConfig(buddy.IntegrationTypeDigitalOcean, buddy.IntegrationScopeWorkspace, `token = "abc"`+"\n"+`secrets_wo_version = 1`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Missing required attribute"),
+			},
+		},
+	})
+}
+
+func testAccIntegrationValidateConfig(typ string, scope string, attrs string) string {
+	return fmt.Sprintf(`
+resource "buddy_integration" "bar" {
+   domain = "test"
+   name = "test"
+   type = "%s"
+   scope = "%s"
+   %s
+}
+`, typ, scope, attrs)
+}
+
 func testAccIntegrationShopify(domain string, name string, projectNameA string, projectNameB string, scope string, scopeProjectName string) string {
 	return fmt.Sprintf(`
 resource "buddy_workspace" "foo" {
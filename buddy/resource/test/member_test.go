@@ -14,7 +14,7 @@ import (
 func TestAccMember(t *testing.T) {
 	var member buddy.Member
 	var permission buddy.Permission
-	domain := util.UniqueString()
+	domain := acc.UniqueString()
 	email := util.RandEmail()
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
@@ -72,6 +72,14 @@ func TestAccMember(t *testing.T) {
 				ImportStateVerify:       true,
 				ImportStateVerifyIgnore: []string{"auto_assign_permission_set_id"},
 			},
+			// import by email
+			{
+				ResourceName:            "buddy_member.bar",
+				ImportState:             true,
+				ImportStateIdFunc:       util.TestImportStateId("buddy_member.bar", "domain", "email"),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"auto_assign_permission_set_id"},
+			},
 		},
 	})
 }
@@ -13,7 +13,7 @@ import (
 
 func TestAccPermission(t *testing.T) {
 	var permission buddy.Permission
-	domain := util.UniqueString()
+	domain := acc.UniqueString()
 	name := util.RandString(5)
 	pipelineAccessLevel := buddy.PermissionAccessLevelRunOnly
 	repositoryAccessLevel := buddy.PermissionAccessLevelReadWrite
@@ -0,0 +1,317 @@
+package test
+
+import (
+	"fmt"
+	"github.com/buddy/api-go-sdk/buddy"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"strconv"
+	"terraform-provider-buddy/buddy/acc"
+	"terraform-provider-buddy/buddy/util"
+	"testing"
+)
+
+func TestAccPipelineAction(t *testing.T) {
+	var action buddy.PipelineAction
+	var first buddy.PipelineAction
+	domain := acc.UniqueString()
+	projectName := acc.UniqueString()
+	pipelineName := util.RandString(10)
+	name := util.RandString(10)
+	newName := util.RandString(10)
+	cmd := util.RandString(10)
+	newCmd := util.RandString(10)
+	varKey := util.RandString(10)
+	varValue := util.RandString(10)
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acc.PreCheck(t)
+		},
+		ProtoV6ProviderFactories: acc.ProviderFactories,
+		CheckDestroy:             testAccPipelineActionCheckDestroy,
+		Steps: []resource.TestStep{
+			// create action
+			{
+				Config: testAccPipelineActionConfig(domain, projectName, pipelineName, name, cmd, buddy.PipelineActionTriggerTimeOnEveryExecution, varKey, varValue),
+				Check: resource.ComposeTestCheckFunc(
+					testAccPipelineActionGet("buddy_pipeline_action.bar", &action),
+					testAccPipelineActionAttributes("buddy_pipeline_action.bar", &action, name, cmd, buddy.PipelineActionTriggerTimeOnEveryExecution),
+				),
+			},
+			// update action
+			{
+				Config: testAccPipelineActionConfig(domain, projectName, pipelineName, newName, newCmd, buddy.PipelineActionTriggerTimeOnFailure, varKey, varValue),
+				Check: resource.ComposeTestCheckFunc(
+					testAccPipelineActionGet("buddy_pipeline_action.bar", &action),
+					testAccPipelineActionAttributes("buddy_pipeline_action.bar", &action, newName, newCmd, buddy.PipelineActionTriggerTimeOnFailure),
+				),
+			},
+			// import action with variables and conditions
+			{
+				ResourceName:      "buddy_pipeline_action.bar",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// place action after another one
+			{
+				Config: testAccPipelineActionAfterConfig(domain, projectName, pipelineName, newName, newCmd, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccPipelineActionGet("buddy_pipeline_action.first", &first),
+					testAccPipelineActionGet("buddy_pipeline_action.bar", &action),
+					testAccPipelineActionAttributes("buddy_pipeline_action.bar", &action, newName, newCmd, buddy.PipelineActionTriggerTimeOnEveryExecution),
+					testAccPipelineActionCleared(&action),
+					resource.TestCheckResourceAttrPair("buddy_pipeline_action.bar", "after_action_id", "buddy_pipeline_action.first", "action_id"),
+				),
+			},
+			// import action placed after another one
+			{
+				ResourceName:      "buddy_pipeline_action.bar",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// move action back to the end
+			{
+				Config: testAccPipelineActionAfterConfig(domain, projectName, pipelineName, newName, newCmd, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccPipelineActionGet("buddy_pipeline_action.bar", &action),
+					testAccPipelineActionLast("buddy_pipeline_action.bar"),
+					resource.TestCheckNoResourceAttr("buddy_pipeline_action.bar", "after_action_id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccPipelineActionAttributes(n string, action *buddy.PipelineAction, name string, cmd string, triggerTime string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+		attrs := rs.Primary.Attributes
+		attrsActionId, _ := strconv.Atoi(attrs["action_id"])
+		if err := util.CheckFieldEqualAndSet("Name", action.Name, name); err != nil {
+			return err
+		}
+		if err := util.CheckFieldEqualAndSet("Type", action.Type, "BUILD"); err != nil {
+			return err
+		}
+		if err := util.CheckFieldEqualAndSet("TriggerTime", action.TriggerTime, triggerTime); err != nil {
+			return err
+		}
+		if err := util.CheckIntFieldEqual("len(ExecuteCommands)", len(action.ExecuteCommands), 1); err != nil {
+			return err
+		}
+		if err := util.CheckFieldEqualAndSet("ExecuteCommands[0]", action.ExecuteCommands[0], cmd); err != nil {
+			return err
+		}
+		if err := util.CheckFieldEqualAndSet("name", attrs["name"], name); err != nil {
+			return err
+		}
+		if err := util.CheckFieldEqualAndSet("type", attrs["type"], "BUILD"); err != nil {
+			return err
+		}
+		if err := util.CheckFieldEqualAndSet("trigger_time", attrs["trigger_time"], triggerTime); err != nil {
+			return err
+		}
+		if err := util.CheckFieldEqualAndSet("execute_commands.0", attrs["execute_commands.0"], cmd); err != nil {
+			return err
+		}
+		if err := util.CheckFieldEqualAndSet("html_url", attrs["html_url"], action.HtmlUrl); err != nil {
+			return err
+		}
+		if err := util.CheckIntFieldEqualAndSet("action_id", attrsActionId, action.Id); err != nil {
+			return err
+		}
+		return nil
+	}
+}
+
+func testAccPipelineActionCleared(action *buddy.PipelineAction) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if err := util.CheckIntFieldEqual("len(Variables)", len(action.Variables), 0); err != nil {
+			return err
+		}
+		if err := util.CheckIntFieldEqual("len(TriggerConditions)", len(action.TriggerConditions), 0); err != nil {
+			return err
+		}
+		return nil
+	}
+}
+
+func testAccPipelineActionLast(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+		domain, projectName, pid, aid, err := util.DecomposeQuadrupleId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		pipelineId, err := strconv.Atoi(pid)
+		if err != nil {
+			return err
+		}
+		actions, _, err := acc.ApiClient.PipelineActionService.GetList(domain, projectName, pipelineId)
+		if err != nil {
+			return err
+		}
+		if err := util.CheckIntFieldEqual("len(Actions)", len(actions.Actions), 3); err != nil {
+			return err
+		}
+		return util.CheckFieldEqual("Actions[2].Id", strconv.Itoa(actions.Actions[2].Id), aid)
+	}
+}
+
+func testAccPipelineActionGet(n string, action *buddy.PipelineAction) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+		domain, projectName, pid, aid, err := util.DecomposeQuadrupleId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		pipelineId, err := strconv.Atoi(pid)
+		if err != nil {
+			return err
+		}
+		actionId, err := strconv.Atoi(aid)
+		if err != nil {
+			return err
+		}
+		a, _, err := acc.ApiClient.PipelineActionService.Get(domain, projectName, pipelineId, actionId)
+		if err != nil {
+			return err
+		}
+		*action = *a
+		return nil
+	}
+}
+
+func testAccPipelineActionConfig(domain string, projectName string, pipelineName string, name string, cmd string, triggerTime string, varKey string, varValue string) string {
+	return fmt.Sprintf(`
+resource "buddy_workspace" "foo" {
+    domain = "%s"
+}
+
+resource "buddy_project" "proj" {
+    domain = "${buddy_workspace.foo.domain}"
+    display_name = "%s"
+}
+
+resource "buddy_pipeline" "pip" {
+    domain = "${buddy_workspace.foo.domain}"
+    project_name = "${buddy_project.proj.name}"
+    name = "%s"
+}
+
+resource "buddy_pipeline_action" "bar" {
+    domain = "${buddy_workspace.foo.domain}"
+    project_name = "${buddy_project.proj.name}"
+    pipeline_id = "${buddy_pipeline.pip.pipeline_id}"
+    name = "%s"
+    type = "BUILD"
+    docker_image_name = "library/ubuntu"
+    docker_image_tag = "22.04"
+    execute_commands = ["%s"]
+    trigger_time = "%s"
+    variable {
+        key = "%s"
+        value = "%s"
+    }
+    trigger_condition {
+        condition = "ON_CHANGE"
+    }
+}
+`, domain, projectName, pipelineName, name, cmd, triggerTime, varKey, varValue)
+}
+
+func testAccPipelineActionAfterConfig(domain string, projectName string, pipelineName string, name string, cmd string, after bool) string {
+	afterActionId := ""
+	if after {
+		afterActionId = `after_action_id = "${buddy_pipeline_action.first.action_id}"`
+	}
+	return fmt.Sprintf(`
+resource "buddy_workspace" "foo" {
+    domain = "%s"
+}
+
+resource "buddy_project" "proj" {
+    domain = "${buddy_workspace.foo.domain}"
+    display_name = "%s"
+}
+
+resource "buddy_pipeline" "pip" {
+    domain = "${buddy_workspace.foo.domain}"
+    project_name = "${buddy_project.proj.name}"
+    name = "%s"
+}
+
+resource "buddy_pipeline_action" "first" {
+    domain = "${buddy_workspace.foo.domain}"
+    project_name = "${buddy_project.proj.name}"
+    pipeline_id = "${buddy_pipeline.pip.pipeline_id}"
+    name = "first"
+    type = "BUILD"
+    docker_image_name = "library/ubuntu"
+    docker_image_tag = "22.04"
+    execute_commands = ["ls"]
+}
+
+resource "buddy_pipeline_action" "last" {
+    domain = "${buddy_workspace.foo.domain}"
+    project_name = "${buddy_project.proj.name}"
+    pipeline_id = "${buddy_pipeline.pip.pipeline_id}"
+    name = "last"
+    type = "BUILD"
+    docker_image_name = "library/ubuntu"
+    docker_image_tag = "22.04"
+    execute_commands = ["ls"]
+    depends_on = [buddy_pipeline_action.first]
+}
+
+resource "buddy_pipeline_action" "bar" {
+    domain = "${buddy_workspace.foo.domain}"
+    project_name = "${buddy_project.proj.name}"
+    pipeline_id = "${buddy_pipeline.pip.pipeline_id}"
+    %s
+    name = "%s"
+    type = "BUILD"
+    docker_image_name = "library/ubuntu"
+    docker_image_tag = "22.04"
+    execute_commands = ["%s"]
+    trigger_time = "ON_EVERY_EXECUTION"
+}
+`, domain, projectName, pipelineName, afterActionId, name, cmd)
+}
+
+func testAccPipelineActionCheckDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "buddy_pipeline_action" {
+			continue
+		}
+		domain, projectName, pid, aid, err := util.DecomposeQuadrupleId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		pipelineId, err := strconv.Atoi(pid)
+		if err != nil {
+			return err
+		}
+		actionId, err := strconv.Atoi(aid)
+		if err != nil {
+			return err
+		}
+		action, resp, err := acc.ApiClient.PipelineActionService.Get(domain, projectName, pipelineId, actionId)
+		if err == nil && action != nil {
+			return util.ErrorResourceExists()
+		}
+		if !util.IsResourceNotFound(resp, err) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+package test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/buddy/api-go-sdk/buddy"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"regexp"
+	"strconv"
+	"terraform-provider-buddy/buddy/acc"
+	"terraform-provider-buddy/buddy/util"
+	"testing"
+)
+
+func TestAccPipelineExecution(t *testing.T) {
+	var execution buddy.Execution
+	var previous buddy.Execution
+	domain := acc.UniqueString()
+	projectName := acc.UniqueString()
+	pipelineName := util.RandString(10)
+	comment := util.RandString(10)
+	trigger := util.RandString(10)
+	newTrigger := util.RandString(10)
+	varKey := util.RandString(10)
+	varValue := util.RandString(10)
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acc.PreCheck(t)
+		},
+		ProtoV6ProviderFactories: acc.ProviderFactories,
+		Steps: []resource.TestStep{
+			// run pipeline
+			{
+				Config: testAccPipelineExecutionConfig(domain, projectName, pipelineName, "echo $"+varKey, comment, trigger, varKey, varValue),
+				Check: resource.ComposeTestCheckFunc(
+					testAccPipelineExecutionGet("buddy_pipeline_execution.bar", &execution),
+					testAccPipelineExecutionAttributes("buddy_pipeline_execution.bar", &execution, comment, buddy.ExecutionStatusSuccessful),
+					testAccPipelineExecutionCopy(&execution, &previous),
+				),
+			},
+			// change triggers to run pipeline again
+			{
+				Config: testAccPipelineExecutionConfig(domain, projectName, pipelineName, "echo $"+varKey, comment, newTrigger, varKey, varValue),
+				Check: resource.ComposeTestCheckFunc(
+					testAccPipelineExecutionGet("buddy_pipeline_execution.bar", &execution),
+					testAccPipelineExecutionAttributes("buddy_pipeline_execution.bar", &execution, comment, buddy.ExecutionStatusSuccessful),
+					testAccPipelineExecutionDifferent(&execution, &previous),
+				),
+			},
+			// import execution
+			{
+				ResourceName:            "buddy_pipeline_execution.bar",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"comment", "triggers", "variable"},
+			},
+			// failed run fails the apply
+			{
+				Config:      testAccPipelineExecutionConfig(domain, projectName, pipelineName, "exit 1", comment, trigger, varKey, varValue),
+				ExpectError: regexp.MustCompile("Pipeline execution failed"),
+			},
+		},
+	})
+}
+
+func testAccPipelineExecutionCopy(execution *buddy.Execution, dst *buddy.Execution) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		*dst = *execution
+		return nil
+	}
+}
+
+func testAccPipelineExecutionDifferent(execution *buddy.Execution, previous *buddy.Execution) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if execution.Id == previous.Id {
+			return errors.New("pipeline was not run again")
+		}
+		return nil
+	}
+}
+
+func testAccPipelineExecutionAttributes(n string, execution *buddy.Execution, comment string, status string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+		attrs := rs.Primary.Attributes
+		attrsExecutionId, _ := strconv.Atoi(attrs["execution_id"])
+		if err := util.CheckFieldEqualAndSet("Status", execution.Status, status); err != nil {
+			return err
+		}
+		if err := util.CheckFieldEqualAndSet("Comment", execution.Comment, comment); err != nil {
+			return err
+		}
+		if err := util.CheckIntFieldEqualAndSet("execution_id", attrsExecutionId, execution.Id); err != nil {
+			return err
+		}
+		if err := util.CheckFieldEqualAndSet("status", attrs["status"], status); err != nil {
+			return err
+		}
+		if err := util.CheckFieldEqualAndSet("html_url", attrs["html_url"], execution.HtmlUrl); err != nil {
+			return err
+		}
+		if err := util.CheckFieldEqualAndSet("start_date", attrs["start_date"], execution.StartDate); err != nil {
+			return err
+		}
+		if err := util.CheckFieldEqualAndSet("finish_date", attrs["finish_date"], execution.FinishDate); err != nil {
+			return err
+		}
+		return nil
+	}
+}
+
+func testAccPipelineExecutionGet(n string, execution *buddy.Execution) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+		domain, projectName, pid, eid, err := util.DecomposeQuadrupleId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		pipelineId, err := strconv.Atoi(pid)
+		if err != nil {
+			return err
+		}
+		executionId, err := strconv.Atoi(eid)
+		if err != nil {
+			return err
+		}
+		e, _, err := acc.ApiClient.ExecutionService.Get(domain, projectName, pipelineId, executionId)
+		if err != nil {
+			return err
+		}
+		*execution = *e
+		return nil
+	}
+}
+
+func testAccPipelineExecutionConfig(domain string, projectName string, pipelineName string, cmd string, comment string, trigger string, varKey string, varValue string) string {
+	return fmt.Sprintf(`
+resource "buddy_workspace" "foo" {
+    domain = "%s"
+}
+
+resource "buddy_project" "proj" {
+    domain = "${buddy_workspace.foo.domain}"
+    display_name = "%s"
+    without_repository = true
+}
+
+resource "buddy_pipeline" "pip" {
+    domain = "${buddy_workspace.foo.domain}"
+    project_name = "${buddy_project.proj.name}"
+    name = "%s"
+}
+
+resource "buddy_pipeline_action" "act" {
+    domain = "${buddy_workspace.foo.domain}"
+    project_name = "${buddy_project.proj.name}"
+    pipeline_id = "${buddy_pipeline.pip.pipeline_id}"
+    name = "run"
+    type = "BUILD"
+    docker_image_name = "library/ubuntu"
+    docker_image_tag = "22.04"
+    execute_commands = ["%s"]
+}
+
+resource "buddy_pipeline_execution" "bar" {
+    domain = "${buddy_workspace.foo.domain}"
+    project_name = "${buddy_project.proj.name}"
+    pipeline_id = "${buddy_pipeline.pip.pipeline_id}"
+    comment = "%s"
+    triggers = {
+        action = "${buddy_pipeline_action.act.execute_commands[0]}"
+        trigger = "%s"
+    }
+    variable {
+        key = "%s"
+        value = "%s"
+    }
+}
+`, domain, projectName, pipelineName, cmd, comment, trigger, varKey, varValue)
+}
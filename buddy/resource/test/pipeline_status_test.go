@@ -0,0 +1,202 @@
+package test
+
+import (
+	"fmt"
+	"github.com/buddy/api-go-sdk/buddy"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"regexp"
+	"strconv"
+	"terraform-provider-buddy/buddy/acc"
+	"terraform-provider-buddy/buddy/util"
+	"testing"
+)
+
+type testAccPipelineStatusExpectedAttributes struct {
+	Paused          bool
+	Disabled        bool
+	DisablingReason string
+	Count           int
+}
+
+func TestAccPipelineStatus(t *testing.T) {
+	var p1, p2 buddy.Pipeline
+	domain := acc.UniqueString()
+	projectName := acc.UniqueString()
+	tag := util.RandString(10)
+	reason := util.RandString(10)
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acc.PreCheck(t)
+		},
+		ProtoV6ProviderFactories: acc.ProviderFactories,
+		CheckDestroy:             acc.DummyCheckDestroy,
+		Steps: []resource.TestStep{
+			// pause pipelines by tag
+			{
+				Config: testAccPipelineStatusConfig(domain, projectName, tag, fmt.Sprintf(`tags = ["%s"]
+   paused = true`, tag)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccPipelineGet("buddy_pipeline.p1", &p1),
+					testAccPipelineGet("buddy_pipeline.p2", &p2),
+					testAccPipelineStatusAttributes("buddy_pipeline_status.s", &p1, &testAccPipelineStatusExpectedAttributes{
+						Paused: true,
+						Count:  2,
+					}),
+					testAccPipelineStatusAttributes("buddy_pipeline_status.s", &p2, &testAccPipelineStatusExpectedAttributes{
+						Paused: true,
+						Count:  2,
+					}),
+				),
+			},
+			// resume and disable pipelines
+			{
+				Config: testAccPipelineStatusConfig(domain, projectName, tag, fmt.Sprintf(`tags = ["%s"]
+   paused = false
+   disabled = true
+   disabling_reason = "%s"`, tag, reason)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccPipelineGet("buddy_pipeline.p1", &p1),
+					testAccPipelineGet("buddy_pipeline.p2", &p2),
+					testAccPipelineStatusAttributes("buddy_pipeline_status.s", &p1, &testAccPipelineStatusExpectedAttributes{
+						Disabled:        true,
+						DisablingReason: reason,
+						Count:           2,
+					}),
+					testAccPipelineStatusAttributes("buddy_pipeline_status.s", &p2, &testAccPipelineStatusExpectedAttributes{
+						Disabled:        true,
+						DisablingReason: reason,
+						Count:           2,
+					}),
+				),
+			},
+			// import status
+			{
+				ResourceName:            "buddy_pipeline_status.s",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"disabling_reason"},
+			},
+			// enable pipeline by id
+			{
+				Config: testAccPipelineStatusConfig(domain, projectName, tag, `pipeline_ids = [buddy_pipeline.p1.pipeline_id]
+   disabled = false`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccPipelineGet("buddy_pipeline.p1", &p1),
+					testAccPipelineGet("buddy_pipeline.p2", &p2),
+					testAccPipelineStatusAttributes("buddy_pipeline_status.s", &p1, &testAccPipelineStatusExpectedAttributes{
+						Count: 1,
+					}),
+					testAccPipelineStatusPipeline(&p2, false, true),
+				),
+			},
+		},
+	})
+}
+
+func TestPipelineStatusValidateConfig(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acc.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "buddy_pipeline_status" "test" {
+   domain = "test"
+   project_name = "test"
+   pipeline_ids = [1]
+   disabled = false
+   disabling_reason = "test"
+}`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("`disabling_reason` can be set only when `disabled` is true"),
+			},
+			{
+				Config: `
+resource "buddy_pipeline_status" "test" {
+   domain = "test"
+   project_name = "test"
+   pipeline_ids = [1]
+   tags = ["test"]
+   paused = true
+}`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Invalid Attribute Combination"),
+			},
+		},
+	})
+}
+
+func testAccPipelineStatusPipeline(pipeline *buddy.Pipeline, paused bool, disabled bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if err := util.CheckBoolFieldEqual("Paused", pipeline.Paused, paused); err != nil {
+			return err
+		}
+		if err := util.CheckBoolFieldEqual("Disabled", pipeline.Disabled, disabled); err != nil {
+			return err
+		}
+		return nil
+	}
+}
+
+func testAccPipelineStatusAttributes(n string, pipeline *buddy.Pipeline, want *testAccPipelineStatusExpectedAttributes) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+		attrs := rs.Primary.Attributes
+		attrsSelectedCount, _ := strconv.Atoi(attrs["selected_pipeline_ids.#"])
+		if err := testAccPipelineStatusPipeline(pipeline, want.Paused, want.Disabled)(s); err != nil {
+			return err
+		}
+		if want.DisablingReason != "" {
+			if err := util.CheckFieldEqualAndSet("DisabledReason", pipeline.DisabledReason, want.DisablingReason); err != nil {
+				return err
+			}
+			if err := util.CheckFieldEqualAndSet("disabling_reason", attrs["disabling_reason"], want.DisablingReason); err != nil {
+				return err
+			}
+		}
+		if err := util.CheckIntFieldEqual("selected_pipeline_ids.#", attrsSelectedCount, want.Count); err != nil {
+			return err
+		}
+		return nil
+	}
+}
+
+func testAccPipelineStatusConfig(domain string, projectName string, tag string, status string) string {
+	return fmt.Sprintf(`
+resource "buddy_workspace" "foo" {
+   domain = "%s"
+}
+
+resource "buddy_project" "proj" {
+   domain = "${buddy_workspace.foo.domain}"
+   display_name = "%s"
+   without_repository = true
+}
+
+resource "buddy_pipeline" "p1" {
+   domain = "${buddy_workspace.foo.domain}"
+   project_name = "${buddy_project.proj.name}"
+   name = "p1"
+   tags = ["%s"]
+   ignore_status_changes = true
+}
+
+resource "buddy_pipeline" "p2" {
+   domain = "${buddy_workspace.foo.domain}"
+   project_name = "${buddy_project.proj.name}"
+   name = "p2"
+   tags = ["%s"]
+   ignore_status_changes = true
+}
+
+resource "buddy_pipeline_status" "s" {
+   domain = "${buddy_workspace.foo.domain}"
+   project_name = "${buddy_project.proj.name}"
+   %s
+   depends_on = [buddy_pipeline.p1, buddy_pipeline.p2]
+}
+`, domain, projectName, tag, tag, status)
+}
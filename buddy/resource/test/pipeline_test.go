@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"log"
+	"regexp"
 	"strconv"
 	"terraform-provider-buddy/buddy/acc"
 	"terraform-provider-buddy/buddy/util"
@@ -70,8 +71,8 @@ func TestAccPipeline_permissions(t *testing.T) {
 	var profile buddy.Profile
 	var member buddy.Member
 	var group buddy.Group
-	domain := util.UniqueString()
-	projectName := util.UniqueString()
+	domain := acc.UniqueString()
+	projectName := acc.UniqueString()
 	name := util.RandString(10)
 	ref := util.RandString(10)
 	email := util.RandEmail()
@@ -83,8 +84,8 @@ func TestAccPipeline_permissions(t *testing.T) {
 	groupPerm1 := buddy.PipelinePermissionReadWrite
 	othersPerm3 := buddy.PipelinePermissionReadWrite
 	groupPerm2 := buddy.PipelinePermissionDefault
-	loop := util.UniqueString()
-	newLoop := util.UniqueString()
+	loop := acc.UniqueString()
+	newLoop := acc.UniqueString()
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
 			acc.PreCheck(t)
@@ -265,6 +266,14 @@ func TestAccPipeline_permissions(t *testing.T) {
 				ImportStateVerify:       true,
 				ImportStateVerifyIgnore: []string{"permissions"},
 			},
+			// import pipeline by identifier
+			{
+				ResourceName:            "buddy_pipeline.bar",
+				ImportState:             true,
+				ImportStateIdFunc:       util.TestImportStateId("buddy_pipeline.bar", "domain", "project_name", "identifier"),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"permissions"},
+			},
 		},
 	})
 }
@@ -273,12 +282,12 @@ func TestAccPipeline_remoteBranch(t *testing.T) {
 	var pipeline buddy.Pipeline
 	var project buddy.Project
 	var profile buddy.Profile
-	domain := util.UniqueString()
-	projectName := util.UniqueString()
-	remoteProjectName := util.UniqueString()
-	remoteProjectName2 := util.UniqueString()
-	gitConfigBranch := util.UniqueString()
-	gitConfigPath := util.UniqueString()
+	domain := acc.UniqueString()
+	projectName := acc.UniqueString()
+	remoteProjectName := acc.UniqueString()
+	remoteProjectName2 := acc.UniqueString()
+	gitConfigBranch := acc.UniqueString()
+	gitConfigPath := acc.UniqueString()
 	gitConfigYml := fmt.Sprintf(`
   git_config = {
     project = "%s"
@@ -398,12 +407,12 @@ func TestAccPipeline_remote(t *testing.T) {
 	var pipeline buddy.Pipeline
 	var project buddy.Project
 	var profile buddy.Profile
-	domain := util.UniqueString()
-	projectName := util.UniqueString()
-	remoteProjectName := util.UniqueString()
-	remoteProjectName2 := util.UniqueString()
-	gitConfigBranch := util.UniqueString()
-	gitConfigPath := util.UniqueString()
+	domain := acc.UniqueString()
+	projectName := acc.UniqueString()
+	remoteProjectName := acc.UniqueString()
+	remoteProjectName2 := acc.UniqueString()
+	gitConfigBranch := acc.UniqueString()
+	gitConfigPath := acc.UniqueString()
 	gitConfigYml := fmt.Sprintf(`
   git_config = {
     project = "%s"
@@ -524,8 +533,8 @@ func TestAccPipeline_schedule(t *testing.T) {
 	var project buddy.Project
 	var profile buddy.Profile
 	eventType := buddy.PipelineEventTypeSchedule
-	domain := util.UniqueString()
-	projectName := util.UniqueString()
+	domain := acc.UniqueString()
+	projectName := acc.UniqueString()
 	name := util.RandString(10)
 	newName := util.RandString(10)
 	startDate := time.Now().UTC().Add(time.Hour).Format(time.RFC3339)
@@ -619,13 +628,13 @@ func TestAccPipeline_schedule_cron(t *testing.T) {
 	var project buddy.Project
 	var profile buddy.Profile
 	eventType := buddy.PipelineEventTypeSchedule
-	domain := util.UniqueString()
-	projectName := util.UniqueString()
+	domain := acc.UniqueString()
+	projectName := acc.UniqueString()
 	name := util.RandString(10)
 	newName := util.RandString(10)
 	cron := "15 14 1 * *"
 	newCron := "0 22 * * 1-5"
-	newTimezone := "Europer/Warsaw"
+	newTimezone := "Europe/Warsaw"
 	reason := util.RandString(10)
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
@@ -767,6 +776,96 @@ resource "buddy_pipeline" "bar" {
 `, domain, projectName, name, cron, timezone, paused)
 }
 
+func TestPipelineValidateConfig(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acc.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPipelineValidateConfig(`event {
+  type = "SCHEDULE"
+  cron = "61 * * * *"
+}`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("`event.cron` is not a valid cron expression"),
+			},
+			{
+				Config: testAccPipelineValidateConfig(`event {
+  type = "SCHEDULE"
+  timezone = "Europe/Warsaw"
+}`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("`event.cron` or `event.delay` with `event.start_date` is required by the `SCHEDULE` event"),
+			},
+			{
+				Config: testAccPipelineValidateConfig(`event {
+  type = "SCHEDULE"
+  cron = "0 22 * * MON-FRI"
+  timezone = "Europer/Warsaw"
+}`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("`event.timezone` must be a valid IANA time zone"),
+			},
+			{
+				Config: testAccPipelineValidateConfig(`event {
+  type = "PUSH"
+}`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("`event.refs` or `event.branches` is required by the `PUSH` event"),
+			},
+			{
+				Config: testAccPipelineValidateConfig(`event {
+  type = "PUSH"
+  refs = ["refs/heads/master"]
+  cron = "15 14 1 * *"
+}`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("`event.cron` is not supported by the `PUSH` event"),
+			},
+			{
+				Config: testAccPipelineValidateConfig(`trigger_condition {
+  condition = "DATETIME"
+  hours = [24]
+  days = [1, 20]
+}`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("`trigger_condition.days` values must be between 1 and 7"),
+			},
+			{
+				Config: testAccPipelineValidateConfig(`trigger_condition {
+  condition = "VAR_IS"
+  variable_key = "KEY"
+}`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("`trigger_condition.variable_value` is required by the `VAR_IS` condition"),
+			},
+			{
+				Config: testAccPipelineValidateConfig(`trigger_condition {
+  condition = "ON_CHANGE"
+  paths = ["/abc"]
+}`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("`trigger_condition.paths` is not supported by the `ON_CHANGE` condition"),
+			},
+			{
+				Config: testAccPipelineValidateConfig(`ignore_status_changes = true
+  paused = true`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("`paused` can't be set when `ignore_status_changes` is true"),
+			},
+		},
+	})
+}
+
+func testAccPipelineValidateConfig(blocks string) string {
+	return fmt.Sprintf(`
+resource "buddy_pipeline" "test" {
+    domain       = "test"
+    project_name = "test"
+    name         = "test"
+    %s
+}`, blocks)
+}
+
 func testAccPipelineConfigScheduleCronDisabled(domain string, projectName string, name string, cron string, paused bool, disabled bool, reason string) string {
 	return fmt.Sprintf(`
 resource "buddy_workspace" "foo" {
@@ -999,8 +1098,8 @@ func TestAccPipeline_event_email(t *testing.T) {
 	var pipeline buddy.Pipeline
 	var project buddy.Project
 	var profile buddy.Profile
-	domain := util.UniqueString()
-	projectName := util.UniqueString()
+	domain := acc.UniqueString()
+	projectName := acc.UniqueString()
 	name := util.RandString(10)
 	newName := util.RandString(10)
 	eventType := buddy.PipelineEventTypeEmail
@@ -1078,8 +1177,8 @@ func TestAccPipeline_event_webhook(t *testing.T) {
 	var pipeline buddy.Pipeline
 	var project buddy.Project
 	var profile buddy.Profile
-	domain := util.UniqueString()
-	projectName := util.UniqueString()
+	domain := acc.UniqueString()
+	projectName := acc.UniqueString()
 	name := util.RandString(10)
 	newName := util.RandString(10)
 	eventType := buddy.PipelineEventTypeWebhook
@@ -1147,8 +1246,8 @@ func TestAccPipeline_event_pull_request(t *testing.T) {
 	var pipeline buddy.Pipeline
 	var project buddy.Project
 	var profile buddy.Profile
-	domain := util.UniqueString()
-	projectName := util.UniqueString()
+	domain := acc.UniqueString()
+	projectName := acc.UniqueString()
 	name := util.RandString(10)
 	newName := util.RandString(10)
 	branch := util.RandString(10)
@@ -1222,12 +1321,12 @@ func TestAccPipeline_event(t *testing.T) {
 	var pipeline buddy.Pipeline
 	var project buddy.Project
 	var profile buddy.Profile
-	domain := util.UniqueString()
-	projectName := util.UniqueString()
+	domain := acc.UniqueString()
+	projectName := acc.UniqueString()
 	name := util.RandString(10)
 	newName := util.RandString(10)
-	identifier := util.UniqueString()
-	newIdentifier := util.UniqueString()
+	identifier := acc.UniqueString()
+	newIdentifier := acc.UniqueString()
 	ref := util.RandString(10)
 	newRef := util.RandString(10)
 	tcChangePath := "/path"
@@ -1416,8 +1515,8 @@ func TestAccPipeline_click(t *testing.T) {
 	var pipeline buddy.Pipeline
 	var project buddy.Project
 	var profile buddy.Profile
-	domain := util.UniqueString()
-	projectName := util.UniqueString()
+	domain := acc.UniqueString()
+	projectName := acc.UniqueString()
 	name := util.RandString(10)
 	newName := util.RandString(10)
 	ref := util.RandString(10)
@@ -2662,6 +2761,84 @@ resource "buddy_pipeline" "bar" {
 `, domain, email, groupName, projectName, name, ref)
 }
 
+func TestAccPipeline_tags(t *testing.T) {
+	var pipeline buddy.Pipeline
+	domain := acc.UniqueString()
+	projectName := acc.UniqueString()
+	name := util.RandString(10)
+	tag := util.RandString(10)
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acc.PreCheck(t)
+		},
+		ProtoV6ProviderFactories: acc.ProviderFactories,
+		CheckDestroy:             testAccPipelineCheckDestroy,
+		Steps: []resource.TestStep{
+			// create pipeline
+			{
+				Config: testAccPipelineConfigTags(domain, projectName, name, tag),
+				Check: resource.ComposeTestCheckFunc(
+					testAccPipelineGet("buddy_pipeline.bar", &pipeline),
+					resource.TestCheckResourceAttr("buddy_pipeline.bar", "tags.#", "1"),
+					resource.TestCheckTypeSetElemAttr("buddy_pipeline.bar", "tags.*", tag),
+					resource.TestCheckResourceAttr("buddy_pipeline.bar", "tags_all.#", "1"),
+				),
+			},
+			// detect tags changed outside of terraform
+			{
+				PreConfig: func() {
+					tags := []string{tag, "drift"}
+					_, _, err := acc.ApiClient.PipelineService.Update(domain, projectName, pipeline.Id, &buddy.PipelineOps{
+						Tags: &tags,
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:             testAccPipelineConfigTags(domain, projectName, name, tag),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// restore tags
+			{
+				Config: testAccPipelineConfigTags(domain, projectName, name, tag),
+				Check: resource.ComposeTestCheckFunc(
+					testAccPipelineGet("buddy_pipeline.bar", &pipeline),
+					resource.TestCheckResourceAttr("buddy_pipeline.bar", "tags.#", "1"),
+					resource.TestCheckTypeSetElemAttr("buddy_pipeline.bar", "tags.*", tag),
+					resource.TestCheckResourceAttr("buddy_pipeline.bar", "tags_all.#", "1"),
+				),
+			},
+			// import pipeline
+			{
+				ResourceName:      "buddy_pipeline.bar",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccPipelineConfigTags(domain string, projectName string, name string, tag string) string {
+	return fmt.Sprintf(`
+resource "buddy_workspace" "foo" {
+    domain = "%s"
+}
+
+resource "buddy_project" "proj" {
+    domain = "${buddy_workspace.foo.domain}"
+    display_name = "%s"
+}
+
+resource "buddy_pipeline" "bar" {
+    domain = "${buddy_workspace.foo.domain}"
+    project_name = "${buddy_project.proj.name}"
+    name = "%s"
+    tags = ["%s"]
+}
+`, domain, projectName, name, tag)
+}
+
 func testAccPipelineConfigClick(domain string, projectName string, name string, alwaysFromScratch bool, failOnPrepareEnvWarning bool, fetchAllRefs bool, autoClearCache bool, noSkipToMostRecent bool, doNotCreateCommitStatus bool, ignoreFailOnProjectStatus bool, executionMessageTemplate string, targetSiteUrl string, ref string, cloneDepth int, cpu string, managePermissionsByYaml bool, manageVariablesByYaml bool) string {
 	return fmt.Sprintf(`
 resource "buddy_workspace" "foo" {
@@ -0,0 +1,298 @@
+package test
+
+import (
+	"fmt"
+	"github.com/buddy/api-go-sdk/buddy"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"regexp"
+	"strconv"
+	"terraform-provider-buddy/buddy/acc"
+	"terraform-provider-buddy/buddy/util"
+	"testing"
+)
+
+func TestAccPipelineYaml(t *testing.T) {
+	var pipeline buddy.Pipeline
+	domain := acc.UniqueString()
+	projectName := acc.UniqueString()
+	name := util.RandString(10)
+	newName := util.RandString(10)
+	actionName := util.RandString(10)
+	newActionName := util.RandString(10)
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acc.PreCheck(t)
+		},
+		CheckDestroy:             testAccPipelineYamlCheckDestroy,
+		ProtoV6ProviderFactories: acc.ProviderFactories,
+		Steps: []resource.TestStep{
+			// create pipeline
+			{
+				Config: testAccPipelineYamlConfig(domain, projectName, fmt.Sprintf(`
+- pipeline: "%s"
+  actions:
+    - action: "%s"
+      type: "BUILD"
+      docker_image_name: "library/ubuntu"
+      docker_image_tag: "22.04"
+      execute_commands:
+        - "ls"
+`, name, actionName)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccPipelineYamlGet("buddy_pipeline_yaml.bar", &pipeline),
+					testAccPipelineYamlAttributes("buddy_pipeline_yaml.bar", &pipeline, name, actionName),
+				),
+			},
+			// reformat yaml without changes
+			{
+				Config: testAccPipelineYamlConfig(domain, projectName, fmt.Sprintf(`
+-   actions:
+    -   execute_commands: [ls]
+        docker_image_tag: '22.04'
+        docker_image_name: library/ubuntu
+        type: BUILD
+        action: %s
+    pipeline: %s
+`, actionName, name)),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("buddy_pipeline_yaml.bar", plancheck.ResourceActionNoop),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					testAccPipelineYamlGet("buddy_pipeline_yaml.bar", &pipeline),
+					testAccPipelineYamlAttributes("buddy_pipeline_yaml.bar", &pipeline, name, actionName),
+				),
+			},
+			// update pipeline
+			{
+				Config: testAccPipelineYamlConfig(domain, projectName, fmt.Sprintf(`
+- pipeline: "%s"
+  actions:
+    - action: "%s"
+      type: "BUILD"
+      docker_image_name: "library/ubuntu"
+      docker_image_tag: "22.04"
+      execute_commands:
+        - "pwd"
+`, newName, newActionName)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccPipelineYamlGet("buddy_pipeline_yaml.bar", &pipeline),
+					testAccPipelineYamlAttributes("buddy_pipeline_yaml.bar", &pipeline, newName, newActionName),
+				),
+			},
+			// import pipeline
+			{
+				ResourceName:            "buddy_pipeline_yaml.bar",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"yaml"},
+			},
+		},
+	})
+}
+
+func TestAccPipelineYaml_mapping(t *testing.T) {
+	var pipeline buddy.Pipeline
+	domain := acc.UniqueString()
+	projectName := acc.UniqueString()
+	name := util.RandString(10)
+	actionName := util.RandString(10)
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acc.PreCheck(t)
+		},
+		CheckDestroy:             testAccPipelineYamlCheckDestroy,
+		ProtoV6ProviderFactories: acc.ProviderFactories,
+		Steps: []resource.TestStep{
+			// create pipeline from single mapping
+			{
+				Config: testAccPipelineYamlConfig(domain, projectName, fmt.Sprintf(`
+pipeline: "%s"
+actions:
+  - action: "%s"
+    type: "BUILD"
+    docker_image_name: "library/ubuntu"
+    docker_image_tag: "22.04"
+    execute_commands:
+      - "ls"
+`, name, actionName)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccPipelineYamlGet("buddy_pipeline_yaml.bar", &pipeline),
+					testAccPipelineYamlAttributes("buddy_pipeline_yaml.bar", &pipeline, name, actionName),
+				),
+			},
+			// same pipeline as list
+			{
+				Config: testAccPipelineYamlConfig(domain, projectName, fmt.Sprintf(`
+- pipeline: "%s"
+  actions:
+    - action: "%s"
+      type: "BUILD"
+      docker_image_name: "library/ubuntu"
+      docker_image_tag: "22.04"
+      execute_commands:
+        - "ls"
+`, name, actionName)),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("buddy_pipeline_yaml.bar", plancheck.ResourceActionNoop),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					testAccPipelineYamlGet("buddy_pipeline_yaml.bar", &pipeline),
+					testAccPipelineYamlAttributes("buddy_pipeline_yaml.bar", &pipeline, name, actionName),
+				),
+			},
+		},
+	})
+}
+
+func TestPipelineYamlValidateConfig(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acc.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "buddy_pipeline_yaml" "test" {
+   domain = "test"
+   project_name = "test"
+   yaml = <<-EOT
+     - pipeline: "a"
+     - pipeline: "b"
+   EOT
+}`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("must define exactly one pipeline"),
+			},
+			{
+				Config: `
+resource "buddy_pipeline_yaml" "test" {
+   domain = "test"
+   project_name = "test"
+   yaml = "pipeline: [a"
+}`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("is not a valid YAML"),
+			},
+			{
+				Config: `
+resource "buddy_pipeline_yaml" "test" {
+   domain = "test"
+   project_name = "test"
+   yaml = <<-EOT
+     pipeline: "a"
+     actions:
+       - action: "build"
+       - action: "build"
+   EOT
+}`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("action names must be unique"),
+			},
+		},
+	})
+}
+
+func testAccPipelineYamlAttributes(n string, pipeline *buddy.Pipeline, name string, actionName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+		attrs := rs.Primary.Attributes
+		attrsPipelineId, _ := strconv.Atoi(attrs["pipeline_id"])
+		attrsActionIdsCount, _ := strconv.Atoi(attrs["action_ids.%"])
+		attrsActionId, _ := strconv.Atoi(attrs["action_ids."+actionName])
+		if err := util.CheckFieldEqualAndSet("Name", pipeline.Name, name); err != nil {
+			return err
+		}
+		if err := util.CheckIntFieldEqualAndSet("pipeline_id", attrsPipelineId, pipeline.Id); err != nil {
+			return err
+		}
+		if err := util.CheckFieldEqualAndSet("name", attrs["name"], name); err != nil {
+			return err
+		}
+		if err := util.CheckFieldEqualAndSet("html_url", attrs["html_url"], pipeline.HtmlUrl); err != nil {
+			return err
+		}
+		if err := util.CheckIntFieldEqual("action_ids.%", attrsActionIdsCount, 1); err != nil {
+			return err
+		}
+		if err := util.CheckIntFieldSet("action_ids."+actionName, attrsActionId); err != nil {
+			return err
+		}
+		return nil
+	}
+}
+
+func testAccPipelineYamlGet(n string, pipeline *buddy.Pipeline) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+		domain, projectName, pid, err := util.DecomposeTripleId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		pipelineId, err := strconv.Atoi(pid)
+		if err != nil {
+			return err
+		}
+		p, _, err := acc.ApiClient.PipelineService.Get(domain, projectName, pipelineId)
+		if err != nil {
+			return err
+		}
+		*pipeline = *p
+		return nil
+	}
+}
+
+func testAccPipelineYamlConfig(domain string, projectName string, yaml string) string {
+	return fmt.Sprintf(`
+resource "buddy_workspace" "foo" {
+   domain = "%s"
+}
+
+resource "buddy_project" "proj" {
+   domain = "${buddy_workspace.foo.domain}"
+   display_name = "%s"
+   without_repository = true
+}
+
+resource "buddy_pipeline_yaml" "bar" {
+   domain = "${buddy_workspace.foo.domain}"
+   project_name = "${buddy_project.proj.name}"
+   yaml = <<-EOT
+%s
+   EOT
+}
+`, domain, projectName, yaml)
+}
+
+func testAccPipelineYamlCheckDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "buddy_pipeline_yaml" {
+			continue
+		}
+		domain, projectName, pid, err := util.DecomposeTripleId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		pipelineId, err := strconv.Atoi(pid)
+		if err != nil {
+			return err
+		}
+		pipeline, resp, err := acc.ApiClient.PipelineService.Get(domain, projectName, pipelineId)
+		if err == nil && pipeline != nil {
+			return util.ErrorResourceExists()
+		}
+		if !util.IsResourceNotFound(resp, err) {
+			return err
+		}
+	}
+	return nil
+}
@@ -13,7 +13,7 @@ import (
 
 func TestAccProjectGroup(t *testing.T) {
 	var group buddy.ProjectGroup
-	domain := util.UniqueString()
+	domain := acc.UniqueString()
 	nameA := util.RandString(10)
 	nameB := util.RandString(10)
 	projectDisplayNameA := util.RandString(10)
@@ -13,7 +13,7 @@ import (
 
 func TestAccProjectMember(t *testing.T) {
 	var member buddy.ProjectMember
-	domain := util.UniqueString()
+	domain := acc.UniqueString()
 	emailA := util.RandEmail()
 	emailB := util.RandEmail()
 	projectDisplayNameA := util.RandString(10)
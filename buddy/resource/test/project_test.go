@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/buddy/api-go-sdk/buddy"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"strconv"
 	"terraform-provider-buddy/buddy/acc"
@@ -13,7 +14,7 @@ import (
 
 func TestAccProject_withoutRepository(t *testing.T) {
 	var project buddy.Project
-	domain := util.UniqueString()
+	domain := acc.UniqueString()
 	displayName := util.RandString(10)
 	newDisplayName := util.RandString(10)
 	resource.Test(t, resource.TestCase{
@@ -50,9 +51,53 @@ func TestAccProject_withoutRepository(t *testing.T) {
 	})
 }
 
+func TestAccProject_providerDomain(t *testing.T) {
+	var project buddy.Project
+	domain := acc.UniqueString()
+	newDomain := acc.UniqueString()
+	displayName := util.RandString(10)
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acc.PreCheck(t)
+		},
+		ProtoV6ProviderFactories: acc.ProviderFactories,
+		CheckDestroy:             testAccProjectCheckDestroy,
+		Steps: []resource.TestStep{
+			// create project in provider default workspace
+			{
+				Config: testAccProjectProviderDomainConfig(domain, newDomain, domain, displayName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccProjectGet("buddy_project.bar", &project),
+					resource.TestCheckResourceAttr("buddy_project.bar", "domain", domain),
+				),
+			},
+			// change provider default workspace
+			{
+				Config: testAccProjectProviderDomainConfig(domain, newDomain, newDomain, displayName),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("buddy_project.bar", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					testAccProjectGet("buddy_project.bar", &project),
+					resource.TestCheckResourceAttr("buddy_project.bar", "domain", newDomain),
+				),
+			},
+			// import project
+			{
+				ResourceName:            "buddy_project.bar",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"without_repository"},
+			},
+		},
+	})
+}
+
 func TestAccProject_buddy(t *testing.T) {
 	var project buddy.Project
-	domain := util.UniqueString()
+	domain := acc.UniqueString()
 	displayName := util.RandString(10)
 	newDisplayName := util.RandString(10)
 	access := buddy.ProjectAccessPublic
@@ -93,7 +138,7 @@ func TestAccProject_buddy(t *testing.T) {
 func TestAccProject_custom(t *testing.T) {
 	var project buddy.Project
 	repoUrl := "git@github.com:octocat/Hello-World.git"
-	domain := util.UniqueString()
+	domain := acc.UniqueString()
 	displayName := util.RandString(10)
 	newDisplayName := util.RandString(10)
 	fetchSubmodulesEnv := "id_workspace"
@@ -281,6 +326,28 @@ resource "buddy_project" "bar" {
 `, domain, name)
 }
 
+func testAccProjectProviderDomainConfig(domain string, newDomain string, providerDomain string, name string) string {
+	return fmt.Sprintf(`
+provider "buddy" {
+   domain = "%s"
+}
+
+resource "buddy_workspace" "foo" {
+   domain = "%s"
+}
+
+resource "buddy_workspace" "foo2" {
+   domain = "%s"
+}
+
+resource "buddy_project" "bar" {
+   depends_on = [buddy_workspace.foo, buddy_workspace.foo2]
+   display_name = "%s"
+   without_repository = true
+}
+`, providerDomain, domain, newDomain, name)
+}
+
 func testAccProjectCheckDestroy(s *terraform.State) error {
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "buddy_project" {
@@ -44,8 +44,8 @@ func testAccSandboxStatusAttributes(n string, sandbox *buddy.Sandbox, want *test
 
 func TestAccSandboxStatus(t *testing.T) {
 	var sandbox buddy.Sandbox
-	domain := util.UniqueString()
-	projectName := util.UniqueString()
+	domain := acc.UniqueString()
+	projectName := acc.UniqueString()
 	name := util.RandString(10)
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
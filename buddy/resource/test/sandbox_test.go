@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"github.com/buddy/api-go-sdk/buddy"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 	"terraform-provider-buddy/buddy/acc"
 	"terraform-provider-buddy/buddy/util"
 	"testing"
@@ -13,10 +15,10 @@ import (
 func TestAccSandbox_wait(t *testing.T) {
 	var sandbox buddy.Sandbox
 	var project buddy.Project
-	domain := util.UniqueString()
-	projectName := util.UniqueString()
+	domain := acc.UniqueString()
+	projectName := acc.UniqueString()
 	timeout := 500
-	name := util.RandString(10)
+	name := acc.UniqueString()
 	installCommands := "sleep 10"
 	runCommand := "while :; do foo; sleep 2; done"
 	resource.Test(t, resource.TestCase{
@@ -47,15 +49,110 @@ func TestAccSandbox_wait(t *testing.T) {
 	})
 }
 
+func TestAccSandboxUpgradeFromV1_40(t *testing.T) {
+	var sandbox buddy.Sandbox
+	domain := acc.UniqueString()
+	projectName := acc.UniqueString()
+	name := acc.UniqueString()
+	runCommand := "while :; do foo; sleep 2; done"
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acc.PreCheck(t)
+		},
+		CheckDestroy: testAccSandboxCheckDestroy,
+		Steps: []resource.TestStep{
+			// create sandbox with the single run command
+			{
+				ExternalProviders: map[string]resource.ExternalProvider{
+					"buddy": {
+						Source:            "buddy/buddy",
+						VersionConstraint: "1.40.0",
+					},
+				},
+				Config: testAccSandboxV1_40Config(domain, projectName, name, runCommand),
+			},
+			// upgrade state
+			{
+				ProtoV6ProviderFactories: acc.ProviderFactories,
+				Config:                   testAccSandboxV1_41Config(domain, projectName, name, runCommand),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("buddy_sandbox.bar", plancheck.ResourceActionNoop),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					testAccSandboxGet("buddy_sandbox.bar", &sandbox),
+					resource.TestCheckNoResourceAttr("buddy_sandbox.bar", "run_command"),
+					resource.TestCheckResourceAttr("buddy_sandbox.bar", "app_commands.#", "1"),
+					resource.TestCheckTypeSetElemAttr("buddy_sandbox.bar", "app_commands.*", runCommand),
+					resource.TestCheckResourceAttr("buddy_sandbox.bar", "wait_for_running", "false"),
+					resource.TestCheckResourceAttr("buddy_sandbox.bar", "wait_for_configured", "false"),
+					resource.TestCheckResourceAttr("buddy_sandbox.bar", "wait_for_apps", "false"),
+					resource.TestCheckResourceAttr("buddy_sandbox.bar", "wait_for_running_timeout", "120"),
+					resource.TestCheckResourceAttr("buddy_sandbox.bar", "wait_for_configured_timeout", "120"),
+					resource.TestCheckResourceAttr("buddy_sandbox.bar", "wait_for_apps_timeout", "120"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSandbox_endpointsWriteOnly(t *testing.T) {
+	var sandbox buddy.Sandbox
+	domain := acc.UniqueString()
+	projectName := acc.UniqueString()
+	name := acc.UniqueString()
+	login := util.RandString(10)
+	password := util.RandString(10)
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acc.PreCheck(t)
+		},
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		ProtoV6ProviderFactories: acc.ProviderFactories,
+		CheckDestroy:             testAccSandboxCheckDestroy,
+		Steps: []resource.TestStep{
+			// create with write-only endpoint password
+			{
+				Config: testAccSandboxConfigEndpointAuth(domain, projectName, name, login, "", fmt.Sprintf(`
+		endpoints_wo = {
+			"www" = {
+				password = "%s"
+			}
+		}
+		endpoints_wo_version = 1`, password)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccSandboxGet("buddy_sandbox.bar", &sandbox),
+					resource.TestCheckResourceAttr("buddy_sandbox.bar", "endpoints.www.http.login", login),
+					resource.TestCheckNoResourceAttr("buddy_sandbox.bar", "endpoints_wo.%"),
+					resource.TestCheckResourceAttr("buddy_sandbox.bar", "endpoints_wo_version", "1"),
+				),
+			},
+			// switch to endpoint password
+			{
+				Config: testAccSandboxConfigEndpointAuth(domain, projectName, name, login, password, ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccSandboxGet("buddy_sandbox.bar", &sandbox),
+					resource.TestCheckResourceAttr("buddy_sandbox.bar", "endpoints.www.http.login", login),
+					resource.TestCheckNoResourceAttr("buddy_sandbox.bar", "endpoints_wo.%"),
+					resource.TestCheckNoResourceAttr("buddy_sandbox.bar", "endpoints_wo_version"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccSandbox_main(t *testing.T) {
 	var sandbox buddy.Sandbox
 	var project buddy.Project
-	domain := util.UniqueString()
-	projectName := util.UniqueString()
-	identifier := util.UniqueString()
-	newIdentifier := util.UniqueString()
-	name := util.RandString(10)
-	newName := util.RandString(10)
+	domain := acc.UniqueString()
+	projectName := acc.UniqueString()
+	identifier := acc.UniqueString()
+	newIdentifier := acc.UniqueString()
+	name := acc.UniqueString()
+	newName := acc.UniqueString()
 	installCommands := "pwd"
 	runCommand := "while :; do foo; sleep 2; done"
 	appDir := "/"
@@ -64,13 +161,13 @@ func TestAccSandbox_main(t *testing.T) {
 	resources := buddy.SandboxResource2X4
 	tag := util.RandString(10)
 	newTag := util.RandString(10)
-	tcpName := util.UniqueString()
+	tcpName := acc.UniqueString()
 	tcpEndpoint := "22"
 	newTcpEndppoint := "123"
-	tlsName := util.UniqueString()
+	tlsName := acc.UniqueString()
 	tlsEndpoint := "222"
 	tlsTerminateAt := buddy.SandboxEndpointTlsTerminateAtRegion
-	httpName := util.UniqueString()
+	httpName := acc.UniqueString()
 	httpEndpoint := "444"
 	httpXHeader := util.RandString(10)
 	resource.Test(t, resource.TestCase{
@@ -495,6 +592,80 @@ resource "buddy_sandbox" "bar" {
 `, domain, projectName, name, installCommands, runCommand, timeout)
 }
 
+func testAccSandboxV1_40Config(domain string, projectName string, name string, runCommand string) string {
+	return fmt.Sprintf(`
+resource "buddy_workspace" "foo" {
+		domain = "%s"
+}
+
+resource "buddy_project" "proj" {
+		domain = "${buddy_workspace.foo.domain}"
+		display_name = "%s"
+}
+
+resource "buddy_sandbox" "bar" {
+		domain = "${buddy_workspace.foo.domain}"
+		project_name = "${buddy_project.proj.name}"
+		name = "%s"
+		run_command = "%s"
+}
+`, domain, projectName, name, runCommand)
+}
+
+func testAccSandboxV1_41Config(domain string, projectName string, name string, runCommand string) string {
+	return fmt.Sprintf(`
+resource "buddy_workspace" "foo" {
+		domain = "%s"
+}
+
+resource "buddy_project" "proj" {
+		domain = "${buddy_workspace.foo.domain}"
+		display_name = "%s"
+}
+
+resource "buddy_sandbox" "bar" {
+		domain = "${buddy_workspace.foo.domain}"
+		project_name = "${buddy_project.proj.name}"
+		name = "%s"
+		app_commands = ["%s"]
+}
+`, domain, projectName, name, runCommand)
+}
+
+func testAccSandboxConfigEndpointAuth(domain string, projectName string, name string, login string, password string, writeOnly string) string {
+	if password != "" {
+		password = fmt.Sprintf(`password = "%s"`, password)
+	}
+	return fmt.Sprintf(`
+resource "buddy_workspace" "foo" {
+		domain = "%s"
+}
+
+resource "buddy_project" "proj" {
+		domain = "${buddy_workspace.foo.domain}"
+		display_name = "%s"
+}
+
+resource "buddy_sandbox" "bar" {
+		domain = "${buddy_workspace.foo.domain}"
+		project_name = "${buddy_project.proj.name}"
+		name = "%s"
+		endpoints = {
+			"www" = {
+				endpoint = "80"
+				type = "HTTP"
+				http = {
+					auth_type = "BASIC"
+					login = "%s"
+					%s
+				}
+			}
+		}
+		%s
+}
+`, domain, projectName, name, login, password, writeOnly)
+}
+
 func testAccSandboxConfigOneEndpoint(domain string, projectName string, identifier string, name string, installCommands string, runCommand string, appDir string, os string, resources string, tag string, tcpName string, tcpEndpoint string, othersAccessLevel string) string {
 	return fmt.Sprintf(`
 resource "buddy_workspace" "foo" {
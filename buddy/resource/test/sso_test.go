@@ -5,6 +5,7 @@ import (
 	"github.com/buddy/api-go-sdk/buddy"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 	"strconv"
 	"terraform-provider-buddy/buddy/acc"
 	"terraform-provider-buddy/buddy/util"
@@ -13,12 +14,12 @@ import (
 
 func TestAccSsoOIDC(t *testing.T) {
 	var sso buddy.Sso
-	domain := util.UniqueString()
-	issuer := "https://sts.windows.net/" + util.UniqueString()
-	newIssuer := "https://sts.windows.net/" + util.UniqueString()
-	clientId := util.UniqueString()
-	clientSecret := util.UniqueString()
-	newClientSecret := util.UniqueString()
+	domain := acc.UniqueString()
+	issuer := "https://sts.windows.net/" + acc.UniqueString()
+	newIssuer := "https://sts.windows.net/" + acc.UniqueString()
+	clientId := acc.UniqueString()
+	clientSecret := acc.UniqueString()
+	newClientSecret := acc.UniqueString()
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
 			acc.PreCheck(t)
@@ -60,19 +61,61 @@ func TestAccSsoOIDC(t *testing.T) {
 	})
 }
 
+func TestAccSsoOIDC_writeOnly(t *testing.T) {
+	var sso buddy.Sso
+	domain := acc.UniqueString()
+	issuer := "https://sts.windows.net/" + acc.UniqueString()
+	clientId := acc.UniqueString()
+	clientSecret := acc.UniqueString()
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acc.PreCheck(t)
+		},
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		ProtoV6ProviderFactories: acc.ProviderFactories,
+		CheckDestroy:             testAccSsoCheckDestroy,
+		Steps: []resource.TestStep{
+			// create with write-only client secret
+			{
+				Config: testAccSsoOidcWriteOnlyConfig(domain, issuer, clientId, clientSecret, 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccSsoGet("buddy_sso.bar", &sso),
+					testAccSsoAttributes("buddy_sso.bar", &sso, domain, buddy.SsoTypeOidc, "", issuer, "", "", "", false),
+					resource.TestCheckNoResourceAttr("buddy_sso.bar", "client_secret"),
+					resource.TestCheckNoResourceAttr("buddy_sso.bar", "client_secret_wo"),
+					resource.TestCheckResourceAttr("buddy_sso.bar", "client_secret_wo_version", "1"),
+				),
+			},
+			// switch to client secret
+			{
+				Config: testAccSsoOidcConfig(domain, issuer, clientId, clientSecret),
+				Check: resource.ComposeTestCheckFunc(
+					testAccSsoGet("buddy_sso.bar", &sso),
+					testAccSsoAttributes("buddy_sso.bar", &sso, domain, buddy.SsoTypeOidc, "", issuer, "", "", "", false),
+					resource.TestCheckResourceAttr("buddy_sso.bar", "client_secret", clientSecret),
+					resource.TestCheckNoResourceAttr("buddy_sso.bar", "client_secret_wo"),
+					resource.TestCheckNoResourceAttr("buddy_sso.bar", "client_secret_wo_version"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccSso(t *testing.T) {
 	var sso buddy.Sso
-	domain := util.UniqueString()
-	ssoUrl := "https://login.microsoftonline.com/" + util.UniqueString() + "/saml2"
-	issuer := "https://sts.windows.net/" + util.UniqueString()
+	domain := acc.UniqueString()
+	ssoUrl := "https://login.microsoftonline.com/" + acc.UniqueString() + "/saml2"
+	issuer := "https://sts.windows.net/" + acc.UniqueString()
 	signature := buddy.SignatureMethodSha256
 	digest := buddy.DigestMethodSha256
 	cert, err := util.GenerateCertificate()
 	if err != nil {
 		t.Fatal(err.Error())
 	}
-	newSsoUrl := "https://login.microsoftonline.com/" + util.UniqueString() + "/saml2"
-	newIssuer := "https://sts.windows.net/" + util.UniqueString()
+	newSsoUrl := "https://login.microsoftonline.com/" + acc.UniqueString() + "/saml2"
+	newIssuer := "https://sts.windows.net/" + acc.UniqueString()
 	newSignature := buddy.SignatureMethodSha512
 	newDigest := buddy.DigestMethodSha512
 	newCert, err := util.GenerateCertificate()
@@ -228,6 +271,22 @@ resource "buddy_sso" "bar" {
 }`, domain, issuer, clientId, clientSecret)
 }
 
+func testAccSsoOidcWriteOnlyConfig(domain string, issuer string, clientId string, clientSecret string, version int) string {
+	return fmt.Sprintf(`
+resource "buddy_workspace" "foo" {
+   domain = "%s"
+}
+
+resource "buddy_sso" "bar" {
+   domain = "${buddy_workspace.foo.domain}"
+   type = "OIDC"
+   issuer = "%s"
+   client_id = "%s"
+   client_secret_wo = "%s"
+   client_secret_wo_version = %d
+}`, domain, issuer, clientId, clientSecret, version)
+}
+
 func testAccSsoOidcConfigRequireForAll(domain string, issuer string, clientId string, clientSecret string) string {
 	return fmt.Sprintf(`
 resource "buddy_workspace" "foo" {
@@ -0,0 +1,156 @@
+package test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/buddy/api-go-sdk/buddy"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"log"
+	"strings"
+	"terraform-provider-buddy/buddy/acc"
+	"terraform-provider-buddy/buddy/util"
+	"testing"
+)
+
+// sweepers are run with `make sweep SWEEP=<domain>[,<domain>]`, domain is the workspace in which leaked objects are removed.
+// Test workspaces (prefixed with acc.TestPrefix) are removed with all of their objects
+func TestMain(m *testing.M) {
+	resource.TestMain(m)
+}
+
+func init() {
+	resource.AddTestSweepers("buddy_workspace", &resource.Sweeper{
+		Name:         "buddy_workspace",
+		F:            sweepWorkspaces,
+		Dependencies: []string{"buddy_project", "buddy_integration"},
+	})
+	resource.AddTestSweepers("buddy_project", &resource.Sweeper{
+		Name:         "buddy_project",
+		F:            sweepProjects,
+		Dependencies: []string{"buddy_sandbox", "buddy_target"},
+	})
+	resource.AddTestSweepers("buddy_integration", &resource.Sweeper{
+		Name: "buddy_integration",
+		F:    sweepIntegrations,
+	})
+	resource.AddTestSweepers("buddy_target", &resource.Sweeper{
+		Name: "buddy_target",
+		F:    sweepTargets,
+	})
+	resource.AddTestSweepers("buddy_sandbox", &resource.Sweeper{
+		Name: "buddy_sandbox",
+		F:    sweepSandboxes,
+	})
+}
+
+// isSweepable returns true if the object was created by acceptance tests or lives in a test workspace
+func isSweepable(domain string, name string) bool {
+	return strings.HasPrefix(strings.ToLower(domain), acc.TestPrefix) || strings.HasPrefix(strings.ToLower(name), acc.TestPrefix)
+}
+
+// sweepWorkspaces removes test workspaces (prefixed with acc.TestPrefix) with all of their objects
+func sweepWorkspaces(_ string) error {
+	workspaces, _, err := acc.ApiClient.WorkspaceService.GetList()
+	if err != nil {
+		return fmt.Errorf("get workspaces: %w", err)
+	}
+	var errs []error
+	for _, w := range workspaces.Workspaces {
+		if !strings.HasPrefix(strings.ToLower(w.Domain), acc.TestPrefix) {
+			continue
+		}
+		log.Printf("[INFO] Deleting workspace %s", w.Domain)
+		resp, err := acc.ApiClient.WorkspaceService.Delete(w.Domain)
+		if err != nil && !util.IsResourceNotFound(resp, err) {
+			errs = append(errs, fmt.Errorf("delete workspace %s: %w", w.Domain, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func sweepProjects(domain string) error {
+	projects, _, err := acc.ApiClient.ProjectService.GetListAll(domain, &buddy.ProjectListQuery{})
+	if err != nil {
+		return fmt.Errorf("get projects: %w", err)
+	}
+	var errs []error
+	for _, p := range projects.Projects {
+		if !isSweepable(domain, p.Name) {
+			continue
+		}
+		log.Printf("[INFO] Deleting project %s:%s", domain, p.Name)
+		_, err = acc.ApiClient.ProjectService.Delete(domain, p.Name)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("delete project %s: %w", p.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func sweepIntegrations(domain string) error {
+	integrations, _, err := acc.ApiClient.IntegrationService.GetList(domain)
+	if err != nil {
+		return fmt.Errorf("get integrations: %w", err)
+	}
+	var errs []error
+	for _, i := range integrations.Integrations {
+		if !isSweepable(domain, i.Name) {
+			continue
+		}
+		log.Printf("[INFO] Deleting integration %s:%s", domain, i.Name)
+		_, err = acc.ApiClient.IntegrationService.Delete(domain, i.HashId)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("delete integration %s: %w", i.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func sweepTargets(domain string) error {
+	targets, _, err := acc.ApiClient.TargetService.GetList(domain, &buddy.TargetGetListQuery{})
+	if err != nil {
+		return fmt.Errorf("get targets: %w", err)
+	}
+	var errs []error
+	for _, t := range targets.Targets {
+		if !isSweepable(domain, t.Name) {
+			continue
+		}
+		log.Printf("[INFO] Deleting target %s:%s", domain, t.Name)
+		_, err = acc.ApiClient.TargetService.Delete(domain, t.Id)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("delete target %s: %w", t.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func sweepSandboxes(domain string) error {
+	projects, _, err := acc.ApiClient.ProjectService.GetListAll(domain, &buddy.ProjectListQuery{})
+	if err != nil {
+		return fmt.Errorf("get projects: %w", err)
+	}
+	var errs []error
+	for _, p := range projects.Projects {
+		projectName := p.Name
+		sandboxes, _, err := acc.ApiClient.SandboxService.GetList(domain, buddy.Query{
+			ProjectName: &projectName,
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("get sandboxes of project %s: %w", projectName, err))
+			continue
+		}
+		for _, s := range sandboxes.Sandboxes {
+			// sandboxes of leaked projects are removed before the projects
+			if !isSweepable(domain, s.Name) && !isSweepable(domain, projectName) {
+				continue
+			}
+			log.Printf("[INFO] Deleting sandbox %s:%s", domain, s.Name)
+			_, err = acc.ApiClient.SandboxService.Delete(domain, s.Id)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("delete sandbox %s: %w", s.Name, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -4,7 +4,10 @@ import (
 	"fmt"
 	"github.com/buddy/api-go-sdk/buddy"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"regexp"
 	"strconv"
 	"terraform-provider-buddy/buddy/acc"
 	"terraform-provider-buddy/buddy/util"
@@ -23,9 +26,9 @@ var targetIgnoreImportVerify = []string{
 
 func TestAccTarget_ftp(t *testing.T) {
 	var target buddy.Target
-	domain := util.UniqueString()
-	name := util.RandString(10)
-	identifier := util.UniqueString()
+	domain := acc.UniqueString()
+	name := acc.UniqueString()
+	identifier := acc.UniqueString()
 	host := "1.1.1.1"
 	port := "33"
 	username := util.RandString(10)
@@ -34,8 +37,8 @@ func TestAccTarget_ftp(t *testing.T) {
 	disabled := true
 	pipelineAccessLevel := buddy.TargetPipelineAccessLevelUseOnly
 
-	newName := util.RandString(10)
-	newIdentifier := util.UniqueString()
+	newName := acc.UniqueString()
+	newIdentifier := acc.UniqueString()
 	newHost := "2.2.2.2"
 	newPort := "44"
 	newUsername := util.RandString(10)
@@ -99,15 +102,23 @@ func TestAccTarget_ftp(t *testing.T) {
 				ImportStateVerify:       true,
 				ImportStateVerifyIgnore: targetIgnoreImportVerify,
 			},
+			// import by identifier
+			{
+				ResourceName:            "buddy_target.test",
+				ImportState:             true,
+				ImportStateIdFunc:       util.TestImportStateId("buddy_target.test", "domain", "identifier"),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: targetIgnoreImportVerify,
+			},
 		},
 	})
 }
 
 func TestAccTarget_sshPassword(t *testing.T) {
 	var target buddy.Target
-	domain := util.UniqueString()
-	name := util.RandString(10)
-	identifier := util.UniqueString()
+	domain := acc.UniqueString()
+	name := acc.UniqueString()
+	identifier := acc.UniqueString()
 	host := "1.1.1.1"
 	port := "44"
 	path := util.RandString(10)
@@ -116,7 +127,7 @@ func TestAccTarget_sshPassword(t *testing.T) {
 	tag := util.RandString(3)
 	typ := buddy.TargetTypeSsh
 
-	newName := util.RandString(10)
+	newName := acc.UniqueString()
 	newHost := "2.2.2.2"
 
 	resource.Test(t, resource.TestCase{
@@ -176,11 +187,72 @@ func TestAccTarget_sshPassword(t *testing.T) {
 	})
 }
 
+func TestAccTarget_sshPasswordWriteOnly(t *testing.T) {
+	var target buddy.Target
+	domain := acc.UniqueString()
+	name := acc.UniqueString()
+	identifier := acc.UniqueString()
+	host := "1.1.1.1"
+	port := "44"
+	path := util.RandString(10)
+	username := util.RandString(10)
+	password := util.RandString(10)
+	tag := util.RandString(3)
+	typ := buddy.TargetTypeSsh
+	ops := &buddy.TargetOps{
+		Name:       &name,
+		Identifier: &identifier,
+		Tags:       &[]string{tag},
+		Host:       &host,
+		Port:       &port,
+		Path:       &path,
+		Type:       &typ,
+		Auth: &buddy.TargetAuth{
+			Method:   buddy.TargetAuthMethodPassword,
+			Username: username,
+			Password: password,
+		},
+	}
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acc.PreCheck(t)
+		},
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		ProtoV6ProviderFactories: acc.ProviderFactories,
+		CheckDestroy:             testAccTargetCheckDestroy,
+		Steps: []resource.TestStep{
+			// create with write-only password
+			{
+				Config: testAccTargetSshPasswordWriteOnlyConfig(domain, name, identifier, tag, host, port, path, username, password, 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccTargetGet("buddy_target.test", &target),
+					testAccTargetAttributes("buddy_target.test", &target, ops),
+					resource.TestCheckNoResourceAttr("buddy_target.test", "auth.0.password"),
+					resource.TestCheckNoResourceAttr("buddy_target.test", "auth_password_wo"),
+					resource.TestCheckResourceAttr("buddy_target.test", "auth_wo_version", "1"),
+				),
+			},
+			// switch to password
+			{
+				Config: testAccTargetSshPasswordConfig(domain, name, identifier, tag, host, port, path, username, password),
+				Check: resource.ComposeTestCheckFunc(
+					testAccTargetGet("buddy_target.test", &target),
+					testAccTargetAttributes("buddy_target.test", &target, ops),
+					resource.TestCheckNoResourceAttr("buddy_target.test", "auth_password_wo"),
+					resource.TestCheckNoResourceAttr("buddy_target.test", "auth_wo_version"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccTarget_sshProxyCredentials(t *testing.T) {
 	var target buddy.Target
-	domain := util.UniqueString()
-	name := util.RandString(10)
-	identifier := util.UniqueString()
+	domain := acc.UniqueString()
+	name := acc.UniqueString()
+	identifier := acc.UniqueString()
 	host := "1.1.1.1"
 	port := "44"
 	path := util.RandString(10)
@@ -267,11 +339,11 @@ func TestAccTarget_sshProxyCredentials(t *testing.T) {
 
 func TestAccTarget_sshKey(t *testing.T) {
 	var target buddy.Target
-	domain := util.UniqueString()
+	domain := acc.UniqueString()
 	email := util.RandEmail()
 	groupName := util.RandString(10)
-	name := util.RandString(10)
-	identifier := util.UniqueString()
+	name := acc.UniqueString()
+	identifier := acc.UniqueString()
 	host := "1.1.1.1"
 	port := "44"
 	username := util.RandString(10)
@@ -281,17 +353,17 @@ func TestAccTarget_sshKey(t *testing.T) {
 	otherLevel := buddy.TargetPermissionManage
 	userLevel := buddy.TargetPermissionUseOnly
 	groupLevel := buddy.TargetPermissionManage
-	pipelineIdentifier := util.UniqueString()
+	pipelineIdentifier := acc.UniqueString()
 	pipelineAccessLevel := buddy.TargetPipelineAccessLevelDenied
-	projectName := util.UniqueString()
+	projectName := acc.UniqueString()
 
-	newName := util.RandString(10)
+	newName := acc.UniqueString()
 	newHost := "2.2.2.2"
 	newKey := util.RandString(10)
 	newOtherLevel := buddy.TargetPermissionUseOnly
 	newUserLevel := buddy.TargetPermissionManage
 	newGroupLevel := buddy.TargetPermissionUseOnly
-	newPipelineIdentifier := util.UniqueString()
+	newPipelineIdentifier := acc.UniqueString()
 	newPipelineAccessLevel := buddy.TargetPipelineAccessLevelUseOnly
 
 	resource.Test(t, resource.TestCase{
@@ -371,6 +443,7 @@ func TestAccTarget_sshKey(t *testing.T) {
 					}),
 				),
 			},
+			// import project target by ID
 			{
 				ResourceName:            "buddy_target.test",
 				ImportState:             true,
@@ -383,9 +456,9 @@ func TestAccTarget_sshKey(t *testing.T) {
 
 func TestAccTarget_sshAsset(t *testing.T) {
 	var target buddy.Target
-	domain := util.UniqueString()
-	name := util.RandString(10)
-	identifier := util.UniqueString()
+	domain := acc.UniqueString()
+	name := acc.UniqueString()
+	identifier := acc.UniqueString()
 	host := "1.1.1.1"
 	port := "44"
 	path := "/a/b/c"
@@ -453,9 +526,9 @@ func TestAccTarget_sshAsset(t *testing.T) {
 
 func TestAccTarget_gitHttp(t *testing.T) {
 	var target buddy.Target
-	domain := util.UniqueString()
-	name := util.RandString(10)
-	identifier := util.UniqueString()
+	domain := acc.UniqueString()
+	name := acc.UniqueString()
+	identifier := acc.UniqueString()
 	repository := "https://aa.com"
 	username := util.RandString(10)
 	password := util.RandString(10)
@@ -516,10 +589,10 @@ func TestAccTarget_gitHttp(t *testing.T) {
 
 func TestAccTarget_vultr(t *testing.T) {
 	var target buddy.Target
-	domain := util.UniqueString()
-	name := util.RandString(10)
-	identifier := util.UniqueString()
-	newName := util.RandString(10)
+	domain := acc.UniqueString()
+	name := acc.UniqueString()
+	identifier := acc.UniqueString()
+	newName := acc.UniqueString()
 	typ := buddy.TargetTypeVultr
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
@@ -560,12 +633,76 @@ func TestAccTarget_vultr(t *testing.T) {
 	})
 }
 
+func TestAccTarget_defaultTags(t *testing.T) {
+	var target buddy.Target
+	domain := acc.UniqueString()
+	name := acc.UniqueString()
+	identifier := acc.UniqueString()
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acc.PreCheck(t)
+		},
+		ProtoV6ProviderFactories: acc.ProviderFactories,
+		CheckDestroy:             testAccTargetCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTargetDefaultTagsConfig(domain, name, identifier, `["managed-by=terraform"]`, "team-a"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccTargetGet("buddy_target.test", &target),
+					resource.TestCheckResourceAttr("buddy_target.test", "tags.#", "1"),
+					resource.TestCheckTypeSetElemAttr("buddy_target.test", "tags.*", "team-a"),
+					resource.TestCheckResourceAttr("buddy_target.test", "tags_all.#", "2"),
+					resource.TestCheckTypeSetElemAttr("buddy_target.test", "tags_all.*", "team-a"),
+					resource.TestCheckTypeSetElemAttr("buddy_target.test", "tags_all.*", "managed-by=terraform"),
+				),
+			},
+			{
+				Config: testAccTargetDefaultTagsConfig(domain, name, identifier, `["managed-by=terraform"]`, "team-b"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccTargetGet("buddy_target.test", &target),
+					resource.TestCheckResourceAttr("buddy_target.test", "tags.#", "1"),
+					resource.TestCheckTypeSetElemAttr("buddy_target.test", "tags.*", "team-b"),
+					resource.TestCheckResourceAttr("buddy_target.test", "tags_all.#", "2"),
+					resource.TestCheckTypeSetElemAttr("buddy_target.test", "tags_all.*", "team-b"),
+					resource.TestCheckTypeSetElemAttr("buddy_target.test", "tags_all.*", "managed-by=terraform"),
+				),
+			},
+			// remove default tags
+			{
+				Config: testAccTargetDefaultTagsConfig(domain, name, identifier, "[]", "team-b"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccTargetGet("buddy_target.test", &target),
+					resource.TestCheckResourceAttr("buddy_target.test", "tags.#", "1"),
+					resource.TestCheckTypeSetElemAttr("buddy_target.test", "tags.*", "team-b"),
+					resource.TestCheckResourceAttr("buddy_target.test", "tags_all.#", "1"),
+					resource.TestCheckTypeSetElemAttr("buddy_target.test", "tags_all.*", "team-b"),
+				),
+			},
+			// remove all tags
+			{
+				Config: testAccTargetDefaultTagsConfig(domain, name, identifier, "[]", ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccTargetGet("buddy_target.test", &target),
+					resource.TestCheckResourceAttr("buddy_target.test", "tags.#", "0"),
+					resource.TestCheckResourceAttr("buddy_target.test", "tags_all.#", "0"),
+				),
+			},
+			{
+				ResourceName:            "buddy_target.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: targetIgnoreImportVerify,
+			},
+		},
+	})
+}
+
 func TestAccTarget_upcloud(t *testing.T) {
 	var target buddy.Target
-	domain := util.UniqueString()
-	name := util.RandString(10)
-	identifier := util.UniqueString()
-	newName := util.RandString(10)
+	domain := acc.UniqueString()
+	name := acc.UniqueString()
+	identifier := acc.UniqueString()
+	newName := acc.UniqueString()
 	typ := buddy.TargetTypeUpcloud
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
@@ -608,10 +745,10 @@ func TestAccTarget_upcloud(t *testing.T) {
 
 func TestAccTarget_digitalOcean(t *testing.T) {
 	var target buddy.Target
-	domain := util.UniqueString()
-	name := util.RandString(10)
-	identifier := util.UniqueString()
-	newName := util.RandString(10)
+	domain := acc.UniqueString()
+	name := acc.UniqueString()
+	identifier := acc.UniqueString()
+	newName := acc.UniqueString()
 	typ := buddy.TargetTypeDigitalOcean
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
@@ -654,9 +791,9 @@ func TestAccTarget_digitalOcean(t *testing.T) {
 
 func TestAccTarget_gitSsh(t *testing.T) {
 	var target buddy.Target
-	domain := util.UniqueString()
-	name := util.RandString(10)
-	identifier := util.UniqueString()
+	domain := acc.UniqueString()
+	name := acc.UniqueString()
+	identifier := acc.UniqueString()
 	repository := "https://aa.com"
 	key := util.RandString(10)
 	typ := buddy.TargetTypeGit
@@ -732,6 +869,170 @@ func testAccTargetCheckDestroy(s *terraform.State) error {
 	return nil
 }
 
+func TestAccTargetUpgradeFromV1_42(t *testing.T) {
+	var target buddy.Target
+	domain := acc.UniqueString()
+	projectName := acc.UniqueString()
+	pipelineIdentifier := acc.UniqueString()
+	name := acc.UniqueString()
+	identifier := acc.UniqueString()
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acc.PreCheck(t)
+		},
+		CheckDestroy: testAccTargetCheckDestroy,
+		Steps: []resource.TestStep{
+			// create target with the boolean access flag
+			{
+				ExternalProviders: map[string]resource.ExternalProvider{
+					"buddy": {
+						Source:            "buddy/buddy",
+						VersionConstraint: "1.42.0",
+					},
+				},
+				Config: testAccTargetV1_42Config(domain, projectName, pipelineIdentifier, name, identifier),
+			},
+			// upgrade state
+			{
+				ProtoV6ProviderFactories: acc.ProviderFactories,
+				Config:                   testAccTargetV1_43Config(domain, projectName, pipelineIdentifier, name, identifier),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("buddy_target.test", plancheck.ResourceActionNoop),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					testAccTargetGet("buddy_target.test", &target),
+					resource.TestCheckResourceAttr("buddy_target.test", "pipelines_access_level", buddy.TargetPipelineAccessLevelDenied),
+					resource.TestCheckResourceAttr("buddy_target.test", "allowed_pipeline.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs("buddy_target.test", "allowed_pipeline.*", map[string]string{
+						"pipeline":     pipelineIdentifier,
+						"access_level": buddy.TargetPipelineAccessLevelUseOnly,
+					}),
+					resource.TestCheckResourceAttr("buddy_target.test", "identifier", identifier),
+				),
+			},
+		},
+	})
+}
+
+func TestTargetValidateConfig(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acc.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccTargetValidateConfig(buddy.TargetTypeGit, `host = "1.1.1.1"`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("`repository` is required by `GIT` target"),
+			},
+			{
+				Config:      testAccTargetValidateConfig(buddy.TargetTypeFtp, `host = "1.1.1.1"`+"\n"+`repository = "abc"`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("`repository` is not supported by `FTP` target"),
+			},
+			{
+				Config: testAccTargetValidateConfig(buddy.TargetTypeSsh, `host = "1.1.1.1"
+auth {
+  method = "SSH_KEY"
+  username = "user"
+}`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("`auth.key` or `auth.key_path` is required by the `SSH_KEY` auth method"),
+			},
+			{
+				Config: testAccTargetValidateConfig(buddy.TargetTypeGit, `repository = "https://github.com/buddy/test"
+auth {
+  method = "PASSWORD"
+  username = "user"
+  password = "pass"
+}`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("`GIT` target supports auth methods"),
+			},
+			{
+				Config: testAccTargetValidateConfig(buddy.TargetTypeSsh, `host = "1.1.1.1"
+auth {
+  method = "PROXY_CREDENTIALS"
+}`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("`proxy` is required by the `PROXY_CREDENTIALS` auth method"),
+			},
+			{
+				Config: testAccTargetValidateConfig(buddy.TargetTypeSsh, `host = "1.1.1.1"
+auth {
+  method = "PROXY_CREDENTIALS"
+}
+proxy {
+  name = "proxy"
+  host = "2.2.2.2"
+  auth {
+    method = "PASSWORD"
+    username = "user"
+    key = "key"
+  }
+}`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("`proxy.auth.password` is required by the `PASSWORD` auth method"),
+			},
+		},
+	})
+}
+
+func TestTargetValidateConfigWriteOnly(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		ProtoV6ProviderFactories: acc.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTargetValidateConfig(buddy.TargetTypeSsh, `host = "1.1.1.1"
+auth_key_wo = "key"
+auth_wo_version = 1
+auth {
+  method = "SSH_KEY"
+  username = "user"
+  key = "key"
+}`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Invalid Attribute Combination"),
+			},
+			{
+				Config: testAccTargetValidateConfig(buddy.TargetTypeSsh, `host = "1.1.1.1"
+auth_key_wo = "key"
+auth {
+  method = "SSH_KEY"
+  username = "user"
+}`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Invalid Attribute Combination"),
+			},
+			{
+				Config: testAccTargetValidateConfig(buddy.TargetTypeSsh, `host = "1.1.1.1"
+auth_wo_version = 1
+auth {
+  method = "SSH_KEY"
+  username = "user"
+  key = "key"
+}`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Missing required attribute"),
+			},
+		},
+	})
+}
+
+func testAccTargetValidateConfig(typ string, attrs string) string {
+	return fmt.Sprintf(`
+resource "buddy_target" "test" {
+    domain     = "test"
+    name       = "test"
+    identifier = "test"
+    type       = "%s"
+    %s
+}`, typ, attrs)
+}
+
 func testAccTargetGet(n string, target *buddy.Target) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -1021,6 +1322,36 @@ resource "buddy_target" "test" {
 }`, domain, name, identifier, tag, host, port, path, username, password)
 }
 
+func testAccTargetSshPasswordWriteOnlyConfig(domain string, name string, identifier string, tag string, host string, port string, path string, username string, password string, version int) string {
+	return fmt.Sprintf(`
+resource "buddy_workspace" "test" {
+    domain = "%s"
+}
+
+resource "buddy_project" "test" {
+    domain       = buddy_workspace.test.domain
+    display_name = "abcdef"
+}
+
+resource "buddy_target" "test" {
+    domain           = buddy_workspace.test.domain
+    project_name     = buddy_project.test.name
+    name             = "%s"
+    identifier       = "%s"
+    type             = "SSH"
+    tags             = ["%s"]
+    host             = "%s"
+    port             = "%s"
+    path             = "%s"
+    auth {
+        method   = "PASSWORD"
+        username = "%s"
+    }
+    auth_password_wo = "%s"
+    auth_wo_version  = %d
+}`, domain, name, identifier, tag, host, port, path, username, password, version)
+}
+
 func testAccTargetGitHttpConfig(domain string, name string, identifier string, repository string, username string, password string) string {
 	return fmt.Sprintf(`
 resource "buddy_workspace" "test" {
@@ -1102,6 +1433,35 @@ resource "buddy_target" "test" {
 }`, domain, name, identifier)
 }
 
+func testAccTargetDefaultTagsConfig(domain string, name string, identifier string, defaultTags string, tag string) string {
+	tags := ""
+	if tag != "" {
+		tags = fmt.Sprintf(`tags         = ["%s"]`, tag)
+	}
+	return fmt.Sprintf(`
+provider "buddy" {
+    default_tags = %s
+}
+
+resource "buddy_workspace" "test" {
+    domain = "%s"
+}
+
+resource "buddy_target" "test" {
+    domain       = buddy_workspace.test.domain
+    name         = "%s"
+    identifier   = "%s"
+    type         = "FTP"
+    host         = "1.1.1.1"
+    port         = "21"
+    %s
+    auth {
+        username = "user"
+        password = "pass"
+    }
+}`, defaultTags, domain, name, identifier, tags)
+}
+
 func testAccTargetUpcloudConfig(domain string, name string, identifier string) string {
 	return fmt.Sprintf(`
 resource "buddy_workspace" "test" {
@@ -1312,3 +1672,80 @@ resource "buddy_target" "test" {
 		}
 }`, domain, projectName, pipelineIdentifier, pipelineIdentifier, email, groupName, name, identifier, host, port, username, key, passphrase, othersLevel, userLevel, groupLevel, pipelineIdentifier, pipelineAccessLevel)
 }
+
+func testAccTargetV1_42Config(domain string, projectName string, pipelineIdentifier string, name string, identifier string) string {
+	return fmt.Sprintf(`
+resource "buddy_workspace" "test" {
+    domain = "%s"
+}
+
+resource "buddy_project" "test" {
+    domain       = buddy_workspace.test.domain
+    display_name = "%s"
+}
+
+resource "buddy_pipeline" "test" {
+    domain       = buddy_workspace.test.domain
+    project_name = buddy_project.test.name
+    name         = "%s"
+    identifier   = "%s"
+}
+
+resource "buddy_target" "test" {
+    domain     = buddy_workspace.test.domain
+    name       = "%s"
+    identifier = "%s"
+    type       = "SSH"
+    host       = "1.1.1.1"
+    port       = "22"
+    auth {
+        method   = "PASSWORD"
+        username = "user"
+        password = "pass"
+    }
+    all_pipelines_allowed = false
+    allowed_pipeline {
+        project  = buddy_project.test.name
+        pipeline = buddy_pipeline.test.identifier
+    }
+}`, domain, projectName, pipelineIdentifier, pipelineIdentifier, name, identifier)
+}
+
+func testAccTargetV1_43Config(domain string, projectName string, pipelineIdentifier string, name string, identifier string) string {
+	return fmt.Sprintf(`
+resource "buddy_workspace" "test" {
+    domain = "%s"
+}
+
+resource "buddy_project" "test" {
+    domain       = buddy_workspace.test.domain
+    display_name = "%s"
+}
+
+resource "buddy_pipeline" "test" {
+    domain       = buddy_workspace.test.domain
+    project_name = buddy_project.test.name
+    name         = "%s"
+    identifier   = "%s"
+}
+
+resource "buddy_target" "test" {
+    domain     = buddy_workspace.test.domain
+    name       = "%s"
+    identifier = "%s"
+    type       = "SSH"
+    host       = "1.1.1.1"
+    port       = "22"
+    auth {
+        method   = "PASSWORD"
+        username = "user"
+        password = "pass"
+    }
+    pipelines_access_level = "DENIED"
+    allowed_pipeline {
+        project      = buddy_project.test.name
+        pipeline     = buddy_pipeline.test.identifier
+        access_level = "USE_ONLY"
+    }
+}`, domain, projectName, pipelineIdentifier, pipelineIdentifier, name, identifier)
+}
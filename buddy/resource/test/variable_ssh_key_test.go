@@ -14,9 +14,9 @@ import (
 
 func TestAccVariableSshKey_workspace(t *testing.T) {
 	var variable buddy.Variable
-	domain := util.UniqueString()
-	key := util.UniqueString()
-	newKey := util.UniqueString()
+	domain := acc.UniqueString()
+	key := acc.UniqueString()
+	newKey := acc.UniqueString()
 	desc := util.RandString(10)
 	filePlace := buddy.VariableSshKeyFilePlaceContainer
 	filePath := "~/.ssh/test"
@@ -81,9 +81,9 @@ func TestAccVariableSshKey_workspace(t *testing.T) {
 
 func TestAccVariableSshKey_project(t *testing.T) {
 	var variable buddy.Variable
-	domain := util.UniqueString()
-	projectName := util.UniqueString()
-	key := util.UniqueString()
+	domain := acc.UniqueString()
+	projectName := acc.UniqueString()
+	key := acc.UniqueString()
 	desc := util.RandString(10)
 	filePlace := buddy.VariableSshKeyFilePlaceContainer
 	filePath := "~/.ssh/test2"
@@ -132,8 +132,8 @@ func TestAccVariableSshKey_project(t *testing.T) {
 
 func TestAccVariableSshKey_environment(t *testing.T) {
 	var variable buddy.Variable
-	domain := util.UniqueString()
-	key := util.UniqueString()
+	domain := acc.UniqueString()
+	key := acc.UniqueString()
 	desc := util.RandString(10)
 	filePlace := buddy.VariableSshKeyFilePlaceContainer
 	filePath := "~/.ssh/test3"
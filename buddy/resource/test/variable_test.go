@@ -5,6 +5,7 @@ import (
 	"github.com/buddy/api-go-sdk/buddy"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 	"strconv"
 	"strings"
 	"terraform-provider-buddy/buddy/acc"
@@ -14,8 +15,8 @@ import (
 
 func TestAccVariable_workspace(t *testing.T) {
 	var variable buddy.Variable
-	domain := util.UniqueString()
-	key := util.UniqueString()
+	domain := acc.UniqueString()
+	key := acc.UniqueString()
 	val := util.RandString(10)
 	newValue := util.RandString(10)
 	newKey := util.RandString(10)
@@ -67,15 +68,23 @@ func TestAccVariable_workspace(t *testing.T) {
 				ImportStateVerify:       true,
 				ImportStateVerifyIgnore: []string{"value"},
 			},
+			// import by key
+			{
+				ResourceName:            "buddy_variable.bar",
+				ImportState:             true,
+				ImportStateIdFunc:       util.TestImportStateId("buddy_variable.bar", "domain", "key"),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"value"},
+			},
 		},
 	})
 }
 
 func TestAccVariable_project(t *testing.T) {
 	var variable buddy.Variable
-	domain := util.UniqueString()
-	projectName := util.UniqueString()
-	key := util.UniqueString()
+	domain := acc.UniqueString()
+	projectName := acc.UniqueString()
+	key := acc.UniqueString()
 	val := util.RandString(10)
 	newValue := util.RandString(10)
 	desc := util.RandString(10)
@@ -114,10 +123,46 @@ func TestAccVariable_project(t *testing.T) {
 	})
 }
 
+func TestAccVariable_importByKey(t *testing.T) {
+	domain := acc.UniqueString()
+	projectName := acc.UniqueString()
+	key := acc.UniqueString()
+	val := util.RandString(10)
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acc.PreCheck(t)
+		},
+		ProtoV6ProviderFactories: acc.ProviderFactories,
+		CheckDestroy:             testAccVariableCheckDestroy,
+		Steps: []resource.TestStep{
+			// create workspace and project variables with the same key
+			{
+				Config: testAccVariableSameKeyConfig(domain, projectName, key, val),
+			},
+			// import workspace variable by key
+			{
+				ResourceName:            "buddy_variable.ws",
+				ImportState:             true,
+				ImportStateIdFunc:       util.TestImportStateId("buddy_variable.ws", "domain", "key"),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"value"},
+			},
+			// import project variable by key
+			{
+				ResourceName:            "buddy_variable.proj",
+				ImportState:             true,
+				ImportStateIdFunc:       util.TestImportStateId("buddy_variable.proj", "domain", "project_name", "key"),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"value"},
+			},
+		},
+	})
+}
+
 func TestAccVariable_environment(t *testing.T) {
 	var variable buddy.Variable
-	domain := util.UniqueString()
-	key := util.UniqueString()
+	domain := acc.UniqueString()
+	key := acc.UniqueString()
 	val := util.RandString(10)
 	newValue := util.RandString(10)
 	desc := util.RandString(10)
@@ -156,6 +201,81 @@ func TestAccVariable_environment(t *testing.T) {
 	})
 }
 
+func TestAccVariable_writeOnly(t *testing.T) {
+	var variable buddy.Variable
+	domain := acc.UniqueString()
+	key := acc.UniqueString()
+	val := util.RandString(10)
+	newValue := util.RandString(10)
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acc.PreCheck(t)
+		},
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		ProtoV6ProviderFactories: acc.ProviderFactories,
+		CheckDestroy:             testAccVariableCheckDestroy,
+		Steps: []resource.TestStep{
+			// create variable
+			{
+				Config: testAccVariableWorkspaceWriteOnlyConfig(domain, key, val, 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccVariableGet("buddy_variable.bar", &variable),
+					testAccVariableWriteOnlyAttributes("buddy_variable.bar", &variable, key, val, "1"),
+				),
+			},
+			// update variable value
+			{
+				Config: testAccVariableWorkspaceWriteOnlyConfig(domain, key, newValue, 2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccVariableGet("buddy_variable.bar", &variable),
+					testAccVariableWriteOnlyAttributes("buddy_variable.bar", &variable, key, newValue, "2"),
+				),
+			},
+			// switch to value
+			{
+				Config: testAccVariableWorkspaceSimpleConfig(domain, key, val),
+				Check: resource.ComposeTestCheckFunc(
+					testAccVariableGet("buddy_variable.bar", &variable),
+					testAccVariableAttributes("buddy_variable.bar", &variable, domain, "", key, val, "", false, false),
+					resource.TestCheckNoResourceAttr("buddy_variable.bar", "value_wo"),
+					resource.TestCheckNoResourceAttr("buddy_variable.bar", "value_wo_version"),
+				),
+			},
+		},
+	})
+}
+
+func testAccVariableWriteOnlyAttributes(n string, variable *buddy.Variable, key string, val string, version string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+		attrs := rs.Primary.Attributes
+		if err := util.CheckFieldEqualAndSet("Key", variable.Key, key); err != nil {
+			return err
+		}
+		if err := util.CheckFieldEqualAndSet("Value", variable.Value, val); err != nil {
+			return err
+		}
+		if err := util.CheckFieldEqual("value", attrs["value"], ""); err != nil {
+			return err
+		}
+		if err := util.CheckFieldEqual("value_wo", attrs["value_wo"], ""); err != nil {
+			return err
+		}
+		if err := util.CheckFieldEqual("value_processed", attrs["value_processed"], ""); err != nil {
+			return err
+		}
+		if err := util.CheckFieldEqualAndSet("value_wo_version", attrs["value_wo_version"], version); err != nil {
+			return err
+		}
+		return nil
+	}
+}
+
 func testAccVariableAttributes(n string, variable *buddy.Variable, domain string, projectName string, key string, val string, description string, encrypted bool, settable bool) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -271,6 +391,21 @@ resource "buddy_variable" "bar" {
 `, domain, key, val)
 }
 
+func testAccVariableWorkspaceWriteOnlyConfig(domain string, key string, val string, version int) string {
+	return fmt.Sprintf(`
+resource "buddy_workspace" "foo" {
+   domain = "%s"
+}
+
+resource "buddy_variable" "bar" {
+   domain = "${buddy_workspace.foo.domain}"
+   key = "%s"
+   value_wo = "%s"
+   value_wo_version = %d
+}
+`, domain, key, val, version)
+}
+
 func testAccVariableProjectComplexConfig(domain string, projectName string, key string, val string, encrypted bool, settable bool, description string) string {
 	return fmt.Sprintf(`
 resource "buddy_workspace" "foo" {
@@ -294,6 +429,32 @@ resource "buddy_variable" "bar" {
 `, domain, projectName, key, val, encrypted, settable, description)
 }
 
+func testAccVariableSameKeyConfig(domain string, projectName string, key string, val string) string {
+	return fmt.Sprintf(`
+resource "buddy_workspace" "foo" {
+   domain = "%s"
+}
+
+resource "buddy_project" "aha" {
+   domain = "${buddy_workspace.foo.domain}"
+   display_name = "%s"
+}
+
+resource "buddy_variable" "ws" {
+   domain = "${buddy_workspace.foo.domain}"
+   key = "%s"
+   value = "%s"
+}
+
+resource "buddy_variable" "proj" {
+   domain = "${buddy_workspace.foo.domain}"
+   project_name = "${buddy_project.aha.name}"
+   key = "%s"
+   value = "%s"
+}
+`, domain, projectName, key, val, key, val)
+}
+
 func testAccVariableEnvironmentComplexConfig(domain string, key string, val string, encrypted bool, settable bool, description string) string {
 	return fmt.Sprintf(`
 resource "buddy_workspace" "foo" {
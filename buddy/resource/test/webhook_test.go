@@ -13,10 +13,10 @@ import (
 
 func TestAccWebhook(t *testing.T) {
 	var webhook buddy.Webhook
-	domain := util.UniqueString()
+	domain := acc.UniqueString()
 	event := buddy.WebhookEventPush
 	newEvent := buddy.WebhookEventExecutionSuccessful
-	projectName := util.UniqueString()
+	projectName := acc.UniqueString()
 	targetUrl := "https://127.0.0.1"
 	newTargetUrl := "https://aaaa.com"
 	secretKey := ""
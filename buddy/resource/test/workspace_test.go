@@ -29,7 +29,7 @@ func testAccWorkspaceCheckDestroy(s *terraform.State) error {
 
 func TestAcc_Workspace(t *testing.T) {
 	var workspace buddy.Workspace
-	domain := util.UniqueString()
+	domain := acc.UniqueString()
 	salt := util.RandString(10)
 	name := "A" + util.RandString(10)
 	resource.Test(t, resource.TestCase{
@@ -22,6 +22,7 @@ var (
 	_ resource.Resource                = &variableResource{}
 	_ resource.ResourceWithConfigure   = &variableResource{}
 	_ resource.ResourceWithImportState = &variableResource{}
+	_ resource.ResourceWithModifyPlan  = &variableResource{}
 )
 
 func NewVariableResource() resource.Resource {
@@ -29,7 +30,8 @@ func NewVariableResource() resource.Resource {
 }
 
 type variableResource struct {
-	client *buddy.Client
+	client        *buddy.Client
+	defaultDomain string
 }
 
 type variableResourceModel struct {
@@ -37,6 +39,8 @@ type variableResourceModel struct {
 	Domain         types.String `tfsdk:"domain"`
 	Key            types.String `tfsdk:"key"`
 	Value          types.String `tfsdk:"value"`
+	ValueWo        types.String `tfsdk:"value_wo"`
+	ValueWoVersion types.Int64  `tfsdk:"value_wo_version"`
 	Encrypted      types.Bool   `tfsdk:"encrypted"`
 	ProjectName    types.String `tfsdk:"project_name"`
 	PipelineId     types.Int64  `tfsdk:"pipeline_id"`
@@ -67,7 +71,14 @@ func (r *variableResourceModel) loadAPI(domain string, variable *buddy.Variable)
 	r.Encrypted = types.BoolValue(variable.Encrypted)
 	r.Settable = types.BoolValue(variable.Settable)
 	r.Description = types.StringValue(variable.Description)
-	r.ValueProcessed = types.StringValue(variable.Value)
+	// write-only values are never stored in the state
+	r.ValueWo = types.StringNull()
+	if !r.ValueWoVersion.IsNull() && !variable.Encrypted {
+		// value set with write-only attribute is not stored in the state
+		r.ValueProcessed = types.StringNull()
+	} else {
+		r.ValueProcessed = types.StringValue(variable.Value)
+	}
 	r.VariableId = types.Int64Value(int64(variable.Id))
 	if variable.Project != nil {
 		r.ProjectName = types.StringValue(variable.Project.Name)
@@ -109,10 +120,12 @@ func (r *variableResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 				},
 			},
 			"domain": schema.StringAttribute{
-				MarkdownDescription: "The workspace's URL handle",
-				Required:            true,
+				MarkdownDescription: "The workspace's URL handle. Defaults to the provider's `domain`",
+				Optional:            true,
+				Computed:            true,
 				Validators:          util.StringValidatorsDomain(),
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
@@ -124,9 +137,28 @@ func (r *variableResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 				},
 			},
 			"value": schema.StringAttribute{
-				MarkdownDescription: "The variable's value",
-				Required:            true,
+				MarkdownDescription: "The variable's value. Provide `value` or `value_wo`",
+				Optional:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("value_wo")),
+				},
+			},
+			"value_wo": schema.StringAttribute{
+				MarkdownDescription: "The variable's write-only value. It's never stored in the state. Requires Terraform 1.11 or later",
+				Optional:            true,
 				Sensitive:           true,
+				WriteOnly:           true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("value_wo_version")),
+				},
+			},
+			"value_wo_version": schema.Int64Attribute{
+				MarkdownDescription: "The `value_wo` version. Change it to update the variable's value",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("value_wo")),
+				},
 			},
 			"encrypted": schema.BoolAttribute{
 				MarkdownDescription: "Is the variable's value encrypted",
@@ -220,7 +252,13 @@ func (r *variableResource) Configure(_ context.Context, req resource.ConfigureRe
 	if req.ProviderData == nil {
 		return
 	}
-	r.client = req.ProviderData.(*buddy.Client)
+	p := req.ProviderData.(*util.ProviderData)
+	r.client = p.Client
+	r.defaultDomain = p.Domain
+}
+
+func (r *variableResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	util.ModifyPlanDomain(ctx, path.Root("domain"), r.defaultDomain, req, resp)
 }
 
 func (r *variableResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -236,6 +274,14 @@ func (r *variableResource) Create(ctx context.Context, req resource.CreateReques
 		Value: data.Value.ValueStringPointer(),
 		Type:  &typ,
 	}
+	valueWo, d := util.WriteOnlyStringToApi(ctx, &req.Config, path.Root("value_wo"))
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if valueWo != nil {
+		ops.Value = valueWo
+	}
 	if !data.Settable.IsNull() && !data.Settable.IsUnknown() {
 		ops.Settable = data.Settable.ValueBoolPointer()
 	}
@@ -316,6 +362,14 @@ func (r *variableResource) Update(ctx context.Context, req resource.UpdateReques
 	ops := buddy.VariableOps{
 		Value: data.Value.ValueStringPointer(),
 	}
+	valueWo, d := util.WriteOnlyStringToApi(ctx, &req.Config, path.Root("value_wo"))
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if valueWo != nil {
+		ops.Value = valueWo
+	}
 	if !data.Encrypted.IsNull() && !data.Encrypted.IsUnknown() {
 		ops.Encrypted = data.Encrypted.ValueBoolPointer()
 	}
@@ -351,6 +405,46 @@ func (r *variableResource) Delete(ctx context.Context, req resource.DeleteReques
 	}
 }
 
+// ImportState accepts `domain:variable_id`, `domain:variable_key` (workspace variable) or `domain:project_name:variable_key`
 func (r *variableResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	query := buddy.VariableGetListQuery{}
+	domain, projectName, key, err := util.DecomposeTripleId(req.ID)
+	if err == nil {
+		query.ProjectName = projectName
+	} else {
+		domain, key, err = util.DecomposeDoubleId(req.ID)
+		if err != nil || util.IsNumericId(key) {
+			resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+			return
+		}
+	}
+	variables, _, err := r.client.VariableService.GetList(domain, &query)
+	if err != nil {
+		resp.Diagnostics.Append(util.NewDiagnosticApiError("get variables", err))
+		return
+	}
+	var found []*buddy.Variable
+	for _, v := range variables.Variables {
+		if v.Type != buddy.VariableTypeVar || v.Key != key {
+			continue
+		}
+		// list of project variables includes the workspace ones
+		if projectName != "" && v.Project == nil {
+			continue
+		}
+		// list of workspace variables includes the project ones
+		if projectName == "" && v.Project != nil {
+			continue
+		}
+		found = append(found, v)
+	}
+	if len(found) == 0 {
+		resp.Diagnostics.Append(util.NewDiagnosticImportNotFound("variable", key))
+		return
+	}
+	if len(found) > 1 {
+		resp.Diagnostics.Append(util.NewDiagnosticImportAmbiguous("variable", key))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), util.ComposeDoubleId(domain, strconv.Itoa(found[0].Id)))...)
 }
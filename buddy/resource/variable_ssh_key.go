@@ -22,6 +22,7 @@ var (
 	_ resource.Resource                = &variableSshKeyResource{}
 	_ resource.ResourceWithConfigure   = &variableSshKeyResource{}
 	_ resource.ResourceWithImportState = &variableSshKeyResource{}
+	_ resource.ResourceWithModifyPlan  = &variableSshKeyResource{}
 )
 
 func NewVariableSshResource() resource.Resource {
@@ -29,7 +30,8 @@ func NewVariableSshResource() resource.Resource {
 }
 
 type variableSshKeyResource struct {
-	client *buddy.Client
+	client        *buddy.Client
+	defaultDomain string
 }
 
 type variableSshKeyResourceModel struct {
@@ -121,10 +123,12 @@ func (r *variableSshKeyResource) Schema(_ context.Context, _ resource.SchemaRequ
 				},
 			},
 			"domain": schema.StringAttribute{
-				MarkdownDescription: "The workspace's URL handle",
-				Required:            true,
+				MarkdownDescription: "The workspace's URL handle. Defaults to the provider's `domain`",
+				Optional:            true,
+				Computed:            true,
 				Validators:          util.StringValidatorsDomain(),
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
@@ -260,7 +264,13 @@ func (r *variableSshKeyResource) Configure(_ context.Context, req resource.Confi
 	if req.ProviderData == nil {
 		return
 	}
-	r.client = req.ProviderData.(*buddy.Client)
+	p := req.ProviderData.(*util.ProviderData)
+	r.client = p.Client
+	r.defaultDomain = p.Domain
+}
+
+func (r *variableSshKeyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	util.ModifyPlanDomain(ctx, path.Root("domain"), r.defaultDomain, req, resp)
 }
 
 func (r *variableSshKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -23,7 +23,8 @@ func NewEnvironmentSource() datasource.DataSource {
 }
 
 type environmentSource struct {
-	client *buddy.Client
+	client        *buddy.Client
+	defaultDomain string
 }
 
 type environmentSourceModel struct {
@@ -56,7 +57,9 @@ func (s *environmentSource) Configure(_ context.Context, req datasource.Configur
 	if req.ProviderData == nil {
 		return
 	}
-	s.client = req.ProviderData.(*buddy.Client)
+	p := req.ProviderData.(*util.ProviderData)
+	s.client = p.Client
+	s.defaultDomain = p.Domain
 }
 
 func (s *environmentSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
@@ -69,8 +72,9 @@ func (s *environmentSource) Schema(_ context.Context, _ datasource.SchemaRequest
 				Computed:            true,
 			},
 			"domain": schema.StringAttribute{
-				MarkdownDescription: "The workspace's URL handle",
-				Required:            true,
+				MarkdownDescription: "The workspace's URL handle. Defaults to the provider's `domain`",
+				Optional:            true,
+				Computed:            true,
 				Validators:          util.StringValidatorsDomain(),
 			},
 			"project_name": schema.StringAttribute{
@@ -121,6 +125,10 @@ func (s *environmentSource) Read(ctx context.Context, req datasource.ReadRequest
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(util.ResolveDomain(path.Root("domain"), &data.Domain, s.defaultDomain)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	var environment *buddy.Environment
 	var err error
 	domain := data.Domain.ValueString()
@@ -25,7 +25,8 @@ func NewGroupSource() datasource.DataSource {
 }
 
 type groupSource struct {
-	client *buddy.Client
+	client        *buddy.Client
+	defaultDomain string
 }
 
 type groupSourceModel struct {
@@ -54,7 +55,9 @@ func (s *groupSource) Configure(_ context.Context, req datasource.ConfigureReque
 	if req.ProviderData == nil {
 		return
 	}
-	s.client = req.ProviderData.(*buddy.Client)
+	p := req.ProviderData.(*util.ProviderData)
+	s.client = p.Client
+	s.defaultDomain = p.Domain
 }
 
 func (s *groupSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
@@ -67,8 +70,9 @@ func (s *groupSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp
 				Computed:            true,
 			},
 			"domain": schema.StringAttribute{
-				MarkdownDescription: "The workspace's URL handle",
-				Required:            true,
+				MarkdownDescription: "The workspace's URL handle. Defaults to the provider's `domain`",
+				Optional:            true,
+				Computed:            true,
 				Validators:          util.StringValidatorsDomain(),
 			},
 			"name": schema.StringAttribute{
@@ -111,6 +115,10 @@ func (s *groupSource) Read(ctx context.Context, req datasource.ReadRequest, resp
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(util.ResolveDomain(path.Root("domain"), &data.Domain, s.defaultDomain)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	var group *buddy.Group
 	var err error
 	domain := data.Domain.ValueString()
@@ -6,6 +6,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"regexp"
@@ -22,7 +23,8 @@ func NewGroupMembersSource() datasource.DataSource {
 }
 
 type groupMembersSource struct {
-	client *buddy.Client
+	client        *buddy.Client
+	defaultDomain string
 }
 
 type groupMembersSourceModel struct {
@@ -50,7 +52,9 @@ func (s *groupMembersSource) Configure(_ context.Context, req datasource.Configu
 	if req.ProviderData == nil {
 		return
 	}
-	s.client = req.ProviderData.(*buddy.Client)
+	p := req.ProviderData.(*util.ProviderData)
+	s.client = p.Client
+	s.defaultDomain = p.Domain
 }
 
 func (s *groupMembersSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
@@ -63,8 +67,9 @@ func (s *groupMembersSource) Schema(_ context.Context, _ datasource.SchemaReques
 				Computed:            true,
 			},
 			"domain": schema.StringAttribute{
-				MarkdownDescription: "The workspace's URL handle",
-				Required:            true,
+				MarkdownDescription: "The workspace's URL handle. Defaults to the provider's `domain`",
+				Optional:            true,
+				Computed:            true,
 				Validators:          util.StringValidatorsDomain(),
 			},
 			"group_id": schema.Int64Attribute{
@@ -95,6 +100,10 @@ func (s *groupMembersSource) Read(ctx context.Context, req datasource.ReadReques
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(util.ResolveDomain(path.Root("domain"), &data.Domain, s.defaultDomain)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	domain := data.Domain.ValueString()
 	groupId := int(data.GroupId.ValueInt64())
 	members, _, err := s.client.GroupService.GetGroupMembers(domain, groupId)
@@ -6,6 +6,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"regexp"
@@ -22,7 +23,8 @@ func NewGroupsSource() datasource.DataSource {
 }
 
 type groupsSource struct {
-	client *buddy.Client
+	client        *buddy.Client
+	defaultDomain string
 }
 
 type groupsSourceModel struct {
@@ -48,7 +50,9 @@ func (s *groupsSource) Configure(_ context.Context, req datasource.ConfigureRequ
 	if req.ProviderData == nil {
 		return
 	}
-	s.client = req.ProviderData.(*buddy.Client)
+	p := req.ProviderData.(*util.ProviderData)
+	s.client = p.Client
+	s.defaultDomain = p.Domain
 }
 
 func (s *groupsSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
@@ -61,8 +65,9 @@ func (s *groupsSource) Schema(_ context.Context, _ datasource.SchemaRequest, res
 				Computed:            true,
 			},
 			"domain": schema.StringAttribute{
-				MarkdownDescription: "The workspace's URL handle",
-				Required:            true,
+				MarkdownDescription: "The workspace's URL handle. Defaults to the provider's `domain`",
+				Optional:            true,
+				Computed:            true,
 				Validators:          util.StringValidatorsDomain(),
 			},
 			"name_regex": schema.StringAttribute{
@@ -89,6 +94,10 @@ func (s *groupsSource) Read(ctx context.Context, req datasource.ReadRequest, res
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(util.ResolveDomain(path.Root("domain"), &data.Domain, s.defaultDomain)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	domain := data.Domain.ValueString()
 	var nameRegex *regexp.Regexp
 	if !data.NameRegex.IsNull() && !data.NameRegex.IsUnknown() {
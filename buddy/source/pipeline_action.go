@@ -0,0 +1,214 @@
+package source
+
+import (
+	"context"
+	"github.com/buddy/api-go-sdk/buddy"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"net/http"
+	"strconv"
+	"terraform-provider-buddy/buddy/util"
+)
+
+var (
+	_ datasource.DataSource              = &pipelineActionSource{}
+	_ datasource.DataSourceWithConfigure = &pipelineActionSource{}
+)
+
+func NewPipelineActionSource() datasource.DataSource {
+	return &pipelineActionSource{}
+}
+
+type pipelineActionSource struct {
+	client        *buddy.Client
+	defaultDomain string
+}
+
+type pipelineActionSourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	Domain          types.String `tfsdk:"domain"`
+	ProjectName     types.String `tfsdk:"project_name"`
+	PipelineId      types.Int64  `tfsdk:"pipeline_id"`
+	ActionId        types.Int64  `tfsdk:"action_id"`
+	Name            types.String `tfsdk:"name"`
+	Type            types.String `tfsdk:"type"`
+	HtmlUrl         types.String `tfsdk:"html_url"`
+	TriggerTime     types.String `tfsdk:"trigger_time"`
+	Disabled        types.Bool   `tfsdk:"disabled"`
+	Shell           types.String `tfsdk:"shell"`
+	DockerImageName types.String `tfsdk:"docker_image_name"`
+	DockerImageTag  types.String `tfsdk:"docker_image_tag"`
+	ExecuteCommands types.List   `tfsdk:"execute_commands"`
+}
+
+func (s *pipelineActionSourceModel) loadAPI(ctx context.Context, domain string, projectName string, pipelineId int, action *buddy.PipelineAction) diag.Diagnostics {
+	s.ID = types.StringValue(util.ComposeQuadrupleId(domain, projectName, strconv.Itoa(pipelineId), strconv.Itoa(action.Id)))
+	s.Domain = types.StringValue(domain)
+	s.ProjectName = types.StringValue(projectName)
+	s.PipelineId = types.Int64Value(int64(pipelineId))
+	s.ActionId = types.Int64Value(int64(action.Id))
+	s.Name = types.StringValue(action.Name)
+	s.Type = types.StringValue(action.Type)
+	s.HtmlUrl = types.StringValue(action.HtmlUrl)
+	s.TriggerTime = types.StringValue(action.TriggerTime)
+	s.Disabled = types.BoolValue(action.Disabled)
+	s.Shell = types.StringValue(action.Shell)
+	s.DockerImageName = types.StringValue(action.DockerImageName)
+	s.DockerImageTag = types.StringValue(action.DockerImageTag)
+	ec, d := types.ListValueFrom(ctx, types.StringType, &action.ExecuteCommands)
+	s.ExecuteCommands = ec
+	return d
+}
+
+func (s *pipelineActionSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pipeline_action"
+}
+
+func (s *pipelineActionSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	p := req.ProviderData.(*util.ProviderData)
+	s.client = p.Client
+	s.defaultDomain = p.Domain
+}
+
+func (s *pipelineActionSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Get pipeline action by name or action ID\n\n" +
+			"Token scopes required: `WORKSPACE`, `EXECUTION_INFO`",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The Terraform resource identifier for this item",
+				Computed:            true,
+			},
+			"domain": schema.StringAttribute{
+				MarkdownDescription: "The workspace's URL handle. Defaults to the provider's `domain`",
+				Optional:            true,
+				Computed:            true,
+				Validators:          util.StringValidatorsDomain(),
+			},
+			"project_name": schema.StringAttribute{
+				MarkdownDescription: "The project's name",
+				Required:            true,
+			},
+			"pipeline_id": schema.Int64Attribute{
+				MarkdownDescription: "The pipeline's ID",
+				Required:            true,
+			},
+			"action_id": schema.Int64Attribute{
+				MarkdownDescription: "The action's ID",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.Int64{
+					int64validator.ExactlyOneOf(path.Expressions{
+						path.MatchRoot("action_id"),
+						path.MatchRoot("name"),
+					}...),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The action's name",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.Expressions{
+						path.MatchRoot("action_id"),
+						path.MatchRoot("name"),
+					}...),
+				},
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "The action's type",
+				Computed:            true,
+			},
+			"html_url": schema.StringAttribute{
+				MarkdownDescription: "The action's URL",
+				Computed:            true,
+			},
+			"trigger_time": schema.StringAttribute{
+				MarkdownDescription: "Specifies when the action is run",
+				Computed:            true,
+			},
+			"disabled": schema.BoolAttribute{
+				MarkdownDescription: "Defines whether or not the action is disabled",
+				Computed:            true,
+			},
+			"shell": schema.StringAttribute{
+				MarkdownDescription: "The shell that is used to execute commands",
+				Computed:            true,
+			},
+			"docker_image_name": schema.StringAttribute{
+				MarkdownDescription: "The name of the Docker image the action is run in",
+				Computed:            true,
+			},
+			"docker_image_tag": schema.StringAttribute{
+				MarkdownDescription: "The tag of the Docker image the action is run in",
+				Computed:            true,
+			},
+			"execute_commands": schema.ListAttribute{
+				MarkdownDescription: "The commands that are executed",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+		},
+	}
+}
+
+func (s *pipelineActionSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data *pipelineActionSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(util.ResolveDomain(path.Root("domain"), &data.Domain, s.defaultDomain)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	domain := data.Domain.ValueString()
+	projectName := data.ProjectName.ValueString()
+	pipelineId := int(data.PipelineId.ValueInt64())
+	var action *buddy.PipelineAction
+	var err error
+	if !data.ActionId.IsNull() && !data.ActionId.IsUnknown() {
+		var httpRes *http.Response
+		action, httpRes, err = s.client.PipelineActionService.Get(domain, projectName, pipelineId, int(data.ActionId.ValueInt64()))
+		if err != nil {
+			if util.IsResourceNotFound(httpRes, err) {
+				resp.Diagnostics.Append(util.NewDiagnosticApiNotFound("pipeline action"))
+				return
+			}
+			resp.Diagnostics.Append(util.NewDiagnosticApiError("get pipeline action", err))
+			return
+		}
+	} else {
+		name := data.Name.ValueString()
+		var actions *buddy.PipelineActions
+		actions, _, err = s.client.PipelineActionService.GetList(domain, projectName, pipelineId)
+		if err != nil {
+			resp.Diagnostics.Append(util.NewDiagnosticApiError("get pipeline actions", err))
+			return
+		}
+		for _, a := range actions.Actions {
+			if a.Name == name {
+				action = a
+				break
+			}
+		}
+		if action == nil {
+			resp.Diagnostics.Append(util.NewDiagnosticApiNotFound("pipeline action"))
+			return
+		}
+	}
+	resp.Diagnostics.Append(data.loadAPI(ctx, domain, projectName, pipelineId, action)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
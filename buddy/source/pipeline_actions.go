@@ -0,0 +1,137 @@
+package source
+
+import (
+	"context"
+	"github.com/buddy/api-go-sdk/buddy"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"regexp"
+	"terraform-provider-buddy/buddy/util"
+)
+
+var (
+	_ datasource.DataSource              = &pipelineActionsSource{}
+	_ datasource.DataSourceWithConfigure = &pipelineActionsSource{}
+)
+
+func NewPipelineActionsSource() datasource.DataSource {
+	return &pipelineActionsSource{}
+}
+
+type pipelineActionsSource struct {
+	client        *buddy.Client
+	defaultDomain string
+}
+
+type pipelineActionsSourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Domain      types.String `tfsdk:"domain"`
+	ProjectName types.String `tfsdk:"project_name"`
+	PipelineId  types.Int64  `tfsdk:"pipeline_id"`
+	NameRegex   types.String `tfsdk:"name_regex"`
+	Actions     types.List   `tfsdk:"actions"`
+}
+
+func (s *pipelineActionsSourceModel) loadAPI(ctx context.Context, domain string, projectName string, pipelineId int, actions *[]*buddy.PipelineAction) diag.Diagnostics {
+	s.ID = types.StringValue(util.UniqueString())
+	s.Domain = types.StringValue(domain)
+	s.ProjectName = types.StringValue(projectName)
+	s.PipelineId = types.Int64Value(int64(pipelineId))
+	a, d := util.PipelineActionsModelFromApi(ctx, actions)
+	s.Actions = a
+	return d
+}
+
+func (s *pipelineActionsSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pipeline_actions"
+}
+
+func (s *pipelineActionsSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	p := req.ProviderData.(*util.ProviderData)
+	s.client = p.Client
+	s.defaultDomain = p.Domain
+}
+
+func (s *pipelineActionsSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "List pipeline actions in execution order and optionally filter them by name\n\n" +
+			"Token scopes required: `WORKSPACE`, `EXECUTION_INFO`",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The Terraform resource identifier for this item",
+				Computed:            true,
+			},
+			"domain": schema.StringAttribute{
+				MarkdownDescription: "The workspace's URL handle. Defaults to the provider's `domain`",
+				Optional:            true,
+				Computed:            true,
+				Validators:          util.StringValidatorsDomain(),
+			},
+			"project_name": schema.StringAttribute{
+				MarkdownDescription: "The project's name",
+				Required:            true,
+			},
+			"pipeline_id": schema.Int64Attribute{
+				MarkdownDescription: "The pipeline's ID",
+				Required:            true,
+			},
+			"name_regex": schema.StringAttribute{
+				MarkdownDescription: "The action's name regular expression to match",
+				Optional:            true,
+				Validators: []validator.String{
+					util.RegexpValidator(),
+				},
+			},
+			"actions": schema.ListNestedAttribute{
+				MarkdownDescription: "List of actions",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: util.SourcePipelineActionModelAttributes(),
+				},
+			},
+		},
+	}
+}
+
+func (s *pipelineActionsSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data *pipelineActionsSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(util.ResolveDomain(path.Root("domain"), &data.Domain, s.defaultDomain)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	domain := data.Domain.ValueString()
+	projectName := data.ProjectName.ValueString()
+	pipelineId := int(data.PipelineId.ValueInt64())
+	var nameRegex *regexp.Regexp
+	if !data.NameRegex.IsNull() && !data.NameRegex.IsUnknown() {
+		nameRegex = regexp.MustCompile(data.NameRegex.ValueString())
+	}
+	actions, _, err := s.client.PipelineActionService.GetList(domain, projectName, pipelineId)
+	if err != nil {
+		resp.Diagnostics.Append(util.NewDiagnosticApiError("get pipeline actions", err))
+		return
+	}
+	var result []*buddy.PipelineAction
+	for _, a := range actions.Actions {
+		if nameRegex != nil && !nameRegex.MatchString(a.Name) {
+			continue
+		}
+		result = append(result, a)
+	}
+	resp.Diagnostics.Append(data.loadAPI(ctx, domain, projectName, pipelineId, &result)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
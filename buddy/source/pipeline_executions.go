@@ -0,0 +1,243 @@
+package source
+
+import (
+	"context"
+	"github.com/buddy/api-go-sdk/buddy"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"strings"
+	"terraform-provider-buddy/buddy/util"
+	"time"
+)
+
+var (
+	_ datasource.DataSource                   = &pipelineExecutionsSource{}
+	_ datasource.DataSourceWithConfigure      = &pipelineExecutionsSource{}
+	_ datasource.DataSourceWithValidateConfig = &pipelineExecutionsSource{}
+)
+
+const (
+	pipelineExecutionsDefaultLimit = 20
+	pipelineExecutionsPerPage      = 50
+	// filtered out runs don't stop paging, so the number of scanned runs is capped
+	pipelineExecutionsMaxPages = 20
+)
+
+func NewPipelineExecutionsSource() datasource.DataSource {
+	return &pipelineExecutionsSource{}
+}
+
+type pipelineExecutionsSource struct {
+	client        *buddy.Client
+	defaultDomain string
+}
+
+type pipelineExecutionsSourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	Domain         types.String `tfsdk:"domain"`
+	ProjectName    types.String `tfsdk:"project_name"`
+	PipelineId     types.Int64  `tfsdk:"pipeline_id"`
+	Status         types.String `tfsdk:"status"`
+	Branch         types.String `tfsdk:"branch"`
+	CreatorEmail   types.String `tfsdk:"creator_email"`
+	StartedAfter   types.String `tfsdk:"started_after"`
+	StartedBefore  types.String `tfsdk:"started_before"`
+	Limit          types.Int64  `tfsdk:"limit"`
+	IncludeActions types.Bool   `tfsdk:"include_actions"`
+	Executions     types.List   `tfsdk:"executions"`
+}
+
+func (s *pipelineExecutionsSourceModel) loadAPI(ctx context.Context, domain string, projectName string, pipelineId int, executions *[]*buddy.Execution) diag.Diagnostics {
+	s.ID = types.StringValue(util.UniqueString())
+	s.Domain = types.StringValue(domain)
+	s.ProjectName = types.StringValue(projectName)
+	s.PipelineId = types.Int64Value(int64(pipelineId))
+	e, d := util.PipelineExecutionsModelFromApi(ctx, executions)
+	s.Executions = e
+	return d
+}
+
+func (s *pipelineExecutionsSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pipeline_executions"
+}
+
+func (s *pipelineExecutionsSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	p := req.ProviderData.(*util.ProviderData)
+	s.client = p.Client
+	s.defaultDomain = p.Domain
+}
+
+func (s *pipelineExecutionsSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "List pipeline runs from the newest one and optionally filter them. Filters are applied to the newest 1000 runs\n\n" +
+			"Token scopes required: `WORKSPACE`, `EXECUTION_INFO`",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The Terraform resource identifier for this item",
+				Computed:            true,
+			},
+			"domain": schema.StringAttribute{
+				MarkdownDescription: "The workspace's URL handle. Defaults to the provider's `domain`",
+				Optional:            true,
+				Computed:            true,
+				Validators:          util.StringValidatorsDomain(),
+			},
+			"project_name": schema.StringAttribute{
+				MarkdownDescription: "The project's name",
+				Required:            true,
+			},
+			"pipeline_id": schema.Int64Attribute{
+				MarkdownDescription: "The pipeline's ID",
+				Required:            true,
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Filter runs by status. For example: `SUCCESSFUL`, `FAILED`, `INPROGRESS`, `TERMINATED`",
+				Optional:            true,
+			},
+			"branch": schema.StringAttribute{
+				MarkdownDescription: "Filter runs by branch",
+				Optional:            true,
+			},
+			"creator_email": schema.StringAttribute{
+				MarkdownDescription: "Filter runs by the email of the member who started them",
+				Optional:            true,
+			},
+			"started_after": schema.StringAttribute{
+				MarkdownDescription: "Filter runs started at or after the date (RFC3339). For example: `2026-01-01T00:00:00Z`",
+				Optional:            true,
+			},
+			"started_before": schema.StringAttribute{
+				MarkdownDescription: "Filter runs started before the date (RFC3339). For example: `2026-02-01T00:00:00Z`",
+				Optional:            true,
+			},
+			"limit": schema.Int64Attribute{
+				MarkdownDescription: "The maximum number of runs to return. Defaults to `20`",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 1000),
+				},
+			},
+			"include_actions": schema.BoolAttribute{
+				MarkdownDescription: "Load statuses of the runs' actions (`executions.actions`). It takes one API call per returned run. Defaults to `false`",
+				Optional:            true,
+			},
+			"executions": schema.ListNestedAttribute{
+				MarkdownDescription: "List of runs",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: util.SourcePipelineExecutionModelAttributes(),
+				},
+			},
+		},
+	}
+}
+
+func (s *pipelineExecutionsSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	for _, name := range []string{"started_after", "started_before"} {
+		var v types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(name), &v)...)
+		if v.IsNull() || v.IsUnknown() {
+			continue
+		}
+		if _, err := time.Parse(time.RFC3339, v.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root(name), "Invalid attribute value", "Date must be in the RFC3339 format, e.g. `2026-01-01T00:00:00Z`")
+		}
+	}
+}
+
+func (s *pipelineExecutionsSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data *pipelineExecutionsSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(util.ResolveDomain(path.Root("domain"), &data.Domain, s.defaultDomain)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	domain := data.Domain.ValueString()
+	projectName := data.ProjectName.ValueString()
+	pipelineId := int(data.PipelineId.ValueInt64())
+	limit := pipelineExecutionsDefaultLimit
+	if !data.Limit.IsNull() && !data.Limit.IsUnknown() {
+		limit = int(data.Limit.ValueInt64())
+	}
+	var startedAfter, startedBefore *time.Time
+	if !data.StartedAfter.IsNull() && !data.StartedAfter.IsUnknown() {
+		t, _ := time.Parse(time.RFC3339, data.StartedAfter.ValueString())
+		startedAfter = &t
+	}
+	if !data.StartedBefore.IsNull() && !data.StartedBefore.IsUnknown() {
+		t, _ := time.Parse(time.RFC3339, data.StartedBefore.ValueString())
+		startedBefore = &t
+	}
+	var result []*buddy.Execution
+	// runs are returned from the newest one so paging stops at the first run older than started_after
+	for page := 1; len(result) < limit && page <= pipelineExecutionsMaxPages; page++ {
+		executions, _, err := s.client.ExecutionService.GetList(domain, projectName, pipelineId, &buddy.ExecutionGetListQuery{
+			Page:    page,
+			PerPage: pipelineExecutionsPerPage,
+		})
+		if err != nil {
+			resp.Diagnostics.Append(util.NewDiagnosticApiError("get pipeline executions", err))
+			return
+		}
+		done := len(executions.Executions) < pipelineExecutionsPerPage
+		for _, e := range executions.Executions {
+			if startedAfter != nil || startedBefore != nil {
+				started, err := time.Parse(time.RFC3339, e.StartDate)
+				if err != nil {
+					// not started yet
+					continue
+				}
+				if startedAfter != nil && started.Before(*startedAfter) {
+					done = true
+					break
+				}
+				if startedBefore != nil && !started.Before(*startedBefore) {
+					continue
+				}
+			}
+			if !data.Status.IsNull() && !data.Status.IsUnknown() && e.Status != data.Status.ValueString() {
+				continue
+			}
+			if !data.Branch.IsNull() && !data.Branch.IsUnknown() && util.PipelineExecutionBranch(e) != data.Branch.ValueString() {
+				continue
+			}
+			if !data.CreatorEmail.IsNull() && !data.CreatorEmail.IsUnknown() && (e.Creator == nil || !strings.EqualFold(e.Creator.Email, data.CreatorEmail.ValueString())) {
+				continue
+			}
+			result = append(result, e)
+			if len(result) >= limit {
+				break
+			}
+		}
+		if done {
+			break
+		}
+	}
+	// list of runs doesn't return actions' statuses
+	if data.IncludeActions.ValueBool() {
+		for i, e := range result {
+			execution, _, err := s.client.ExecutionService.Get(domain, projectName, pipelineId, e.Id)
+			if err != nil {
+				resp.Diagnostics.Append(util.NewDiagnosticApiError("get pipeline execution", err))
+				return
+			}
+			result[i] = execution
+		}
+	}
+	resp.Diagnostics.Append(data.loadAPI(ctx, domain, projectName, pipelineId, &result)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,116 @@
+package source
+
+import (
+	"context"
+	"github.com/buddy/api-go-sdk/buddy"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"strconv"
+	"terraform-provider-buddy/buddy/util"
+)
+
+var (
+	_ datasource.DataSource              = &pipelineYamlSource{}
+	_ datasource.DataSourceWithConfigure = &pipelineYamlSource{}
+)
+
+func NewPipelineYamlSource() datasource.DataSource {
+	return &pipelineYamlSource{}
+}
+
+type pipelineYamlSource struct {
+	client        *buddy.Client
+	defaultDomain string
+}
+
+type pipelineYamlSourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Domain      types.String `tfsdk:"domain"`
+	ProjectName types.String `tfsdk:"project_name"`
+	PipelineId  types.Int64  `tfsdk:"pipeline_id"`
+	Yaml        types.String `tfsdk:"yaml"`
+}
+
+func (s *pipelineYamlSourceModel) loadAPI(domain string, projectName string, pipelineId int, yaml string) {
+	s.ID = types.StringValue(util.ComposeTripleId(domain, projectName, strconv.Itoa(pipelineId)))
+	s.Domain = types.StringValue(domain)
+	s.ProjectName = types.StringValue(projectName)
+	s.PipelineId = types.Int64Value(int64(pipelineId))
+	s.Yaml = types.StringValue(yaml)
+}
+
+func (s *pipelineYamlSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pipeline_yaml"
+}
+
+func (s *pipelineYamlSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	p := req.ProviderData.(*util.ProviderData)
+	s.client = p.Client
+	s.defaultDomain = p.Domain
+}
+
+func (s *pipelineYamlSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Get the YAML definition of a pipeline, actions included. Keys are sorted so the output doesn't change unless the pipeline does\n\n" +
+			"Token scopes required: `WORKSPACE`, `EXECUTION_INFO`",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The Terraform resource identifier for this item",
+				Computed:            true,
+			},
+			"domain": schema.StringAttribute{
+				MarkdownDescription: "The workspace's URL handle. Defaults to the provider's `domain`",
+				Optional:            true,
+				Computed:            true,
+				Validators:          util.StringValidatorsDomain(),
+			},
+			"project_name": schema.StringAttribute{
+				MarkdownDescription: "The project's name",
+				Required:            true,
+			},
+			"pipeline_id": schema.Int64Attribute{
+				MarkdownDescription: "The pipeline's ID",
+				Required:            true,
+			},
+			"yaml": schema.StringAttribute{
+				MarkdownDescription: "The pipeline's normalized YAML",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (s *pipelineYamlSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data *pipelineYamlSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(util.ResolveDomain(path.Root("domain"), &data.Domain, s.defaultDomain)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	domain := data.Domain.ValueString()
+	projectName := data.ProjectName.ValueString()
+	pipelineId := int(data.PipelineId.ValueInt64())
+	pipelineYaml, _, err := s.client.PipelineService.GetYaml(domain, projectName, pipelineId)
+	if err != nil {
+		resp.Diagnostics.Append(util.NewDiagnosticApiError("get pipeline yaml", err))
+		return
+	}
+	yaml, err := util.DecodePipelineYaml(pipelineYaml.Yaml)
+	if err == nil {
+		yaml, err = util.NormalizeYaml(yaml)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid pipeline yaml", err.Error())
+		return
+	}
+	data.loadAPI(domain, projectName, pipelineId, yaml)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
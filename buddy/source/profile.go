@@ -47,7 +47,7 @@ func (s *profileSource) Configure(_ context.Context, req datasource.ConfigureReq
 	if req.ProviderData == nil {
 		return
 	}
-	s.client = req.ProviderData.(*buddy.Client)
+	s.client = req.ProviderData.(*util.ProviderData).Client
 }
 
 func (s *profileSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
@@ -11,9 +11,9 @@ import (
 )
 
 func TestAccSourceEnvironment(t *testing.T) {
-	domain := util.UniqueString()
-	projectName := util.UniqueString()
-	name := util.UniqueString()
+	domain := acc.UniqueString()
+	projectName := acc.UniqueString()
+	name := acc.UniqueString()
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
 			acc.PreCheck(t)
@@ -12,10 +12,10 @@ import (
 )
 
 func TestAccSourceEnvironments(t *testing.T) {
-	domain := util.UniqueString()
-	projectName := util.UniqueString()
-	name1 := "aaaa" + util.UniqueString()
-	name2 := util.UniqueString()
+	domain := acc.UniqueString()
+	projectName := acc.UniqueString()
+	name1 := "aaaa" + acc.UniqueString()
+	name2 := acc.UniqueString()
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
 			acc.PreCheck(t)
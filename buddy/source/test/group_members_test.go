@@ -11,8 +11,8 @@ import (
 )
 
 func TestAccSourceGroupMembers(t *testing.T) {
-	domain := util.UniqueString()
-	groupName := util.UniqueString()
+	domain := acc.UniqueString()
+	groupName := acc.UniqueString()
 	email1 := util.RandEmail()
 	email2 := util.RandEmail()
 	resource.Test(t, resource.TestCase{
@@ -11,7 +11,7 @@ import (
 )
 
 func TestAccSourceGroup(t *testing.T) {
-	domain := util.UniqueString()
+	domain := acc.UniqueString()
 	name := util.RandString(5)
 	desc := util.RandString(5)
 	resource.Test(t, resource.TestCase{
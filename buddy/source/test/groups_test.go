@@ -80,5 +80,5 @@ data "buddy_groups" "name" {
    depends_on = [buddy_group.a, buddy_group.b]
    name_regex = "^abc"
 }
-`, util.UniqueString())
+`, acc.UniqueString())
 }
@@ -11,7 +11,7 @@ import (
 )
 
 func TestAccSourceIntegration(t *testing.T) {
-	domain := util.UniqueString()
+	domain := acc.UniqueString()
 	name := util.RandString(10)
 	typ := buddy.IntegrationTypeAmazon
 	scope := buddy.IntegrationScopeWorkspace
@@ -19,7 +19,7 @@ func TestAccSourceMember(t *testing.T) {
 		ProtoV6ProviderFactories: acc.ProviderFactories,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccSourceMemberConfig(util.UniqueString()),
+				Config: testAccSourceMemberConfig(acc.UniqueString()),
 				Check: resource.ComposeTestCheckFunc(
 					testAccSourceMemberAttributesCheck("data.buddy_member.id"),
 					testAccSourceMemberAttributesCheck("data.buddy_member.name"),
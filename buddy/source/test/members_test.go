@@ -11,7 +11,7 @@ import (
 )
 
 func TestAccSourceMembers(t *testing.T) {
-	domain := util.UniqueString()
+	domain := acc.UniqueString()
 	email1 := util.RandEmail()
 	email2 := util.RandEmail()
 	resource.Test(t, resource.TestCase{
@@ -12,7 +12,7 @@ import (
 )
 
 func TestAccSourcePermission(t *testing.T) {
-	domain := util.UniqueString()
+	domain := acc.UniqueString()
 	name := util.RandString(10)
 	pipelineAccessLevel := buddy.PermissionAccessLevelReadWrite
 	repositoryAccessLevel := buddy.PermissionAccessLevelReadOnly
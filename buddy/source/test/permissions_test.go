@@ -12,7 +12,7 @@ import (
 )
 
 func TestAccSourcePermissions(t *testing.T) {
-	domain := util.UniqueString()
+	domain := acc.UniqueString()
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
 			acc.PreCheck(t)
@@ -0,0 +1,110 @@
+package test
+
+import (
+	"fmt"
+	"github.com/buddy/api-go-sdk/buddy"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"strconv"
+	"terraform-provider-buddy/buddy/acc"
+	"terraform-provider-buddy/buddy/util"
+	"testing"
+)
+
+func TestAccSourcePipelineAction(t *testing.T) {
+	domain := acc.UniqueString()
+	projectName := acc.UniqueString()
+	pipelineName := util.RandString(10)
+	name := util.RandString(10)
+	cmd := util.RandString(10)
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acc.PreCheck(t)
+		},
+		CheckDestroy:             acc.DummyCheckDestroy,
+		ProtoV6ProviderFactories: acc.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSourcePipelineActionConfig(domain, projectName, pipelineName, name, cmd),
+				Check: resource.ComposeTestCheckFunc(
+					testAccSourcePipelineActionAttributes("data.buddy_pipeline_action.name", name, cmd),
+					testAccSourcePipelineActionAttributes("data.buddy_pipeline_action.id", name, cmd),
+				),
+			},
+		},
+	})
+}
+
+func testAccSourcePipelineActionAttributes(n string, name string, cmd string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+		attrs := rs.Primary.Attributes
+		attrsActionId, _ := strconv.Atoi(attrs["action_id"])
+		if err := util.CheckFieldEqualAndSet("name", attrs["name"], name); err != nil {
+			return err
+		}
+		if err := util.CheckFieldEqualAndSet("type", attrs["type"], "BUILD"); err != nil {
+			return err
+		}
+		if err := util.CheckFieldEqualAndSet("trigger_time", attrs["trigger_time"], buddy.PipelineActionTriggerTimeOnEveryExecution); err != nil {
+			return err
+		}
+		if err := util.CheckFieldEqualAndSet("execute_commands.0", attrs["execute_commands.0"], cmd); err != nil {
+			return err
+		}
+		if err := util.CheckFieldSet("html_url", attrs["html_url"]); err != nil {
+			return err
+		}
+		if err := util.CheckIntFieldSet("action_id", attrsActionId); err != nil {
+			return err
+		}
+		return nil
+	}
+}
+
+func testAccSourcePipelineActionConfig(domain string, projectName string, pipelineName string, name string, cmd string) string {
+	return fmt.Sprintf(`
+resource "buddy_workspace" "foo" {
+   domain = "%s"
+}
+
+resource "buddy_project" "proj" {
+   domain = "${buddy_workspace.foo.domain}"
+   display_name = "%s"
+}
+
+resource "buddy_pipeline" "pip" {
+   domain = "${buddy_workspace.foo.domain}"
+   project_name = "${buddy_project.proj.name}"
+   name = "%s"
+}
+
+resource "buddy_pipeline_action" "act" {
+   domain = "${buddy_workspace.foo.domain}"
+   project_name = "${buddy_project.proj.name}"
+   pipeline_id = "${buddy_pipeline.pip.pipeline_id}"
+   name = "%s"
+   type = "BUILD"
+   docker_image_name = "library/ubuntu"
+   docker_image_tag = "22.04"
+   execute_commands = ["%s"]
+}
+
+data "buddy_pipeline_action" "name" {
+   domain = "${buddy_workspace.foo.domain}"
+   project_name = "${buddy_project.proj.name}"
+   pipeline_id = "${buddy_pipeline.pip.pipeline_id}"
+   name = "${buddy_pipeline_action.act.name}"
+}
+
+data "buddy_pipeline_action" "id" {
+   domain = "${buddy_workspace.foo.domain}"
+   project_name = "${buddy_project.proj.name}"
+   pipeline_id = "${buddy_pipeline.pip.pipeline_id}"
+   action_id = "${buddy_pipeline_action.act.action_id}"
+}
+`, domain, projectName, pipelineName, name, cmd)
+}
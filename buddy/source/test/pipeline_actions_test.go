@@ -0,0 +1,122 @@
+package test
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"strconv"
+	"terraform-provider-buddy/buddy/acc"
+	"terraform-provider-buddy/buddy/util"
+	"testing"
+)
+
+func TestAccSourcePipelineActions(t *testing.T) {
+	domain := acc.UniqueString()
+	projectName := acc.UniqueString()
+	pipelineName := util.RandString(10)
+	name1 := "aaaa" + util.RandString(10)
+	name2 := util.RandString(10)
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acc.PreCheck(t)
+		},
+		CheckDestroy:             acc.DummyCheckDestroy,
+		ProtoV6ProviderFactories: acc.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSourcePipelineActionsConfig(domain, projectName, pipelineName, name1, name2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccSourcePipelineActionsAttributes("data.buddy_pipeline_actions.all", 2, name1),
+					testAccSourcePipelineActionsAttributes("data.buddy_pipeline_actions.name", 1, name1),
+				),
+			},
+		},
+	})
+}
+
+func testAccSourcePipelineActionsAttributes(n string, count int, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+		attrs := rs.Primary.Attributes
+		attrsActionsCount, _ := strconv.Atoi(attrs["actions.#"])
+		attrsActionId, _ := strconv.Atoi(attrs["actions.0.action_id"])
+		if err := util.CheckIntFieldEqual("actions.#", attrsActionsCount, count); err != nil {
+			return err
+		}
+		if count > 0 {
+			if err := util.CheckFieldEqualAndSet("actions.0.name", attrs["actions.0.name"], name); err != nil {
+				return err
+			}
+			if err := util.CheckFieldEqualAndSet("actions.0.type", attrs["actions.0.type"], "BUILD"); err != nil {
+				return err
+			}
+			if err := util.CheckFieldSet("actions.0.html_url", attrs["actions.0.html_url"]); err != nil {
+				return err
+			}
+			if err := util.CheckIntFieldSet("actions.0.action_id", attrsActionId); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func testAccSourcePipelineActionsConfig(domain string, projectName string, pipelineName string, name1 string, name2 string) string {
+	return fmt.Sprintf(`
+resource "buddy_workspace" "foo" {
+   domain = "%s"
+}
+
+resource "buddy_project" "proj" {
+   domain = "${buddy_workspace.foo.domain}"
+   display_name = "%s"
+}
+
+resource "buddy_pipeline" "pip" {
+   domain = "${buddy_workspace.foo.domain}"
+   project_name = "${buddy_project.proj.name}"
+   name = "%s"
+}
+
+resource "buddy_pipeline_action" "a1" {
+   domain = "${buddy_workspace.foo.domain}"
+   project_name = "${buddy_project.proj.name}"
+   pipeline_id = "${buddy_pipeline.pip.pipeline_id}"
+   name = "%s"
+   type = "BUILD"
+   docker_image_name = "library/ubuntu"
+   docker_image_tag = "22.04"
+   execute_commands = ["ls"]
+}
+
+resource "buddy_pipeline_action" "a2" {
+   domain = "${buddy_workspace.foo.domain}"
+   project_name = "${buddy_project.proj.name}"
+   pipeline_id = "${buddy_pipeline.pip.pipeline_id}"
+   after_action_id = "${buddy_pipeline_action.a1.action_id}"
+   name = "%s"
+   type = "BUILD"
+   docker_image_name = "library/ubuntu"
+   docker_image_tag = "22.04"
+   execute_commands = ["pwd"]
+}
+
+data "buddy_pipeline_actions" "all" {
+   domain = "${buddy_workspace.foo.domain}"
+   project_name = "${buddy_project.proj.name}"
+   pipeline_id = "${buddy_pipeline.pip.pipeline_id}"
+   depends_on = [buddy_pipeline_action.a1, buddy_pipeline_action.a2]
+}
+
+data "buddy_pipeline_actions" "name" {
+   domain = "${buddy_workspace.foo.domain}"
+   project_name = "${buddy_project.proj.name}"
+   pipeline_id = "${buddy_pipeline.pip.pipeline_id}"
+   name_regex = "^aaaa"
+   depends_on = [buddy_pipeline_action.a1, buddy_pipeline_action.a2]
+}
+`, domain, projectName, pipelineName, name1, name2)
+}
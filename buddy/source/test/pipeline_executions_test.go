@@ -0,0 +1,228 @@
+package test
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"regexp"
+	"strconv"
+	"terraform-provider-buddy/buddy/acc"
+	"terraform-provider-buddy/buddy/util"
+	"testing"
+)
+
+func TestAccSourcePipelineExecutions(t *testing.T) {
+	domain := acc.UniqueString()
+	projectName := acc.UniqueString()
+	pipelineName := util.RandString(10)
+	comment1 := util.RandString(10)
+	comment2 := util.RandString(10)
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acc.PreCheck(t)
+		},
+		CheckDestroy:             acc.DummyCheckDestroy,
+		ProtoV6ProviderFactories: acc.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSourcePipelineExecutionsConfig(domain, projectName, pipelineName, comment1, comment2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccSourcePipelineExecutionsAttributes("data.buddy_pipeline_executions.all", 2, true),
+					testAccSourcePipelineExecutionsAttributes("data.buddy_pipeline_executions.limit", 1, false),
+					testAccSourcePipelineExecutionsAttributes("data.buddy_pipeline_executions.successful", 2, false),
+					testAccSourcePipelineExecutionsAttributes("data.buddy_pipeline_executions.failed", 0, false),
+					testAccSourcePipelineExecutionsAttributes("data.buddy_pipeline_executions.future", 0, false),
+					testAccSourcePipelineExecutionsAttributes("data.buddy_pipeline_executions.creator", 2, false),
+					testAccSourcePipelineExecutionsAttributes("data.buddy_pipeline_executions.other_creator", 0, false),
+					testAccSourcePipelineExecutionsAttributes("data.buddy_pipeline_executions.branch", 0, false),
+				),
+			},
+		},
+	})
+}
+
+func TestSourcePipelineExecutionsValidateConfig(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acc.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "buddy_pipeline_executions" "test" {
+   domain = "test"
+   project_name = "test"
+   pipeline_id = 1
+   started_after = "yesterday"
+}`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Date must be in the RFC3339 format"),
+			},
+		},
+	})
+}
+
+func testAccSourcePipelineExecutionsAttributes(n string, count int, actions bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+		attrs := rs.Primary.Attributes
+		attrsExecutionsCount, _ := strconv.Atoi(attrs["executions.#"])
+		attrsExecutionId, _ := strconv.Atoi(attrs["executions.0.execution_id"])
+		if err := util.CheckIntFieldEqual("executions.#", attrsExecutionsCount, count); err != nil {
+			return err
+		}
+		if count > 0 {
+			if err := util.CheckFieldEqualAndSet("executions.0.status", attrs["executions.0.status"], "SUCCESSFUL"); err != nil {
+				return err
+			}
+			if err := util.CheckFieldSet("executions.0.html_url", attrs["executions.0.html_url"]); err != nil {
+				return err
+			}
+			if err := util.CheckFieldSet("executions.0.start_date", attrs["executions.0.start_date"]); err != nil {
+				return err
+			}
+			if err := util.CheckFieldSet("executions.0.finish_date", attrs["executions.0.finish_date"]); err != nil {
+				return err
+			}
+			if err := util.CheckIntFieldSet("executions.0.execution_id", attrsExecutionId); err != nil {
+				return err
+			}
+			attrsActionsCount, _ := strconv.Atoi(attrs["executions.0.actions.#"])
+			attrsActionId, _ := strconv.Atoi(attrs["executions.0.actions.0.action_id"])
+			if !actions {
+				return util.CheckIntFieldEqual("executions.0.actions.#", attrsActionsCount, 0)
+			}
+			if err := util.CheckIntFieldEqual("executions.0.actions.#", attrsActionsCount, 1); err != nil {
+				return err
+			}
+			if err := util.CheckIntFieldSet("executions.0.actions.0.action_id", attrsActionId); err != nil {
+				return err
+			}
+			if err := util.CheckFieldEqualAndSet("executions.0.actions.0.name", attrs["executions.0.actions.0.name"], "ls"); err != nil {
+				return err
+			}
+			if err := util.CheckFieldEqualAndSet("executions.0.actions.0.status", attrs["executions.0.actions.0.status"], "SUCCESSFUL"); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func testAccSourcePipelineExecutionsConfig(domain string, projectName string, pipelineName string, comment1 string, comment2 string) string {
+	return fmt.Sprintf(`
+resource "buddy_workspace" "foo" {
+   domain = "%s"
+}
+
+resource "buddy_project" "proj" {
+   domain = "${buddy_workspace.foo.domain}"
+   display_name = "%s"
+   without_repository = true
+}
+
+resource "buddy_pipeline" "pip" {
+   domain = "${buddy_workspace.foo.domain}"
+   project_name = "${buddy_project.proj.name}"
+   name = "%s"
+}
+
+resource "buddy_pipeline_action" "a1" {
+   domain = "${buddy_workspace.foo.domain}"
+   project_name = "${buddy_project.proj.name}"
+   pipeline_id = "${buddy_pipeline.pip.pipeline_id}"
+   name = "ls"
+   type = "BUILD"
+   docker_image_name = "library/ubuntu"
+   docker_image_tag = "22.04"
+   execute_commands = ["ls"]
+}
+
+resource "buddy_pipeline_execution" "e1" {
+   domain = "${buddy_workspace.foo.domain}"
+   project_name = "${buddy_project.proj.name}"
+   pipeline_id = "${buddy_pipeline_action.a1.pipeline_id}"
+   comment = "%s"
+}
+
+resource "buddy_pipeline_execution" "e2" {
+   domain = "${buddy_workspace.foo.domain}"
+   project_name = "${buddy_project.proj.name}"
+   pipeline_id = "${buddy_pipeline_action.a1.pipeline_id}"
+   comment = "%s"
+   depends_on = [buddy_pipeline_execution.e1]
+}
+
+data "buddy_pipeline_executions" "all" {
+   domain = "${buddy_workspace.foo.domain}"
+   project_name = "${buddy_project.proj.name}"
+   pipeline_id = "${buddy_pipeline.pip.pipeline_id}"
+   include_actions = true
+   depends_on = [buddy_pipeline_execution.e1, buddy_pipeline_execution.e2]
+}
+
+data "buddy_pipeline_executions" "limit" {
+   domain = "${buddy_workspace.foo.domain}"
+   project_name = "${buddy_project.proj.name}"
+   pipeline_id = "${buddy_pipeline.pip.pipeline_id}"
+   limit = 1
+   depends_on = [buddy_pipeline_execution.e1, buddy_pipeline_execution.e2]
+}
+
+data "buddy_pipeline_executions" "successful" {
+   domain = "${buddy_workspace.foo.domain}"
+   project_name = "${buddy_project.proj.name}"
+   pipeline_id = "${buddy_pipeline.pip.pipeline_id}"
+   status = "SUCCESSFUL"
+   depends_on = [buddy_pipeline_execution.e1, buddy_pipeline_execution.e2]
+}
+
+data "buddy_pipeline_executions" "failed" {
+   domain = "${buddy_workspace.foo.domain}"
+   project_name = "${buddy_project.proj.name}"
+   pipeline_id = "${buddy_pipeline.pip.pipeline_id}"
+   status = "FAILED"
+   depends_on = [buddy_pipeline_execution.e1, buddy_pipeline_execution.e2]
+}
+
+data "buddy_pipeline_executions" "future" {
+   domain = "${buddy_workspace.foo.domain}"
+   project_name = "${buddy_project.proj.name}"
+   pipeline_id = "${buddy_pipeline.pip.pipeline_id}"
+   started_after = "2100-01-01T00:00:00Z"
+   depends_on = [buddy_pipeline_execution.e1, buddy_pipeline_execution.e2]
+}
+
+data "buddy_profile" "me" {}
+
+data "buddy_member" "me" {
+   domain = "${buddy_workspace.foo.domain}"
+   member_id = "${data.buddy_profile.me.member_id}"
+}
+
+data "buddy_pipeline_executions" "creator" {
+   domain = "${buddy_workspace.foo.domain}"
+   project_name = "${buddy_project.proj.name}"
+   pipeline_id = "${buddy_pipeline.pip.pipeline_id}"
+   creator_email = upper(data.buddy_member.me.email)
+   depends_on = [buddy_pipeline_execution.e1, buddy_pipeline_execution.e2]
+}
+
+data "buddy_pipeline_executions" "other_creator" {
+   domain = "${buddy_workspace.foo.domain}"
+   project_name = "${buddy_project.proj.name}"
+   pipeline_id = "${buddy_pipeline.pip.pipeline_id}"
+   creator_email = "other@example.com"
+   depends_on = [buddy_pipeline_execution.e1, buddy_pipeline_execution.e2]
+}
+
+data "buddy_pipeline_executions" "branch" {
+   domain = "${buddy_workspace.foo.domain}"
+   project_name = "${buddy_project.proj.name}"
+   pipeline_id = "${buddy_pipeline.pip.pipeline_id}"
+   branch = "other"
+   depends_on = [buddy_pipeline_execution.e1, buddy_pipeline_execution.e2]
+}
+`, domain, projectName, pipelineName, comment1, comment2)
+}
@@ -12,8 +12,8 @@ import (
 )
 
 func TestAccSourcePipeline(t *testing.T) {
-	domain := util.UniqueString()
-	projectName := util.UniqueString()
+	domain := acc.UniqueString()
+	projectName := acc.UniqueString()
 	name := util.RandString(10)
 	ref := util.RandString(10)
 	reason := util.RandString(10)
@@ -24,8 +24,8 @@ func TestAccSourcePipeline(t *testing.T) {
 	newGitChangeSet := buddy.PipelineGitChangeSetBaseLatestRunMatchingRef
 	newFilesystemChangeSet := buddy.PipelineFilesystemChangeSetBaseDateModified
 	cpu := buddy.PipelineCpuArm
-	loop := util.UniqueString()
-	newLoop := util.UniqueString()
+	loop := acc.UniqueString()
+	newLoop := acc.UniqueString()
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
 			acc.PreCheck(t)
@@ -0,0 +1,71 @@
+package test
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"regexp"
+	"terraform-provider-buddy/buddy/acc"
+	"terraform-provider-buddy/buddy/util"
+	"testing"
+)
+
+func TestAccSourcePipelineYaml(t *testing.T) {
+	domain := acc.UniqueString()
+	projectName := acc.UniqueString()
+	pipelineName := util.RandString(10)
+	actionName := util.RandString(10)
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acc.PreCheck(t)
+		},
+		CheckDestroy:             acc.DummyCheckDestroy,
+		ProtoV6ProviderFactories: acc.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSourcePipelineYamlConfig(domain, projectName, pipelineName, actionName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.buddy_pipeline_yaml.yaml", "pipeline_id", "buddy_pipeline.pip", "pipeline_id"),
+					resource.TestMatchResourceAttr("data.buddy_pipeline_yaml.yaml", "yaml", regexp.MustCompile(fmt.Sprintf("(?m)^  pipeline: %s$", pipelineName))),
+					resource.TestMatchResourceAttr("data.buddy_pipeline_yaml.yaml", "yaml", regexp.MustCompile(fmt.Sprintf("(?m)^    - action: %s$", actionName))),
+				),
+			},
+		},
+	})
+}
+
+func testAccSourcePipelineYamlConfig(domain string, projectName string, pipelineName string, actionName string) string {
+	return fmt.Sprintf(`
+resource "buddy_workspace" "foo" {
+   domain = "%s"
+}
+
+resource "buddy_project" "proj" {
+   domain = "${buddy_workspace.foo.domain}"
+   display_name = "%s"
+}
+
+resource "buddy_pipeline" "pip" {
+   domain = "${buddy_workspace.foo.domain}"
+   project_name = "${buddy_project.proj.name}"
+   name = "%s"
+}
+
+resource "buddy_pipeline_action" "a1" {
+   domain = "${buddy_workspace.foo.domain}"
+   project_name = "${buddy_project.proj.name}"
+   pipeline_id = "${buddy_pipeline.pip.pipeline_id}"
+   name = "%s"
+   type = "BUILD"
+   docker_image_name = "library/ubuntu"
+   docker_image_tag = "22.04"
+   execute_commands = ["ls"]
+}
+
+data "buddy_pipeline_yaml" "yaml" {
+   domain = "${buddy_workspace.foo.domain}"
+   project_name = "${buddy_project.proj.name}"
+   pipeline_id = "${buddy_pipeline.pip.pipeline_id}"
+   depends_on = [buddy_pipeline_action.a1]
+}
+`, domain, projectName, pipelineName, actionName)
+}
@@ -12,12 +12,12 @@ import (
 )
 
 func TestAccSourcePipelines(t *testing.T) {
-	domain := util.UniqueString()
-	projectName := util.UniqueString()
+	domain := acc.UniqueString()
+	projectName := acc.UniqueString()
 	name1 := "aaaa" + util.RandString(10)
 	name2 := util.RandString(10)
 	ref := util.RandString(10)
-	loop := util.UniqueString()
+	loop := acc.UniqueString()
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
 			acc.PreCheck(t)
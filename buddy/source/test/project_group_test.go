@@ -12,9 +12,9 @@ import (
 )
 
 func TestAccSourceProjectGroup(t *testing.T) {
-	domain := util.UniqueString()
+	domain := acc.UniqueString()
 	groupName := util.RandString(10)
-	projectName := util.UniqueString()
+	projectName := acc.UniqueString()
 	permissionName := util.RandString(10)
 	pipelineAccessLevel := buddy.PermissionAccessLevelRunOnly
 	repoAccessLevel := buddy.PermissionAccessLevelReadWrite
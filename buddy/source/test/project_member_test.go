@@ -12,9 +12,9 @@ import (
 )
 
 func TestAccSourceProjectMember(t *testing.T) {
-	domain := util.UniqueString()
+	domain := acc.UniqueString()
 	memberEmail := util.RandEmail()
-	projectName := util.UniqueString()
+	projectName := acc.UniqueString()
 	permissionName := util.RandString(10)
 	pipelineAccessLevel := buddy.PermissionAccessLevelRunOnly
 	repoAccessLevel := buddy.PermissionAccessLevelReadWrite
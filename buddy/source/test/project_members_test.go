@@ -11,8 +11,8 @@ import (
 )
 
 func TestAccSourceProjectMembers(t *testing.T) {
-	domain := util.UniqueString()
-	projectName := util.UniqueString()
+	domain := acc.UniqueString()
+	projectName := acc.UniqueString()
 	email1 := util.RandEmail()
 	email2 := util.RandEmail()
 	resource.Test(t, resource.TestCase{
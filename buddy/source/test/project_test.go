@@ -10,8 +10,8 @@ import (
 )
 
 func TestAccSourceProject(t *testing.T) {
-	domain := util.UniqueString()
-	name := util.UniqueString()
+	domain := acc.UniqueString()
+	name := acc.UniqueString()
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
 			acc.PreCheck(t)
@@ -11,10 +11,10 @@ import (
 )
 
 func TestAccSourceProjects(t *testing.T) {
-	domain := util.UniqueString()
-	name1 := "aaa" + util.UniqueString()
-	name2 := util.UniqueString() + "bbb"
-	name3 := util.UniqueString()
+	domain := acc.UniqueString()
+	name1 := "aaa" + acc.UniqueString()
+	name2 := acc.UniqueString() + "bbb"
+	name3 := acc.UniqueString()
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
 			acc.PreCheck(t)
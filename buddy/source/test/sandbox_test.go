@@ -10,8 +10,8 @@ import (
 )
 
 func TestAccSourceSandbox(t *testing.T) {
-	domain := util.UniqueString()
-	projectName := util.UniqueString()
+	domain := acc.UniqueString()
+	projectName := acc.UniqueString()
 	name := util.RandString(10)
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
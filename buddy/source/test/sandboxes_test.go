@@ -11,8 +11,8 @@ import (
 )
 
 func TestAccSourceSandboxes(t *testing.T) {
-	domain := util.UniqueString()
-	projectName := util.UniqueString()
+	domain := acc.UniqueString()
+	projectName := acc.UniqueString()
 	name1 := "aaaa" + util.RandString(10)
 	name2 := util.RandString(10)
 	resource.Test(t, resource.TestCase{
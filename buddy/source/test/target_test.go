@@ -11,9 +11,9 @@ import (
 )
 
 func TestAccSourceTarget(t *testing.T) {
-	domain := util.UniqueString()
+	domain := acc.UniqueString()
 	name := util.RandString(10)
-	identifier := util.UniqueString()
+	identifier := acc.UniqueString()
 	host := "1.1.1.1"
 	port := "22"
 	path := "/"
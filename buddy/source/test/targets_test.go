@@ -11,11 +11,11 @@ import (
 )
 
 func TestAccSourceTargets_domain(t *testing.T) {
-	domain := util.UniqueString()
+	domain := acc.UniqueString()
 	name1 := "aaa" + util.RandString(10)
-	identifier1 := util.UniqueString()
+	identifier1 := acc.UniqueString()
 	name2 := util.RandString(10)
-	identifier2 := util.UniqueString()
+	identifier2 := acc.UniqueString()
 
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
@@ -36,11 +36,11 @@ func TestAccSourceTargets_domain(t *testing.T) {
 }
 
 func TestAccSourceTargets_project(t *testing.T) {
-	domain := util.UniqueString()
+	domain := acc.UniqueString()
 	name1 := "aaa" + util.RandString(10)
-	identifier1 := util.UniqueString()
+	identifier1 := acc.UniqueString()
 	name2 := util.RandString(10)
-	identifier2 := util.UniqueString()
+	identifier2 := acc.UniqueString()
 	projectName := util.RandString(10)
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
@@ -61,11 +61,11 @@ func TestAccSourceTargets_project(t *testing.T) {
 }
 
 func TestAccSourceTargets_pipeline(t *testing.T) {
-	domain := util.UniqueString()
+	domain := acc.UniqueString()
 	name1 := "aaa" + util.RandString(10)
-	identifier1 := util.UniqueString()
+	identifier1 := acc.UniqueString()
 	name2 := util.RandString(10)
-	identifier2 := util.UniqueString()
+	identifier2 := acc.UniqueString()
 	projectName := util.RandString(10)
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
@@ -282,14 +282,14 @@ data "buddy_targets" "name" {
 
 //
 //func TestAccSourceTargets_byProject(t *testing.T) {
-//	domain := util.UniqueString()
-//	projectName := util.UniqueString()
+//	domain := acc.UniqueString()
+//	projectName := acc.UniqueString()
 //	name1 := util.RandString(10)
-//	identifier1 := util.UniqueString()
+//	identifier1 := acc.UniqueString()
 //	name2 := util.RandString(10)
-//	identifier2 := util.UniqueString()
+//	identifier2 := acc.UniqueString()
 //	name3 := util.RandString(10)
-//	identifier3 := util.UniqueString()
+//	identifier3 := acc.UniqueString()
 //	host := "1.1.1.1"
 //	port := "44"
 //	path := util.RandString(10)
@@ -315,13 +315,13 @@ data "buddy_targets" "name" {
 //}
 //
 //func TestAccSourceTargets_byPipeline(t *testing.T) {
-//	domain := util.UniqueString()
-//	projectName := util.UniqueString()
-//	pipelineName := util.UniqueString()
+//	domain := acc.UniqueString()
+//	projectName := acc.UniqueString()
+//	pipelineName := acc.UniqueString()
 //	name1 := util.RandString(10)
-//	identifier1 := util.UniqueString()
+//	identifier1 := acc.UniqueString()
 //	name2 := util.RandString(10)
-//	identifier2 := util.UniqueString()
+//	identifier2 := acc.UniqueString()
 //	host := "1.1.1.1"
 //	port := "44"
 //	path := util.RandString(10)
@@ -344,14 +344,14 @@ data "buddy_targets" "name" {
 //}
 //
 //func TestAccSourceTargets_byEnvironment(t *testing.T) {
-//	domain := util.UniqueString()
-//	projectName := util.UniqueString()
-//	envName := util.UniqueString()
-//	envId := util.UniqueString()
+//	domain := acc.UniqueString()
+//	projectName := acc.UniqueString()
+//	envName := acc.UniqueString()
+//	envId := acc.UniqueString()
 //	name1 := util.RandString(10)
-//	identifier1 := util.UniqueString()
+//	identifier1 := acc.UniqueString()
 //	name2 := util.RandString(10)
-//	identifier2 := util.UniqueString()
+//	identifier2 := acc.UniqueString()
 //	host := "1.1.1.1"
 //	port := "44"
 //	path := util.RandString(10)
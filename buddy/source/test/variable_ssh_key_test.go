@@ -12,7 +12,7 @@ import (
 )
 
 func TestAccSourceVariableSshKey(t *testing.T) {
-	domain := util.UniqueString()
+	domain := acc.UniqueString()
 	key := util.RandString(10)
 	desc := util.RandString(10)
 	filePlace := buddy.VariableSshKeyFilePlaceContainer
@@ -11,7 +11,7 @@ import (
 )
 
 func TestAccSourceVariable(t *testing.T) {
-	domain := util.UniqueString()
+	domain := acc.UniqueString()
 	key := util.RandString(10)
 	val := util.RandString(10)
 	desc := util.RandString(10)
@@ -185,5 +185,5 @@ data "buddy_variables_ssh_keys" "env" {
    key_regex = "^te"
 }
 
-`, util.UniqueString(), privateKey, privateKey2, privateKey, privateKey)
+`, acc.UniqueString(), privateKey, privateKey2, privateKey, privateKey)
 }
@@ -146,5 +146,5 @@ data "buddy_variables" "env" {
    environment_id = "${buddy_environment.e.environment_id}"
    key_regex = "^te"
 }
-`, util.UniqueString())
+`, acc.UniqueString())
 }
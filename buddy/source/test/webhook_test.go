@@ -12,9 +12,9 @@ import (
 )
 
 func TestAccSourceWebhook(t *testing.T) {
-	domain := util.UniqueString()
+	domain := acc.UniqueString()
 	event := buddy.WebhookEventPush
-	projectName := util.UniqueString()
+	projectName := acc.UniqueString()
 	targetUrl := "https://127.0.0.1"
 	secretKey := util.RandString(10)
 	resource.Test(t, resource.TestCase{
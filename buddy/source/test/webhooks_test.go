@@ -11,7 +11,7 @@ import (
 )
 
 func TestAccSourceWebhooks(t *testing.T) {
-	domain := util.UniqueString()
+	domain := acc.UniqueString()
 	target1 := "https://127.0.0.1"
 	target2 := "https://192.168.1.1"
 	resource.Test(t, resource.TestCase{
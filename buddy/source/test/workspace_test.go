@@ -11,7 +11,7 @@ import (
 )
 
 func TestAccSourceWorkspace(t *testing.T) {
-	domain := util.UniqueString()
+	domain := acc.UniqueString()
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
 			acc.PreCheck(t)
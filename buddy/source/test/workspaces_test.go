@@ -11,9 +11,9 @@ import (
 )
 
 func TestAccSourceWorkspaces(t *testing.T) {
-	domain1 := util.UniqueString() + "aaa"
-	domain2 := "bbb" + util.UniqueString()
-	domain3 := util.UniqueString()
+	domain1 := acc.UniqueString() + "aaa"
+	domain2 := "bbb" + acc.UniqueString()
+	domain3 := acc.UniqueString()
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
 			acc.PreCheck(t)
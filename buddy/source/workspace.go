@@ -50,7 +50,7 @@ func (s *workspaceSource) Configure(_ context.Context, req datasource.ConfigureR
 	if req.ProviderData == nil {
 		return
 	}
-	s.client = req.ProviderData.(*buddy.Client)
+	s.client = req.ProviderData.(*util.ProviderData).Client
 }
 
 func (s *workspaceSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
@@ -47,7 +47,7 @@ func (s *workspacesSource) Configure(_ context.Context, req datasource.Configure
 	if req.ProviderData == nil {
 		return
 	}
-	s.client = req.ProviderData.(*buddy.Client)
+	s.client = req.ProviderData.(*util.ProviderData).Client
 }
 
 func (s *workspacesSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
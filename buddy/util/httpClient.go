@@ -0,0 +1,83 @@
+package util
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+type HttpClientConfig struct {
+	Token                 string
+	Timeout               time.Duration
+	Tls                   *TlsConfig
+	Proxy                 *ProxyConfig
+	MaxRetries            int
+	RetryMaxWait          time.Duration
+	RequestsPerSecond     float64
+	MaxConcurrentRequests int
+	// WrapTransport wraps the base transport (e.g. to record and replay acceptance tests)
+	WrapTransport func(http.RoundTripper) http.RoundTripper
+}
+
+type timeoutTransport struct {
+	next    http.RoundTripper
+	timeout time.Duration
+}
+
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// timeout is applied per attempt so retries are not cut by the client timeout
+func (t *timeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.timeout <= 0 {
+		return t.next.RoundTrip(req)
+	}
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+	resp, err := t.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return resp, err
+	}
+	resp.Body = &cancelBody{
+		ReadCloser: resp.Body,
+		cancel:     cancel,
+	}
+	return resp, nil
+}
+
+func NewHttpClient(ctx context.Context, cfg *HttpClientConfig) (*http.Client, error) {
+	tlsConfig, err := NewTlsConfig(cfg.Tls)
+	if err != nil {
+		return nil, err
+	}
+	proxy, err := NewProxyFunc(cfg.Proxy)
+	if err != nil {
+		return nil, err
+	}
+	base := http.DefaultTransport.(*http.Transport).Clone()
+	base.TLSClientConfig = tlsConfig
+	base.Proxy = proxy
+	var transport http.RoundTripper = base
+	if cfg.WrapTransport != nil {
+		transport = cfg.WrapTransport(transport)
+	}
+	transport = &timeoutTransport{
+		next:    transport,
+		timeout: cfg.Timeout,
+	}
+	transport = NewLogTransport(ctx, transport, cfg.Token)
+	transport = NewRateLimitTransport(transport, cfg.RequestsPerSecond, cfg.MaxConcurrentRequests)
+	transport = NewRetryTransport(transport, cfg.MaxRetries, cfg.RetryMaxWait)
+	return &http.Client{
+		Transport: transport,
+	}, nil
+}
@@ -0,0 +1,238 @@
+package util
+
+import (
+	"context"
+	"fmt"
+	"github.com/buddy/api-go-sdk/buddy"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"maps"
+	"slices"
+	"strings"
+)
+
+type integrationFields struct {
+	// at least one attribute of every group must be set
+	required [][]string
+	// optional attributes
+	allowed []string
+}
+
+// attributes which depend on the integration's type
+var integrationTypeAttributes = []string{
+	"username",
+	"shop",
+	"token",
+	"partner_token",
+	"access_key",
+	"secret_key",
+	"audience",
+	"google_config",
+	"google_project",
+	"app_id",
+	"tenant_id",
+	"password",
+	"api_key",
+	"email",
+	"role_assumption",
+}
+
+// secrets which can be provided with write-only attributes instead
+var integrationWriteOnlyAttributes = map[string]string{
+	"token":         "token_wo",
+	"partner_token": "partner_token_wo",
+	"access_key":    "access_key_wo",
+	"secret_key":    "secret_key_wo",
+	"password":      "password_wo",
+	"api_key":       "api_key_wo",
+}
+
+var integrationTokenFields = &integrationFields{
+	required: [][]string{{"token"}},
+}
+
+// rules per integration's type, types with auth_type are keyed with `TYPE:AUTH_TYPE`
+var integrationRules = map[string]*integrationFields{
+	buddy.IntegrationTypeDigitalOcean: integrationTokenFields,
+	buddy.IntegrationTypeAmazon + ":" + buddy.IntegrationAuthTypeDefault: {
+		required: [][]string{{"access_key"}, {"secret_key"}},
+		allowed:  []string{"role_assumption"},
+	},
+	buddy.IntegrationTypeAmazon + ":" + buddy.IntegrationAuthTypeTrusted: {
+		required: [][]string{{"role_assumption"}},
+	},
+	buddy.IntegrationTypeAmazon + ":" + buddy.IntegrationAuthTypeOidc: {
+		required: [][]string{{"role_assumption"}},
+		allowed:  []string{"audience"},
+	},
+	buddy.IntegrationTypeShopify: {
+		required: [][]string{{"token"}, {"shop", "partner_token"}},
+	},
+	buddy.IntegrationTypePushover: {
+		required: [][]string{{"token"}, {"access_key"}},
+	},
+	buddy.IntegrationTypeRackspace: {
+		required: [][]string{{"username"}, {"token"}},
+	},
+	buddy.IntegrationTypeCloudflare: {
+		required: [][]string{{"token", "api_key"}},
+		allowed:  []string{"email"},
+	},
+	buddy.IntegrationTypeNewRelic:         integrationTokenFields,
+	buddy.IntegrationTypeSentry:           integrationTokenFields,
+	buddy.IntegrationTypeRollbar:          integrationTokenFields,
+	buddy.IntegrationTypeDatadog:          integrationTokenFields,
+	buddy.IntegrationTypeHoneybadger:      integrationTokenFields,
+	buddy.IntegrationTypeVultr:            integrationTokenFields,
+	buddy.IntegrationTypeSentryEnterprise: integrationTokenFields,
+	buddy.IntegrationTypeLoggly:           integrationTokenFields,
+	buddy.IntegrationTypeFirebase:         integrationTokenFields,
+	buddy.IntegrationTypeGhostInspector:   integrationTokenFields,
+	buddy.IntegrationTypeGitHub:           integrationTokenFields,
+	buddy.IntegrationTypeGitLab:           integrationTokenFields,
+	buddy.IntegrationTypeDigitalOceanSpaces: {
+		required: [][]string{{"access_key"}, {"secret_key"}},
+	},
+	buddy.IntegrationTypeUpcloud: {
+		required: [][]string{{"username"}, {"password"}},
+	},
+	buddy.IntegrationTypeDockerHub: {
+		required: [][]string{{"username"}, {"password"}},
+	},
+	buddy.IntegrationTypeAzureCloud + ":" + buddy.IntegrationAuthTypeDefault: {
+		required: [][]string{{"app_id"}, {"tenant_id"}, {"password"}},
+	},
+	buddy.IntegrationTypeAzureCloud + ":" + buddy.IntegrationAuthTypeOidc: {
+		required: [][]string{{"app_id"}, {"tenant_id"}},
+		allowed:  []string{"audience"},
+	},
+	buddy.IntegrationTypeGoogleServiceAccount + ":" + buddy.IntegrationAuthTypeDefault: {
+		required: [][]string{{"api_key"}},
+	},
+	buddy.IntegrationTypeGoogleServiceAccount + ":" + buddy.IntegrationAuthTypeOidc: {
+		required: [][]string{{"google_config"}, {"google_project"}},
+		allowed:  []string{"audience"},
+	},
+	buddy.IntegrationTypeStackHawk: {
+		required: [][]string{{"api_key"}},
+	},
+}
+
+// types which support auth_type
+var integrationAuthTypes = map[string][]string{
+	buddy.IntegrationTypeAmazon:               {buddy.IntegrationAuthTypeDefault, buddy.IntegrationAuthTypeTrusted, buddy.IntegrationAuthTypeOidc},
+	buddy.IntegrationTypeAzureCloud:           {buddy.IntegrationAuthTypeDefault, buddy.IntegrationAuthTypeOidc},
+	buddy.IntegrationTypeGoogleServiceAccount: {buddy.IntegrationAuthTypeDefault, buddy.IntegrationAuthTypeOidc},
+}
+
+// ValidateIntegrationConfig checks which attributes are required or forbidden by the integration's type, auth type and scope
+func ValidateIntegrationConfig(ctx context.Context, config *tfsdk.Config) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var typ, authType, scope types.String
+	diags.Append(config.GetAttribute(ctx, path.Root("type"), &typ)...)
+	diags.Append(config.GetAttribute(ctx, path.Root("auth_type"), &authType)...)
+	diags.Append(config.GetAttribute(ctx, path.Root("scope"), &scope)...)
+	if diags.HasError() {
+		return diags
+	}
+	if !scope.IsNull() && !scope.IsUnknown() {
+		projectSet, d := isConfigAttributeSet(ctx, config, "project_name")
+		diags.Append(d...)
+		if scope.ValueString() == buddy.IntegrationScopeProject && !projectSet {
+			diags.AddAttributeError(path.Root("project_name"), "Missing required attribute", fmt.Sprintf("`project_name` is required for the `%s` scope", buddy.IntegrationScopeProject))
+		} else if scope.ValueString() == buddy.IntegrationScopeWorkspace && projectSet {
+			diags.AddAttributeError(path.Root("project_name"), "Invalid attribute combination", fmt.Sprintf("`project_name` is not supported for the `%s` scope", buddy.IntegrationScopeWorkspace))
+		}
+	}
+	diags.Append(validateWriteOnlyVersion(ctx, config, "secrets_wo_version", slices.Sorted(maps.Values(integrationWriteOnlyAttributes)))...)
+	if typ.IsNull() || typ.IsUnknown() || authType.IsUnknown() {
+		return diags
+	}
+	key := typ.ValueString()
+	description := fmt.Sprintf("`%s` integration", key)
+	if authTypes, ok := integrationAuthTypes[key]; ok {
+		auth := buddy.IntegrationAuthTypeDefault
+		if !authType.IsNull() {
+			auth = authType.ValueString()
+		}
+		if !slices.Contains(authTypes, auth) {
+			diags.AddAttributeError(path.Root("auth_type"), "Invalid attribute value", fmt.Sprintf("%s supports auth types: `%s`", description, strings.Join(authTypes, "`, `")))
+			return diags
+		}
+		key += ":" + auth
+		description += fmt.Sprintf(" with `%s` auth type", auth)
+	} else if !authType.IsNull() {
+		diags.AddAttributeError(path.Root("auth_type"), "Invalid attribute combination", fmt.Sprintf("`auth_type` is not supported by %s", description))
+	}
+	rules, ok := integrationRules[key]
+	if !ok {
+		return diags
+	}
+	set := map[string]bool{}
+	for _, name := range integrationTypeAttributes {
+		isSet, d := isConfigAttributeSet(ctx, config, name)
+		diags.Append(d...)
+		if wo, ok := integrationWriteOnlyAttributes[name]; ok && !isSet {
+			isSet, d = isConfigAttributeSet(ctx, config, wo)
+			diags.Append(d...)
+		}
+		set[name] = isSet
+	}
+	allowed := map[string]bool{}
+	for _, name := range rules.allowed {
+		allowed[name] = true
+	}
+	for _, group := range rules.required {
+		found := false
+		for _, name := range group {
+			allowed[name] = true
+			found = found || set[name]
+		}
+		if found {
+			continue
+		}
+		if len(group) == 1 {
+			diags.AddAttributeError(path.Root(group[0]), "Missing required attribute", fmt.Sprintf("`%s` is required by %s", group[0], description))
+		} else {
+			diags.AddAttributeError(path.Root(group[0]), "Missing required attribute", fmt.Sprintf("One of `%s` is required by %s", strings.Join(group, "`, `"), description))
+		}
+	}
+	for _, name := range integrationTypeAttributes {
+		if set[name] && !allowed[name] {
+			diags.AddAttributeError(path.Root(name), "Invalid attribute combination", fmt.Sprintf("`%s` is not supported by %s", name, description))
+		}
+	}
+	return diags
+}
+
+// validateWriteOnlyVersion checks that at least one of the write-only attributes is set along with its version
+func validateWriteOnlyVersion(ctx context.Context, config *tfsdk.Config, version string, writeOnly []string) diag.Diagnostics {
+	var v types.Int64
+	diags := config.GetAttribute(ctx, path.Root(version), &v)
+	if diags.HasError() || v.IsNull() {
+		return diags
+	}
+	for _, name := range writeOnly {
+		isSet, d := isConfigAttributeSet(ctx, config, name)
+		diags.Append(d...)
+		if isSet {
+			return diags
+		}
+	}
+	diags.AddAttributeError(path.Root(version), "Missing required attribute", fmt.Sprintf("One of `%s` is required along with `%s`", strings.Join(writeOnly, "`, `"), version))
+	return diags
+}
+
+// isConfigAttributeSet returns true if the attribute is set in the config (unknown values are treated as set)
+func isConfigAttributeSet(ctx context.Context, config *tfsdk.Config, name string) (bool, diag.Diagnostics) {
+	if name == "role_assumption" {
+		var v types.List
+		diags := config.GetAttribute(ctx, path.Root(name), &v)
+		return !v.IsNull() && (v.IsUnknown() || len(v.Elements()) > 0), diags
+	}
+	var v types.String
+	diags := config.GetAttribute(ctx, path.Root(name), &v)
+	return !v.IsNull(), diags
+}
@@ -0,0 +1,208 @@
+package util
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	LogSubsystem = "buddy"
+	logRedacted  = "***"
+)
+
+var logSensitiveHeaders = []string{
+	"Authorization",
+	"Cookie",
+	"Proxy-Authorization",
+	"Set-Cookie",
+	"X-Buddy-Token",
+}
+
+// fields of request and response bodies which are redacted in logs and recorded test fixtures
+var sensitiveBodyFields = map[string]bool{
+	"value":           true,
+	"value_processed": true,
+	"password":        true,
+	"secret_key":      true,
+	"api_key":         true,
+	"access_key":      true,
+	"partner_token":   true,
+	"token":           true,
+	"access_token":    true,
+	"subject_token":   true,
+	"client_secret":   true,
+	"certificate":     true,
+	"passphrase":      true,
+	"private_key":     true,
+}
+
+// fields which are sensitive only inside of target auth object
+var sensitiveAuthFields = map[string]bool{
+	"key": true,
+}
+
+// IsSensitiveField returns true if the body field (child of the parent field) holds a secret
+func IsSensitiveField(parent string, field string) bool {
+	return sensitiveBodyFields[field] || (parent == "auth" && sensitiveAuthFields[field])
+}
+
+var logPathFields = map[string]string{
+	"workspaces":   "domain",
+	"projects":     "project_name",
+	"pipelines":    "pipeline_id",
+	"actions":      "action_id",
+	"executions":   "execution_id",
+	"sandboxes":    "sandbox_id",
+	"targets":      "target_id",
+	"environments": "environment_id",
+	"integrations": "integration_id",
+	"variables":    "variable_id",
+}
+
+// logTransport logs with the provider context given to NewLogTransport because the sdk does not
+// propagate request contexts, resource identifiers are taken from the request path instead
+type logTransport struct {
+	next http.RoundTripper
+	ctx  context.Context
+}
+
+func NewLogTransport(ctx context.Context, next http.RoundTripper, token string) http.RoundTripper {
+	ctx = tflog.NewSubsystem(ctx, LogSubsystem)
+	if token != "" {
+		ctx = tflog.SubsystemMaskAllFieldValuesStrings(ctx, LogSubsystem, token)
+		ctx = tflog.SubsystemMaskMessageStrings(ctx, LogSubsystem, token)
+	}
+	return &logTransport{
+		next: next,
+		ctx:  ctx,
+	}
+}
+
+func (t *logTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	fields := map[string]interface{}{
+		"http_method": req.Method,
+		"http_path":   req.URL.Path,
+	}
+	for k, v := range logFieldsFromPath(req.URL.Path) {
+		fields[k] = v
+	}
+	ctx := t.ctx
+	for k, v := range fields {
+		ctx = tflog.SubsystemSetField(ctx, LogSubsystem, k, v)
+	}
+	reqFields := map[string]interface{}{
+		"http_request_headers": logRedactHeaders(req.Header),
+	}
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		reqFields["http_request_body"] = logRedactBody(body)
+	}
+	tflog.SubsystemDebug(ctx, LogSubsystem, "Sending Buddy API request")
+	tflog.SubsystemTrace(ctx, LogSubsystem, "Buddy API request details", reqFields)
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	ctx = tflog.SubsystemSetField(ctx, LogSubsystem, "http_duration_ms", time.Since(start).Milliseconds())
+	if err != nil {
+		tflog.SubsystemError(ctx, LogSubsystem, "Buddy API request failed", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return resp, err
+	}
+	ctx = tflog.SubsystemSetField(ctx, LogSubsystem, "http_status_code", resp.StatusCode)
+	if requestId := resp.Header.Get("X-Request-Id"); requestId != "" {
+		ctx = tflog.SubsystemSetField(ctx, LogSubsystem, "http_request_id", requestId)
+	}
+	respFields := map[string]interface{}{
+		"http_response_headers": logRedactHeaders(resp.Header),
+	}
+	if resp.Body != nil && resp.Body != http.NoBody {
+		body, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		respFields["http_response_body"] = logRedactBody(body)
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		tflog.SubsystemWarn(ctx, LogSubsystem, "Received Buddy API error response")
+	} else {
+		tflog.SubsystemDebug(ctx, LogSubsystem, "Received Buddy API response")
+	}
+	tflog.SubsystemTrace(ctx, LogSubsystem, "Buddy API response details", respFields)
+	return resp, nil
+}
+
+func logFieldsFromPath(p string) map[string]string {
+	fields := map[string]string{}
+	segments := strings.Split(strings.Trim(p, "/"), "/")
+	for i := 0; i+1 < len(segments); i++ {
+		if field, ok := logPathFields[segments[i]]; ok {
+			fields[field] = segments[i+1]
+			i++
+		}
+	}
+	return fields
+}
+
+func logRedactHeaders(header http.Header) map[string]string {
+	result := map[string]string{}
+	for k := range header {
+		result[k] = header.Get(k)
+	}
+	for _, h := range logSensitiveHeaders {
+		if header.Get(h) != "" {
+			result[http.CanonicalHeaderKey(h)] = logRedacted
+		}
+	}
+	return result
+}
+
+func logRedactBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		// non json body is not logged as it can't be redacted
+		return logRedacted
+	}
+	b, err := json.Marshal(logRedactValue(v, ""))
+	if err != nil {
+		return logRedacted
+	}
+	return string(b)
+}
+
+func logRedactValue(v interface{}, parent string) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, field := range val {
+			if IsSensitiveField(parent, k) {
+				if field != nil && field != "" {
+					val[k] = logRedacted
+				}
+				continue
+			}
+			val[k] = logRedactValue(field, k)
+		}
+		return val
+	case []interface{}:
+		for i, item := range val {
+			val[i] = logRedactValue(item, parent)
+		}
+		return val
+	}
+	return v
+}
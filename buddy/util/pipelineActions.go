@@ -0,0 +1,211 @@
+package util
+
+import (
+	"context"
+	"github.com/buddy/api-go-sdk/buddy"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	sourceschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+type pipelineActionModel struct {
+	ActionId        types.Int64  `tfsdk:"action_id"`
+	Name            types.String `tfsdk:"name"`
+	Type            types.String `tfsdk:"type"`
+	HtmlUrl         types.String `tfsdk:"html_url"`
+	TriggerTime     types.String `tfsdk:"trigger_time"`
+	Disabled        types.Bool   `tfsdk:"disabled"`
+	Shell           types.String `tfsdk:"shell"`
+	DockerImageName types.String `tfsdk:"docker_image_name"`
+	DockerImageTag  types.String `tfsdk:"docker_image_tag"`
+	ExecuteCommands types.List   `tfsdk:"execute_commands"`
+}
+
+func pipelineActionModelAttrs() map[string]attr.Type {
+	return map[string]attr.Type{
+		"action_id":         types.Int64Type,
+		"name":              types.StringType,
+		"type":              types.StringType,
+		"html_url":          types.StringType,
+		"trigger_time":      types.StringType,
+		"disabled":          types.BoolType,
+		"shell":             types.StringType,
+		"docker_image_name": types.StringType,
+		"docker_image_tag":  types.StringType,
+		"execute_commands":  types.ListType{ElemType: types.StringType},
+	}
+}
+
+func (a *pipelineActionModel) loadAPI(ctx context.Context, action *buddy.PipelineAction) diag.Diagnostics {
+	a.ActionId = types.Int64Value(int64(action.Id))
+	a.Name = types.StringValue(action.Name)
+	a.Type = types.StringValue(action.Type)
+	a.HtmlUrl = types.StringValue(action.HtmlUrl)
+	a.TriggerTime = types.StringValue(action.TriggerTime)
+	a.Disabled = types.BoolValue(action.Disabled)
+	a.Shell = types.StringValue(action.Shell)
+	a.DockerImageName = types.StringValue(action.DockerImageName)
+	a.DockerImageTag = types.StringValue(action.DockerImageTag)
+	ec, d := types.ListValueFrom(ctx, types.StringType, &action.ExecuteCommands)
+	a.ExecuteCommands = ec
+	return d
+}
+
+func SourcePipelineActionModelAttributes() map[string]sourceschema.Attribute {
+	return map[string]sourceschema.Attribute{
+		"action_id": sourceschema.Int64Attribute{
+			Computed: true,
+		},
+		"name": sourceschema.StringAttribute{
+			Computed: true,
+		},
+		"type": sourceschema.StringAttribute{
+			Computed: true,
+		},
+		"html_url": sourceschema.StringAttribute{
+			Computed: true,
+		},
+		"trigger_time": sourceschema.StringAttribute{
+			Computed: true,
+		},
+		"disabled": sourceschema.BoolAttribute{
+			Computed: true,
+		},
+		"shell": sourceschema.StringAttribute{
+			Computed: true,
+		},
+		"docker_image_name": sourceschema.StringAttribute{
+			Computed: true,
+		},
+		"docker_image_tag": sourceschema.StringAttribute{
+			Computed: true,
+		},
+		"execute_commands": sourceschema.ListAttribute{
+			Computed:    true,
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func PipelineActionsModelFromApi(ctx context.Context, actions *[]*buddy.PipelineAction) (basetypes.ListValue, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	a := make([]*pipelineActionModel, len(*actions))
+	for i, v := range *actions {
+		a[i] = &pipelineActionModel{}
+		diags.Append(a[i].loadAPI(ctx, v)...)
+	}
+	r, d := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: pipelineActionModelAttrs()}, &a)
+	diags.Append(d...)
+	return r, diags
+}
+
+type pipelineActionVariableModel struct {
+	Key         types.String `tfsdk:"key"`
+	Value       types.String `tfsdk:"value"`
+	Encrypted   types.Bool   `tfsdk:"encrypted"`
+	Settable    types.Bool   `tfsdk:"settable"`
+	Description types.String `tfsdk:"description"`
+}
+
+func ResourcePipelineActionVariableModelAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"key": schema.StringAttribute{
+			Required: true,
+		},
+		"value": schema.StringAttribute{
+			Required:  true,
+			Sensitive: true,
+		},
+		"encrypted": schema.BoolAttribute{
+			Optional: true,
+		},
+		"settable": schema.BoolAttribute{
+			Optional: true,
+		},
+		"description": schema.StringAttribute{
+			Optional: true,
+		},
+	}
+}
+
+func pipelineActionVariableModelAttrs() map[string]attr.Type {
+	return map[string]attr.Type{
+		"key":         types.StringType,
+		"value":       types.StringType,
+		"encrypted":   types.BoolType,
+		"settable":    types.BoolType,
+		"description": types.StringType,
+	}
+}
+
+// PipelineActionVariablesModelFromApi keeps configured values of encrypted variables (API returns them encrypted)
+// and null optional attributes which the API returns as defaults
+func PipelineActionVariablesModelFromApi(ctx context.Context, current *types.Set, variables []*buddy.Variable) (basetypes.SetValue, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if len(variables) == 0 && current.IsNull() {
+		return types.SetNull(types.ObjectType{AttrTypes: pipelineActionVariableModelAttrs()}), diags
+	}
+	byKey := map[string]pipelineActionVariableModel{}
+	if !current.IsNull() && !current.IsUnknown() {
+		var vm []pipelineActionVariableModel
+		diags.Append(current.ElementsAs(ctx, &vm, false)...)
+		for _, v := range vm {
+			byKey[v.Key.ValueString()] = v
+		}
+	}
+	r := make([]*pipelineActionVariableModel, len(variables))
+	for i, v := range variables {
+		c, ok := byKey[v.Key]
+		m := &pipelineActionVariableModel{
+			Key:         types.StringValue(v.Key),
+			Value:       types.StringValue(v.Value),
+			Encrypted:   types.BoolValue(v.Encrypted),
+			Settable:    types.BoolValue(v.Settable),
+			Description: stringValueOrNull(v.Description),
+		}
+		if v.Encrypted && ok {
+			m.Value = c.Value
+		}
+		if !v.Encrypted && (!ok || c.Encrypted.IsNull()) {
+			m.Encrypted = types.BoolNull()
+		}
+		if !v.Settable && (!ok || c.Settable.IsNull()) {
+			m.Settable = types.BoolNull()
+		}
+		r[i] = m
+	}
+	result, d := types.SetValueFrom(ctx, types.ObjectType{AttrTypes: pipelineActionVariableModelAttrs()}, &r)
+	diags.Append(d...)
+	return result, diags
+}
+
+func PipelineActionVariablesModelToApi(ctx context.Context, s *types.Set) (*[]*buddy.Variable, diag.Diagnostics) {
+	var vm []pipelineActionVariableModel
+	diags := s.ElementsAs(ctx, &vm, false)
+	variables := make([]*buddy.Variable, len(vm))
+	for i, v := range vm {
+		variable := &buddy.Variable{
+			Type: buddy.VariableTypeVar,
+		}
+		if !v.Key.IsNull() && !v.Key.IsUnknown() {
+			variable.Key = v.Key.ValueString()
+		}
+		if !v.Value.IsNull() && !v.Value.IsUnknown() {
+			variable.Value = v.Value.ValueString()
+		}
+		if !v.Encrypted.IsNull() && !v.Encrypted.IsUnknown() {
+			variable.Encrypted = v.Encrypted.ValueBool()
+		}
+		if !v.Settable.IsNull() && !v.Settable.IsUnknown() {
+			variable.Settable = v.Settable.ValueBool()
+		}
+		if !v.Description.IsNull() && !v.Description.IsUnknown() {
+			variable.Description = v.Description.ValueString()
+		}
+		variables[i] = variable
+	}
+	return &variables, diags
+}
@@ -0,0 +1,388 @@
+package util
+
+import (
+	"context"
+	"fmt"
+	"github.com/buddy/api-go-sdk/buddy"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata"
+)
+
+type pipelineEventRules struct {
+	// at least one attribute from every group is required
+	required [][]string
+	allowed  []string
+}
+
+// event attributes which depend on the event's type
+var pipelineEventAttributes = []string{
+	"refs",
+	"branches",
+	"events",
+	"start_date",
+	"delay",
+	"cron",
+	"totp",
+	"prefix",
+	"whitelist",
+	"timezone",
+}
+
+var pipelineEventRulesByType = map[string]*pipelineEventRules{
+	buddy.PipelineEventTypePush: {
+		required: [][]string{{"refs", "branches"}},
+	},
+	buddy.PipelineEventTypeCreateRef: {
+		allowed: []string{"refs"},
+	},
+	buddy.PipelineEventTypeDeleteRef: {
+		allowed: []string{"refs"},
+	},
+	buddy.PipelineEventTypePullRequest: {
+		allowed: []string{"branches", "events"},
+	},
+	buddy.PipelineEventTypeSchedule: {
+		allowed: []string{"start_date", "delay", "cron", "timezone"},
+	},
+	buddy.PipelineEventTypeWebhook: {
+		allowed: []string{"totp"},
+	},
+	buddy.PipelineEventTypeEmail: {
+		allowed: []string{"prefix", "whitelist"},
+	},
+}
+
+// trigger condition attributes which depend on the condition
+var pipelineTriggerConditionAttributes = []string{
+	"paths",
+	"variable_key",
+	"variable_value",
+	"hours",
+	"days",
+	"timezone",
+	"project_name",
+	"pipeline_name",
+	"trigger_user",
+	"trigger_group",
+}
+
+var pipelineTriggerConditionVarRules = []string{"variable_key", "variable_value"}
+
+var pipelineTriggerConditionRules = map[string][]string{
+	buddy.PipelineTriggerConditionOnChange:                   {},
+	buddy.PipelineTriggerConditionOnChangeAtPath:             {"paths"},
+	buddy.PipelineTriggerConditionVarIs:                      pipelineTriggerConditionVarRules,
+	buddy.PipelineTriggerConditionVarIsNot:                   pipelineTriggerConditionVarRules,
+	buddy.PipelineTriggerConditionVarContains:                pipelineTriggerConditionVarRules,
+	buddy.PipelineTriggerConditionVarNotContains:             pipelineTriggerConditionVarRules,
+	buddy.PipelineTriggerConditionDateTime:                   {"hours", "days"},
+	buddy.PipelineTriggerConditionSuccessPipeline:            {"project_name", "pipeline_name"},
+	buddy.PipelineTriggerConditionTriggeringUserIs:           {"trigger_user"},
+	buddy.PipelineTriggerConditionTriggeringUserIsNot:        {"trigger_user"},
+	buddy.PipelineTriggerConditionTriggeringUserIsInGroup:    {"trigger_group"},
+	buddy.PipelineTriggerConditionTriggeringUserIsNotInGroup: {"trigger_group"},
+}
+
+// ValidatePipelineConfig checks events and trigger conditions of the pipeline
+func ValidatePipelineConfig(ctx context.Context, config *tfsdk.Config) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var events types.Set
+	diags.Append(config.GetAttribute(ctx, path.Root("event"), &events)...)
+	if !diags.HasError() && !events.IsNull() && !events.IsUnknown() {
+		diags.Append(validatePipelineEvents(ctx, &events)...)
+	}
+	var conditions types.Set
+	diags.Append(config.GetAttribute(ctx, path.Root("trigger_condition"), &conditions)...)
+	if !diags.HasError() && !conditions.IsNull() && !conditions.IsUnknown() {
+		diags.Append(validatePipelineTriggerConditions(ctx, &conditions)...)
+	}
+	diags.Append(validatePipelineIgnoreStatusChanges(ctx, config)...)
+	return diags
+}
+
+// validatePipelineIgnoreStatusChanges rejects status fields when they are managed by buddy_pipeline_status
+func validatePipelineIgnoreStatusChanges(ctx context.Context, config *tfsdk.Config) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var ignore types.Bool
+	diags.Append(config.GetAttribute(ctx, path.Root("ignore_status_changes"), &ignore)...)
+	if diags.HasError() || !ignore.ValueBool() {
+		return diags
+	}
+	var paused, disabled types.Bool
+	var reason types.String
+	diags.Append(config.GetAttribute(ctx, path.Root("paused"), &paused)...)
+	diags.Append(config.GetAttribute(ctx, path.Root("disabled"), &disabled)...)
+	diags.Append(config.GetAttribute(ctx, path.Root("disabling_reason"), &reason)...)
+	if diags.HasError() {
+		return diags
+	}
+	values := map[string]attr.Value{
+		"paused":           paused,
+		"disabled":         disabled,
+		"disabling_reason": reason,
+	}
+	for _, name := range []string{"paused", "disabled", "disabling_reason"} {
+		if !values[name].IsNull() {
+			diags.AddAttributeError(path.Root(name), "Invalid attribute combination", fmt.Sprintf("`%s` can't be set when `ignore_status_changes` is true", name))
+		}
+	}
+	return diags
+}
+
+func validatePipelineEvents(ctx context.Context, s *types.Set) diag.Diagnostics {
+	var events []eventModel
+	diags := s.ElementsAs(ctx, &events, false)
+	if diags.HasError() {
+		return diags
+	}
+	p := path.Root("event")
+	for _, e := range events {
+		if e.Type.IsNull() || e.Type.IsUnknown() {
+			continue
+		}
+		typ := e.Type.ValueString()
+		rules, ok := pipelineEventRulesByType[typ]
+		if !ok {
+			continue
+		}
+		values := map[string]attr.Value{
+			"refs":       e.Refs,
+			"branches":   e.Branches,
+			"events":     e.Events,
+			"start_date": e.StartDate,
+			"delay":      e.Delay,
+			"cron":       e.Cron,
+			"totp":       e.Totp,
+			"prefix":     e.Prefix,
+			"whitelist":  e.Whitelist,
+			"timezone":   e.Timezone,
+		}
+		diags.Append(validateConfigRules(p, "event", fmt.Sprintf("the `%s` event", typ), values, rules.required, rules.allowed, pipelineEventAttributes)...)
+		if typ == buddy.PipelineEventTypeSchedule {
+			diags.Append(validatePipelineSchedule(p, &e, values)...)
+		}
+	}
+	return diags
+}
+
+func validatePipelineSchedule(p path.Path, e *eventModel, values map[string]attr.Value) diag.Diagnostics {
+	var diags diag.Diagnostics
+	cron := isConfigValueSet(values["cron"])
+	delay := isConfigValueSet(values["delay"])
+	startDate := isConfigValueSet(values["start_date"])
+	// conflicts between cron, delay and start_date are checked by the schema validators
+	if !cron && !delay && !startDate {
+		diags.AddAttributeError(p, "Missing required attribute", "`event.cron` or `event.delay` with `event.start_date` is required by the `SCHEDULE` event")
+	}
+	if cron && !e.Cron.IsUnknown() {
+		if err := ParseCron(e.Cron.ValueString()); err != nil {
+			diags.AddAttributeError(p, "Invalid attribute value", fmt.Sprintf("`event.cron` is not a valid cron expression: %s", err.Error()))
+		}
+	}
+	if delay && !e.Delay.IsUnknown() && e.Delay.ValueInt64() <= 0 {
+		diags.AddAttributeError(p, "Invalid attribute value", "`event.delay` must be greater than 0")
+	}
+	if startDate && !e.StartDate.IsUnknown() {
+		if _, err := time.Parse(time.RFC3339, e.StartDate.ValueString()); err != nil {
+			diags.AddAttributeError(p, "Invalid attribute value", "`event.start_date` must be in the RFC3339 format, e.g. `2016-11-18T12:38:16.000Z`")
+		}
+	}
+	diags.Append(validateTimezone(p, "event.timezone", &e.Timezone)...)
+	return diags
+}
+
+func validatePipelineTriggerConditions(ctx context.Context, s *types.Set) diag.Diagnostics {
+	var conditions []triggerConditionModel
+	diags := s.ElementsAs(ctx, &conditions, false)
+	if diags.HasError() {
+		return diags
+	}
+	p := path.Root("trigger_condition")
+	for _, tc := range conditions {
+		if tc.Condition.IsNull() || tc.Condition.IsUnknown() {
+			continue
+		}
+		condition := tc.Condition.ValueString()
+		required, ok := pipelineTriggerConditionRules[condition]
+		if !ok {
+			continue
+		}
+		values := map[string]attr.Value{
+			"paths":          tc.Paths,
+			"variable_key":   tc.VariableKey,
+			"variable_value": tc.VariableValue,
+			"hours":          tc.Hours,
+			"days":           tc.Days,
+			"timezone":       tc.Timezone,
+			"project_name":   tc.ProjectName,
+			"pipeline_name":  tc.PipelineName,
+			"trigger_user":   tc.TriggerUser,
+			"trigger_group":  tc.TriggerGroup,
+		}
+		var groups [][]string
+		for _, name := range required {
+			groups = append(groups, []string{name})
+		}
+		var allowed []string
+		if condition == buddy.PipelineTriggerConditionDateTime {
+			allowed = []string{"timezone"}
+		}
+		description := fmt.Sprintf("the `%s` condition", condition)
+		diags.Append(validateConfigRules(p, "trigger_condition", description, values, groups, allowed, pipelineTriggerConditionAttributes)...)
+		if condition == buddy.PipelineTriggerConditionDateTime {
+			diags.Append(validateInt64SetRange(p, "trigger_condition.hours", &tc.Hours, 0, 23)...)
+			diags.Append(validateInt64SetRange(p, "trigger_condition.days", &tc.Days, 1, 7)...)
+			diags.Append(validateTimezone(p, "trigger_condition.timezone", &tc.Timezone)...)
+		}
+	}
+	return diags
+}
+
+// validateConfigRules checks that every required group has at least one attribute set and no unsupported attribute is set
+func validateConfigRules(p path.Path, prefix string, description string, values map[string]attr.Value, required [][]string, allowed []string, attributes []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	supported := map[string]bool{}
+	for _, name := range allowed {
+		supported[name] = true
+	}
+	for _, group := range required {
+		found := false
+		for _, name := range group {
+			supported[name] = true
+			found = found || isConfigValueSet(values[name])
+		}
+		if !found {
+			diags.AddAttributeError(p, "Missing required attribute", fmt.Sprintf("`%s.%s` is required by %s", prefix, strings.Join(group, "` or `"+prefix+"."), description))
+		}
+	}
+	for _, name := range attributes {
+		if !supported[name] && isConfigValueSet(values[name]) {
+			diags.AddAttributeError(p, "Invalid attribute combination", fmt.Sprintf("`%s.%s` is not supported by %s", prefix, name, description))
+		}
+	}
+	return diags
+}
+
+// isConfigValueSet treats empty strings and empty sets as not set, unknown values are set
+func isConfigValueSet(v attr.Value) bool {
+	if v == nil || v.IsNull() {
+		return false
+	}
+	if v.IsUnknown() {
+		return true
+	}
+	switch t := v.(type) {
+	case types.String:
+		return t.ValueString() != ""
+	case types.Set:
+		return len(t.Elements()) > 0
+	}
+	return true
+}
+
+func validateInt64SetRange(p path.Path, name string, s *types.Set, min int64, max int64) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if s.IsNull() || s.IsUnknown() {
+		return diags
+	}
+	for _, e := range s.Elements() {
+		v, ok := e.(types.Int64)
+		if !ok || v.IsNull() || v.IsUnknown() {
+			continue
+		}
+		if v.ValueInt64() < min || v.ValueInt64() > max {
+			diags.AddAttributeError(p, "Invalid attribute value", fmt.Sprintf("`%s` values must be between %d and %d, got: %d", name, min, max, v.ValueInt64()))
+		}
+	}
+	return diags
+}
+
+func validateTimezone(p path.Path, name string, s *types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if s.IsNull() || s.IsUnknown() || s.ValueString() == "" {
+		return diags
+	}
+	if _, err := time.LoadLocation(s.ValueString()); err != nil || s.ValueString() == "Local" {
+		diags.AddAttributeError(p, "Invalid attribute value", fmt.Sprintf("`%s` must be a valid IANA time zone, e.g. `Europe/Warsaw`, got: %s", name, s.ValueString()))
+	}
+	return diags
+}
+
+type cronField struct {
+	name  string
+	min   int
+	max   int
+	names []string
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}},
+	{name: "day of week", min: 0, max: 7, names: []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}},
+}
+
+// ParseCron validates standard 5 fields cron expression
+func ParseCron(expr string) error {
+	fields := strings.Fields(expr)
+	if len(fields) != len(cronFields) {
+		return fmt.Errorf("expected %d fields, got %d", len(cronFields), len(fields))
+	}
+	for i, f := range cronFields {
+		if err := f.parse(fields[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *cronField) parse(value string) error {
+	for _, part := range strings.Split(value, ",") {
+		rng := part
+		if idx := strings.Index(part, "/"); idx >= 0 {
+			rng = part[:idx]
+			step, err := strconv.Atoi(part[idx+1:])
+			if err != nil || step <= 0 {
+				return fmt.Errorf("invalid step in %s field: %s", f.name, part)
+			}
+		}
+		if rng == "*" {
+			continue
+		}
+		bounds := strings.SplitN(rng, "-", 2)
+		from, err := f.value(bounds[0])
+		if err != nil {
+			return err
+		}
+		if len(bounds) == 2 {
+			to, err := f.value(bounds[1])
+			if err != nil {
+				return err
+			}
+			if from > to {
+				return fmt.Errorf("invalid range in %s field: %s", f.name, rng)
+			}
+		}
+	}
+	return nil
+}
+
+func (f *cronField) value(s string) (int, error) {
+	if idx := slices.Index(f.names, strings.ToUpper(s)); idx >= 0 {
+		return idx + f.min, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("invalid value in %s field: %s (allowed %d-%d)", f.name, s, f.min, f.max)
+	}
+	return v, nil
+}
@@ -0,0 +1,275 @@
+package util
+
+import (
+	"context"
+	"github.com/buddy/api-go-sdk/buddy"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	sourceschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"slices"
+	"time"
+)
+
+// PipelineExecutionFinishedStatuses are statuses after which the execution won't change anymore
+var PipelineExecutionFinishedStatuses = []string{
+	buddy.ExecutionStatusSuccessful,
+	buddy.ExecutionStatusFailed,
+	buddy.ExecutionStatusTerminated,
+	buddy.ExecutionStatusSkipped,
+	buddy.ExecutionStatusNotExecuted,
+}
+
+func IsPipelineExecutionFinished(status string) bool {
+	return slices.Contains(PipelineExecutionFinishedStatuses, status)
+}
+
+func IsPipelineExecutionFailed(status string) bool {
+	return status == buddy.ExecutionStatusFailed || status == buddy.ExecutionStatusTerminated
+}
+
+type pipelineExecutionVariableModel struct {
+	Key       types.String `tfsdk:"key"`
+	Value     types.String `tfsdk:"value"`
+	Encrypted types.Bool   `tfsdk:"encrypted"`
+}
+
+func ResourcePipelineExecutionVariableModelAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"key": schema.StringAttribute{
+			Required: true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"value": schema.StringAttribute{
+			Required:  true,
+			Sensitive: true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"encrypted": schema.BoolAttribute{
+			Optional: true,
+			PlanModifiers: []planmodifier.Bool{
+				boolplanmodifier.RequiresReplace(),
+			},
+		},
+	}
+}
+
+func PipelineExecutionVariablesModelToApi(ctx context.Context, s *types.Set) (*[]*buddy.Variable, diag.Diagnostics) {
+	var vm []pipelineExecutionVariableModel
+	diags := s.ElementsAs(ctx, &vm, false)
+	variables := make([]*buddy.Variable, len(vm))
+	for i, v := range vm {
+		variable := &buddy.Variable{
+			Type: buddy.VariableTypeVar,
+		}
+		if !v.Key.IsNull() && !v.Key.IsUnknown() {
+			variable.Key = v.Key.ValueString()
+		}
+		if !v.Value.IsNull() && !v.Value.IsUnknown() {
+			variable.Value = v.Value.ValueString()
+		}
+		if !v.Encrypted.IsNull() && !v.Encrypted.IsUnknown() {
+			variable.Encrypted = v.Encrypted.ValueBool()
+		}
+		variables[i] = variable
+	}
+	return &variables, diags
+}
+
+type pipelineExecutionModel struct {
+	ExecutionId  types.Int64  `tfsdk:"execution_id"`
+	HtmlUrl      types.String `tfsdk:"html_url"`
+	Status       types.String `tfsdk:"status"`
+	Comment      types.String `tfsdk:"comment"`
+	Revision     types.String `tfsdk:"revision"`
+	Branch       types.String `tfsdk:"branch"`
+	CreatorEmail types.String `tfsdk:"creator_email"`
+	CreatorName  types.String `tfsdk:"creator_name"`
+	StartDate    types.String `tfsdk:"start_date"`
+	FinishDate   types.String `tfsdk:"finish_date"`
+	Duration     types.Int64  `tfsdk:"duration"`
+	Actions      types.List   `tfsdk:"actions"`
+}
+
+func pipelineExecutionModelAttrs() map[string]attr.Type {
+	return map[string]attr.Type{
+		"execution_id":  types.Int64Type,
+		"html_url":      types.StringType,
+		"status":        types.StringType,
+		"comment":       types.StringType,
+		"revision":      types.StringType,
+		"branch":        types.StringType,
+		"creator_email": types.StringType,
+		"creator_name":  types.StringType,
+		"start_date":    types.StringType,
+		"finish_date":   types.StringType,
+		"duration":      types.Int64Type,
+		"actions":       types.ListType{ElemType: types.ObjectType{AttrTypes: pipelineActionExecutionModelAttrs()}},
+	}
+}
+
+func (e *pipelineExecutionModel) loadAPI(ctx context.Context, execution *buddy.Execution) diag.Diagnostics {
+	e.ExecutionId = types.Int64Value(int64(execution.Id))
+	e.HtmlUrl = types.StringValue(execution.HtmlUrl)
+	e.Status = types.StringValue(execution.Status)
+	e.Comment = types.StringValue(execution.Comment)
+	e.Revision = types.StringValue(PipelineExecutionRevision(execution))
+	e.Branch = types.StringValue(PipelineExecutionBranch(execution))
+	if execution.Creator != nil {
+		e.CreatorEmail = types.StringValue(execution.Creator.Email)
+		e.CreatorName = types.StringValue(execution.Creator.Name)
+	} else {
+		e.CreatorEmail = types.StringValue("")
+		e.CreatorName = types.StringValue("")
+	}
+	e.StartDate = types.StringValue(execution.StartDate)
+	e.FinishDate = types.StringValue(execution.FinishDate)
+	e.Duration = types.Int64Value(PipelineExecutionDuration(execution.StartDate, execution.FinishDate))
+	a := make([]*pipelineActionExecutionModel, len(execution.ActionExecutions))
+	for i, v := range execution.ActionExecutions {
+		a[i] = &pipelineActionExecutionModel{}
+		a[i].loadAPI(v)
+	}
+	actions, d := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: pipelineActionExecutionModelAttrs()}, &a)
+	e.Actions = actions
+	return d
+}
+
+type pipelineActionExecutionModel struct {
+	ActionId   types.Int64  `tfsdk:"action_id"`
+	Name       types.String `tfsdk:"name"`
+	Status     types.String `tfsdk:"status"`
+	StartDate  types.String `tfsdk:"start_date"`
+	FinishDate types.String `tfsdk:"finish_date"`
+}
+
+func pipelineActionExecutionModelAttrs() map[string]attr.Type {
+	return map[string]attr.Type{
+		"action_id":   types.Int64Type,
+		"name":        types.StringType,
+		"status":      types.StringType,
+		"start_date":  types.StringType,
+		"finish_date": types.StringType,
+	}
+}
+
+func (a *pipelineActionExecutionModel) loadAPI(actionExecution *buddy.ActionExecution) {
+	if actionExecution.Action != nil {
+		a.ActionId = types.Int64Value(int64(actionExecution.Action.Id))
+		a.Name = types.StringValue(actionExecution.Action.Name)
+	} else {
+		a.ActionId = types.Int64Value(0)
+		a.Name = types.StringValue("")
+	}
+	a.Status = types.StringValue(actionExecution.Status)
+	a.StartDate = types.StringValue(actionExecution.StartDate)
+	a.FinishDate = types.StringValue(actionExecution.FinishDate)
+}
+
+func SourcePipelineExecutionModelAttributes() map[string]sourceschema.Attribute {
+	return map[string]sourceschema.Attribute{
+		"execution_id": sourceschema.Int64Attribute{
+			Computed: true,
+		},
+		"html_url": sourceschema.StringAttribute{
+			Computed: true,
+		},
+		"status": sourceschema.StringAttribute{
+			Computed: true,
+		},
+		"comment": sourceschema.StringAttribute{
+			Computed: true,
+		},
+		"revision": sourceschema.StringAttribute{
+			Computed: true,
+		},
+		"branch": sourceschema.StringAttribute{
+			Computed: true,
+		},
+		"creator_email": sourceschema.StringAttribute{
+			Computed: true,
+		},
+		"creator_name": sourceschema.StringAttribute{
+			Computed: true,
+		},
+		"start_date": sourceschema.StringAttribute{
+			Computed: true,
+		},
+		"finish_date": sourceschema.StringAttribute{
+			Computed: true,
+		},
+		"duration": sourceschema.Int64Attribute{
+			Computed: true,
+		},
+		"actions": sourceschema.ListNestedAttribute{
+			Computed: true,
+			NestedObject: sourceschema.NestedAttributeObject{
+				Attributes: map[string]sourceschema.Attribute{
+					"action_id": sourceschema.Int64Attribute{
+						Computed: true,
+					},
+					"name": sourceschema.StringAttribute{
+						Computed: true,
+					},
+					"status": sourceschema.StringAttribute{
+						Computed: true,
+					},
+					"start_date": sourceschema.StringAttribute{
+						Computed: true,
+					},
+					"finish_date": sourceschema.StringAttribute{
+						Computed: true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func PipelineExecutionsModelFromApi(ctx context.Context, executions *[]*buddy.Execution) (basetypes.ListValue, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	e := make([]*pipelineExecutionModel, len(*executions))
+	for i, v := range *executions {
+		e[i] = &pipelineExecutionModel{}
+		diags.Append(e[i].loadAPI(ctx, v)...)
+	}
+	r, d := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: pipelineExecutionModelAttrs()}, &e)
+	diags.Append(d...)
+	return r, diags
+}
+
+func PipelineExecutionRevision(execution *buddy.Execution) string {
+	if execution.ToRevision != nil {
+		return execution.ToRevision.Revision
+	}
+	return ""
+}
+
+func PipelineExecutionBranch(execution *buddy.Execution) string {
+	if execution.Branch != nil {
+		return execution.Branch.Name
+	}
+	return ""
+}
+
+// PipelineExecutionDuration returns seconds between start and finish of the run, 0 if the run isn't finished
+func PipelineExecutionDuration(startDate string, finishDate string) int64 {
+	start, err := time.Parse(time.RFC3339, startDate)
+	if err != nil {
+		return 0
+	}
+	finish, err := time.Parse(time.RFC3339, finishDate)
+	if err != nil || finish.Before(start) {
+		return 0
+	}
+	return int64(finish.Sub(start).Seconds())
+}
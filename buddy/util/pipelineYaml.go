@@ -0,0 +1,245 @@
+package util
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"gopkg.in/yaml.v3"
+	"reflect"
+	"strings"
+)
+
+// NormalizeYaml re-encodes YAML with mapping keys sorted and 2 spaces indentation so equal pipelines give equal strings
+func NormalizeYaml(s string) (string, error) {
+	var v interface{}
+	if err := yaml.Unmarshal([]byte(s), &v); err != nil {
+		return "", err
+	}
+	if v == nil {
+		return "", errors.New("yaml is empty")
+	}
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(v); err != nil {
+		return "", err
+	}
+	if err := enc.Close(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// DecodePipelineYaml returns YAML from the API which is base64 encoded
+func DecodePipelineYaml(s string) (string, error) {
+	b, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func EncodePipelineYaml(s string) string {
+	return base64.StdEncoding.EncodeToString([]byte(s))
+}
+
+// YamlContains checks whether every value of want is present in got, so values added by the API don't cause a diff
+func YamlContains(got string, want string) bool {
+	var g, w interface{}
+	if err := yaml.Unmarshal([]byte(got), &g); err != nil {
+		return false
+	}
+	if err := yaml.Unmarshal([]byte(want), &w); err != nil {
+		return false
+	}
+	return yamlValueContains(g, w)
+}
+
+func yamlValueContains(got interface{}, want interface{}) bool {
+	switch w := want.(type) {
+	case map[string]interface{}:
+		g, ok := got.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for k, v := range w {
+			if !yamlValueContains(g[k], v) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		g, ok := got.([]interface{})
+		if !ok || len(g) != len(w) {
+			return false
+		}
+		for i := range w {
+			if !yamlValueContains(g[i], w[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(got, want)
+}
+
+// YamlPipelinesCount returns number of pipelines defined in the YAML
+func YamlPipelinesCount(s string) (int, error) {
+	var v interface{}
+	if err := yaml.Unmarshal([]byte(s), &v); err != nil {
+		return 0, err
+	}
+	switch p := v.(type) {
+	case []interface{}:
+		return len(p), nil
+	case map[string]interface{}:
+		return 1, nil
+	}
+	return 0, errors.New("yaml must be a list of pipelines")
+}
+
+// PipelineYamlList wraps a single pipeline mapping in a list, the API defines and returns pipelines as a list
+func PipelineYamlList(s string) (string, error) {
+	var v interface{}
+	if err := yaml.Unmarshal([]byte(s), &v); err != nil {
+		return "", err
+	}
+	if _, ok := v.(map[string]interface{}); !ok {
+		return s, nil
+	}
+	b, err := yaml.Marshal([]interface{}{v})
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// YamlDuplicateActionName returns the first action name used more than once in the YAML pipelines, empty if names are unique
+func YamlDuplicateActionName(s string) (string, error) {
+	list, err := PipelineYamlList(s)
+	if err != nil {
+		return "", err
+	}
+	var pipelines []map[string]interface{}
+	if err = yaml.Unmarshal([]byte(list), &pipelines); err != nil {
+		return "", err
+	}
+	names := map[string]bool{}
+	for _, p := range pipelines {
+		actions, _ := p["actions"].([]interface{})
+		for _, a := range actions {
+			action, ok := a.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			name := fmt.Sprint(action["action"])
+			if names[name] {
+				return name, nil
+			}
+			names[name] = true
+		}
+	}
+	return "", nil
+}
+
+var _ basetypes.StringTypable = YamlType{}
+
+// YamlType is a string type which values are equal if they define the same YAML
+type YamlType struct {
+	basetypes.StringType
+}
+
+func (t YamlType) Equal(o attr.Type) bool {
+	other, ok := o.(YamlType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t YamlType) String() string {
+	return "util.YamlType"
+}
+
+func (t YamlType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return YamlValue{
+		StringValue: in,
+	}, nil
+}
+
+func (t YamlType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+	stringValuable, diags := t.ValueFromString(ctx, stringValue)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unexpected error converting StringValue to StringValuable: %v", diags)
+	}
+	return stringValuable, nil
+}
+
+func (t YamlType) ValueType(_ context.Context) attr.Value {
+	return YamlValue{}
+}
+
+var _ basetypes.StringValuableWithSemanticEquals = YamlValue{}
+
+type YamlValue struct {
+	basetypes.StringValue
+}
+
+func NewYamlValue(s string) YamlValue {
+	return YamlValue{
+		StringValue: basetypes.NewStringValue(s),
+	}
+}
+
+func (v YamlValue) Equal(o attr.Value) bool {
+	other, ok := o.(YamlValue)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v YamlValue) Type(_ context.Context) attr.Type {
+	return YamlType{}
+}
+
+func (v YamlValue) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	newValue, ok := newValuable.(YamlValue)
+	if !ok {
+		diags.AddError("Semantic Equality Check Error", fmt.Sprintf("Expected value type %T, got: %T", v, newValuable))
+		return false, diags
+	}
+	prior, err := normalizePipelineYaml(v.ValueString())
+	if err != nil {
+		return false, diags
+	}
+	current, err := normalizePipelineYaml(newValue.ValueString())
+	if err != nil {
+		return false, diags
+	}
+	return prior == current, diags
+}
+
+// normalizePipelineYaml normalizes YAML pipelines, a single pipeline mapping equals the list with the same pipeline
+func normalizePipelineYaml(s string) (string, error) {
+	list, err := PipelineYamlList(s)
+	if err != nil {
+		return "", err
+	}
+	return NormalizeYaml(list)
+}
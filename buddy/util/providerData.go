@@ -0,0 +1,64 @@
+package util
+
+import (
+	"context"
+	"github.com/buddy/api-go-sdk/buddy"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type ProviderData struct {
+	Client      *buddy.Client
+	Domain      string
+	DefaultTags []string
+}
+
+func NewDiagnosticMissingDomain(attr path.Path) diag.Diagnostic {
+	return diag.NewAttributeErrorDiagnostic(
+		attr,
+		"Missing workspace domain",
+		"The workspace's URL handle must be set in the resource or in the provider configuration (`domain` attribute or `BUDDY_DOMAIN` environment variable)",
+	)
+}
+
+// ResolveDomain fills not configured domain with the provider default
+func ResolveDomain(attr path.Path, domain *types.String, defaultDomain string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if !domain.IsNull() {
+		return diags
+	}
+	if defaultDomain == "" {
+		diags.Append(NewDiagnosticMissingDomain(attr))
+		return diags
+	}
+	*domain = types.StringValue(defaultDomain)
+	return diags
+}
+
+// ModifyPlanDomain plans the provider default domain when it's not configured in the resource.
+// Change of the default forces replacement as the resource can't be moved between workspaces
+func ModifyPlanDomain(ctx context.Context, attr path.Path, defaultDomain string, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var configDomain types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, attr, &configDomain)...)
+	if resp.Diagnostics.HasError() || !configDomain.IsNull() {
+		return
+	}
+	resp.Diagnostics.Append(ResolveDomain(attr, &configDomain, defaultDomain)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, attr, configDomain)...)
+	if req.State.Raw.IsNull() {
+		return
+	}
+	var stateDomain types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, attr, &stateDomain)...)
+	if !stateDomain.IsNull() && !stateDomain.Equal(configDomain) {
+		resp.RequiresReplace = append(resp.RequiresReplace, attr)
+	}
+}
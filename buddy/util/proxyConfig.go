@@ -0,0 +1,46 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"golang.org/x/net/http/httpproxy"
+	"net/http"
+	"net/url"
+)
+
+type ProxyConfig struct {
+	Url      string
+	NoProxy  string
+	Username string
+	Password string
+}
+
+// NewProxyFunc returns proxy of the provider configuration. Without it the standard HTTP_PROXY env variables are used
+func NewProxyFunc(cfg *ProxyConfig) (func(*http.Request) (*url.URL, error), error) {
+	if cfg == nil || cfg.Url == "" {
+		return http.ProxyFromEnvironment, nil
+	}
+	proxyUrl, err := url.Parse(cfg.Url)
+	if err != nil {
+		// url error contains raw url with credentials
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return nil, fmt.Errorf("invalid proxy url: %w", err)
+	}
+	if proxyUrl.Scheme == "" || proxyUrl.Host == "" {
+		return nil, errors.New("invalid proxy url: scheme and host are required")
+	}
+	if cfg.Username != "" {
+		proxyUrl.User = url.UserPassword(cfg.Username, cfg.Password)
+	}
+	proxyFunc := (&httpproxy.Config{
+		HTTPProxy:  proxyUrl.String(),
+		HTTPSProxy: proxyUrl.String(),
+		NoProxy:    cfg.NoProxy,
+	}).ProxyFunc()
+	return func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}, nil
+}
@@ -0,0 +1,96 @@
+package util
+
+import (
+	"golang.org/x/time/rate"
+	"io"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+)
+
+type rateLimitTransport struct {
+	next    http.RoundTripper
+	limiter *rate.Limiter
+	slots   chan struct{}
+}
+
+// rateLimitSlotHoldTimeout bounds how long an unconsumed response holds a concurrency slot
+const rateLimitSlotHoldTimeout = 30 * time.Second
+
+type releaseBody struct {
+	io.ReadCloser
+	release func()
+}
+
+// Read releases the slot as soon as the body is fully read
+func (b *releaseBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if err != nil {
+		b.release()
+	}
+	return n, err
+}
+
+func (b *releaseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+	return err
+}
+
+func NewRateLimitTransport(next http.RoundTripper, requestsPerSecond float64, maxConcurrentRequests int) http.RoundTripper {
+	t := &rateLimitTransport{
+		next: next,
+	}
+	if requestsPerSecond > 0 {
+		t.limiter = rate.NewLimiter(rate.Limit(requestsPerSecond), int(math.Max(1, math.Ceil(requestsPerSecond))))
+	}
+	if maxConcurrentRequests > 0 {
+		t.slots = make(chan struct{}, maxConcurrentRequests)
+	}
+	return t
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if t.slots != nil {
+		select {
+		case t.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	var once sync.Once
+	release := func() {
+		once.Do(func() {
+			if t.slots != nil {
+				<-t.slots
+			}
+		})
+	}
+	if t.limiter != nil {
+		if err := t.limiter.Wait(ctx); err != nil {
+			release()
+			return nil, err
+		}
+	}
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		release()
+		return resp, err
+	}
+	if t.slots == nil {
+		return resp, nil
+	}
+	// concurrency slot is held until the response is read, closed or the hold timeout passes
+	// so responses dropped without closing can't exhaust the slots
+	timer := time.AfterFunc(rateLimitSlotHoldTimeout, release)
+	resp.Body = &releaseBody{
+		ReadCloser: resp.Body,
+		release: func() {
+			timer.Stop()
+			release()
+		},
+	}
+	return resp, nil
+}
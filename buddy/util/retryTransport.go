@@ -0,0 +1,140 @@
+package util
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	RetryMinWait = 1 * time.Second
+)
+
+type retryTransport struct {
+	next       http.RoundTripper
+	maxRetries int
+	maxWait    time.Duration
+}
+
+func NewRetryTransport(next http.RoundTripper, maxRetries int, maxWait time.Duration) http.RoundTripper {
+	return &retryTransport{
+		next:       next,
+		maxRetries: maxRetries,
+		maxWait:    maxWait,
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		body, err := io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+	}
+	attemptReq := req
+	for attempt := 0; ; attempt++ {
+		resp, err := t.next.RoundTrip(attemptReq)
+		if attempt >= t.maxRetries || !shouldRetry(req, resp, err) {
+			return resp, err
+		}
+		wait := t.backoff(attempt, resp)
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+		attemptReq = req.Clone(req.Context())
+		if req.GetBody != nil {
+			attemptReq.Body, err = req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+func (t *retryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			if wait > t.maxWait {
+				return t.maxWait
+			}
+			return wait
+		}
+	}
+	wait := RetryMinWait << attempt
+	if wait <= 0 || wait > t.maxWait {
+		wait = t.maxWait
+	}
+	// jitter in range [wait/2, wait]
+	half := int64(wait / 2)
+	if half <= 0 {
+		return wait
+	}
+	return time.Duration(half + rand.Int63n(half+1))
+}
+
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+func shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+	if err != nil {
+		// connection was never established so request was not processed
+		var opErr *net.OpError
+		if errors.As(err, &opErr) && opErr.Op == "dial" {
+			return true
+		}
+		return isIdempotentMethod(req.Method)
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	if resp.StatusCode >= http.StatusInternalServerError && resp.StatusCode != http.StatusNotImplemented {
+		return isIdempotentMethod(req.Method)
+	}
+	return false
+}
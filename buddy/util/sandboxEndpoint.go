@@ -6,9 +6,11 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 )
@@ -407,6 +409,73 @@ func ResourceSandboxEndpointHttpModelAttributes() map[string]schema.Attribute {
 	}
 }
 
+func sandboxEndpointWriteOnlyModelAttrs() map[string]attr.Type {
+	return map[string]attr.Type{
+		"password":    types.StringType,
+		"private_key": types.StringType,
+	}
+}
+
+// SandboxEndpointsWriteOnlyNull returns null value of the write-only endpoints map
+func SandboxEndpointsWriteOnlyNull() basetypes.MapValue {
+	return types.MapNull(types.ObjectType{AttrTypes: sandboxEndpointWriteOnlyModelAttrs()})
+}
+
+type sandboxEndpointWriteOnlyModel struct {
+	Password   types.String `tfsdk:"password"`
+	PrivateKey types.String `tfsdk:"private_key"`
+}
+
+func ResourceSandboxEndpointWriteOnlyModelAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"password": schema.StringAttribute{
+			MarkdownDescription: "The endpoint's write-only http password",
+			Optional:            true,
+			Sensitive:           true,
+			WriteOnly:           true,
+		},
+		"private_key": schema.StringAttribute{
+			MarkdownDescription: "The endpoint's write-only tls private key",
+			Optional:            true,
+			Sensitive:           true,
+			WriteOnly:           true,
+		},
+	}
+}
+
+// SandboxEndpointsWriteOnlyToApi sets endpoints secrets provided with write-only attribute
+func SandboxEndpointsWriteOnlyToApi(ctx context.Context, config *tfsdk.Config, attr path.Path, endpoints *[]*buddy.SandboxEndpoint) diag.Diagnostics {
+	var m types.Map
+	diags := config.GetAttribute(ctx, attr, &m)
+	if m.IsNull() || m.IsUnknown() || endpoints == nil {
+		return diags
+	}
+	var e map[string]sandboxEndpointWriteOnlyModel
+	diags.Append(m.ElementsAs(ctx, &e, false)...)
+	for _, endpoint := range *endpoints {
+		if endpoint.Name == nil {
+			continue
+		}
+		v, ok := e[*endpoint.Name]
+		if !ok {
+			continue
+		}
+		if !v.Password.IsNull() && !v.Password.IsUnknown() {
+			if endpoint.Http == nil {
+				endpoint.Http = &buddy.SandboxEndpointHttp{}
+			}
+			endpoint.Http.Password = v.Password.ValueStringPointer()
+		}
+		if !v.PrivateKey.IsNull() && !v.PrivateKey.IsUnknown() {
+			if endpoint.Tls == nil {
+				endpoint.Tls = &buddy.SandboxEndpointTls{}
+			}
+			endpoint.Tls.PrivateKey = v.PrivateKey.ValueStringPointer()
+		}
+	}
+	return diags
+}
+
 func ResourceSandboxEndpointModelAttributes() map[string]schema.Attribute {
 	return map[string]schema.Attribute{
 		"endpoint": schema.StringAttribute{
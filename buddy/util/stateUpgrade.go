@@ -0,0 +1,146 @@
+package util
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// UpgradeRawState returns upgrader which works on the raw JSON state, so it handles all prior shapes of the schema
+// stored under the same version. After the upgrade func is applied, attributes which are not in the current schema
+// (or have a different type) are dropped and missing attributes are set to null - they are refreshed on the next read
+func UpgradeRawState(ctx context.Context, s schema.Schema, upgrade func(state map[string]interface{})) resource.StateUpgrader {
+	return resource.StateUpgrader{
+		StateUpgrader: func(_ context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+			if req.RawState == nil || req.RawState.JSON == nil {
+				resp.Diagnostics.AddError("Unable to upgrade state", "Prior state is not in JSON format")
+				return
+			}
+			var state map[string]interface{}
+			d := json.NewDecoder(bytes.NewReader(req.RawState.JSON))
+			d.UseNumber()
+			if err := d.Decode(&state); err != nil {
+				resp.Diagnostics.AddError("Unable to upgrade state", err.Error())
+				return
+			}
+			upgrade(state)
+			b, err := json.Marshal(pruneRawState(state, s.Type().TerraformType(ctx)))
+			if err != nil {
+				resp.Diagnostics.AddError("Unable to upgrade state", err.Error())
+				return
+			}
+			resp.DynamicValue = &tfprotov6.DynamicValue{
+				JSON: b,
+			}
+		},
+	}
+}
+
+// UpgradeRawStateRename moves value of the attribute to the new name (if the new one is not set)
+func UpgradeRawStateRename(state map[string]interface{}, oldName string, newName string) {
+	v, ok := state[oldName]
+	if !ok {
+		return
+	}
+	delete(state, oldName)
+	if state[newName] == nil {
+		state[newName] = v
+	}
+}
+
+// UpgradeRawStateBoolToAccessLevel replaces boolean flag with the access level string
+func UpgradeRawStateBoolToAccessLevel(state map[string]interface{}, oldName string, newName string, allowed string, denied string) {
+	v, ok := state[oldName]
+	if !ok {
+		return
+	}
+	delete(state, oldName)
+	if state[newName] != nil {
+		return
+	}
+	if b, ok := v.(bool); ok {
+		if b {
+			state[newName] = allowed
+		} else {
+			state[newName] = denied
+		}
+	}
+}
+
+// UpgradeRawStateBlockDefault sets value of the attribute in every element of the nested block if it's not set
+func UpgradeRawStateBlockDefault(state map[string]interface{}, block string, name string, value interface{}) {
+	items, ok := state[block].([]interface{})
+	if !ok {
+		return
+	}
+	for _, item := range items {
+		if m, ok := item.(map[string]interface{}); ok && m[name] == nil {
+			m[name] = value
+		}
+	}
+}
+
+func pruneRawState(v interface{}, t tftypes.Type) interface{} {
+	if v == nil {
+		return nil
+	}
+	switch tt := t.(type) {
+	case tftypes.Object:
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		out := map[string]interface{}{}
+		for name, attrType := range tt.AttributeTypes {
+			out[name] = pruneRawState(m[name], attrType)
+		}
+		return out
+	case tftypes.List:
+		return pruneRawStateElements(v, tt.ElementType)
+	case tftypes.Set:
+		return pruneRawStateElements(v, tt.ElementType)
+	case tftypes.Map:
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		out := map[string]interface{}{}
+		for k, val := range m {
+			out[k] = pruneRawState(val, tt.ElementType)
+		}
+		return out
+	}
+	switch {
+	case t.Is(tftypes.String):
+		if _, ok := v.(string); !ok {
+			return nil
+		}
+	case t.Is(tftypes.Number):
+		switch v.(type) {
+		case json.Number, float64, int, int64:
+		default:
+			return nil
+		}
+	case t.Is(tftypes.Bool):
+		if _, ok := v.(bool); !ok {
+			return nil
+		}
+	}
+	return v
+}
+
+func pruneRawStateElements(v interface{}, t tftypes.Type) interface{} {
+	items, ok := v.([]interface{})
+	if !ok {
+		return nil
+	}
+	out := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		out = append(out, pruneRawState(item, t))
+	}
+	return out
+}
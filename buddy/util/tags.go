@@ -0,0 +1,121 @@
+package util
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"sort"
+)
+
+func MergeTags(tags []string, defaultTags []string) []string {
+	seen := map[string]bool{}
+	merged := []string{}
+	for _, t := range append(append([]string{}, defaultTags...), tags...) {
+		if seen[t] {
+			continue
+		}
+		seen[t] = true
+		merged = append(merged, t)
+	}
+	sort.Strings(merged)
+	return merged
+}
+
+// TagsModelFromApi returns resource's tags (without provider's default tags which were not configured in the resource) and all tags
+func TagsModelFromApi(ctx context.Context, current *types.Set, apiTags []string, defaultTags []string) (basetypes.SetValue, basetypes.SetValue, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	all := MergeTags(apiTags, nil)
+	tagsAll, d := types.SetValueFrom(ctx, types.StringType, &all)
+	diags.Append(d...)
+	if len(defaultTags) == 0 {
+		tags, d := types.SetValueFrom(ctx, types.StringType, &apiTags)
+		diags.Append(d...)
+		return tags, tagsAll, diags
+	}
+	configured := map[string]bool{}
+	var filtered []string
+	if !current.IsNull() && !current.IsUnknown() {
+		var arr []string
+		diags.Append(current.ElementsAs(ctx, &arr, false)...)
+		for _, t := range arr {
+			configured[t] = true
+		}
+		filtered = []string{}
+	}
+	defaults := map[string]bool{}
+	for _, t := range defaultTags {
+		defaults[t] = true
+	}
+	for _, t := range apiTags {
+		if defaults[t] && !configured[t] {
+			continue
+		}
+		filtered = append(filtered, t)
+	}
+	tags, d := types.SetValueFrom(ctx, types.StringType, &filtered)
+	diags.Append(d...)
+	return tags, tagsAll, diags
+}
+
+// TagsToApi returns all tags to send or nil if tags are not managed. Tags are managed when they're configured in the resource,
+// the provider has default tags or the resource had any tags before (state is nil on create), so removing all of them sends an empty list
+func TagsToApi(ctx context.Context, config *tfsdk.Config, state *tfsdk.State, tags *types.Set, tagsAll *types.Set) (*[]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if tagsAll.IsNull() || tagsAll.IsUnknown() {
+		if !tags.IsNull() && !tags.IsUnknown() {
+			return StringSetToApi(ctx, tags)
+		}
+		return nil, diags
+	}
+	all, d := StringSetToApi(ctx, tagsAll)
+	diags.Append(d...)
+	if len(*all) > 0 {
+		return all, diags
+	}
+	var configTags types.Set
+	diags.Append(config.GetAttribute(ctx, path.Root("tags"), &configTags)...)
+	managed := !configTags.IsNull()
+	if !managed && state != nil {
+		var priorTagsAll types.Set
+		diags.Append(state.GetAttribute(ctx, path.Root("tags_all"), &priorTagsAll)...)
+		managed = len(priorTagsAll.Elements()) > 0
+	}
+	if !managed {
+		return nil, diags
+	}
+	return &[]string{}, diags
+}
+
+// ModifyPlanTags plans tags_all as the resource's tags merged with the provider's default tags.
+// It's planned also without any tags so removing default_tags removes them from the resource
+func ModifyPlanTags(ctx context.Context, defaultTags []string, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var configTags types.Set
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("tags"), &configTags)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if configTags.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("tags_all"), types.SetUnknown(types.StringType))...)
+		return
+	}
+	var tags []string
+	if !configTags.IsNull() {
+		resp.Diagnostics.Append(configTags.ElementsAs(ctx, &tags, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	tagsAll, d := types.SetValueFrom(ctx, types.StringType, MergeTags(tags, defaultTags))
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("tags_all"), tagsAll)...)
+}
@@ -5,8 +5,10 @@ import (
 	"github.com/buddy/api-go-sdk/buddy"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -54,6 +56,23 @@ func TargetAuthModelToApi(ctx context.Context, s *types.Set) (*buddy.TargetAuth,
 	return &result, diags
 }
 
+// TargetAuthWriteOnlyToApi sets target auth secrets provided with write-only attributes
+func TargetAuthWriteOnlyToApi(ctx context.Context, config *tfsdk.Config, auth *buddy.TargetAuth) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for attr, field := range map[string]*string{
+		"auth_password_wo":   &auth.Password,
+		"auth_passphrase_wo": &auth.Passphrase,
+		"auth_key_wo":        &auth.Key,
+	} {
+		v, d := WriteOnlyStringToApi(ctx, config, path.Root(attr))
+		diags.Append(d...)
+		if v != nil {
+			*field = *v
+		}
+	}
+	return diags
+}
+
 func TargetAuthModelAttributes() map[string]schema.Attribute {
 	return map[string]schema.Attribute{
 		"method": schema.StringAttribute{
@@ -0,0 +1,261 @@
+package util
+
+import (
+	"context"
+	"fmt"
+	"github.com/buddy/api-go-sdk/buddy"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"slices"
+	"strings"
+)
+
+type targetTypeRules struct {
+	required []string
+	allowed  []string
+	// allowed auth methods, empty method (no auth block or no method) is always allowed
+	methods []string
+}
+
+// attributes which depend on the target's type
+var targetTypeAttributes = []string{
+	"host",
+	"port",
+	"path",
+	"secure",
+	"repository",
+	"integration",
+	"auth",
+	"proxy",
+	"scope",
+}
+
+var targetCloudRules = &targetTypeRules{
+	required: []string{"host", "integration"},
+	allowed:  []string{"port", "path", "auth"},
+	methods:  []string{buddy.TargetAuthMethodPassword, buddy.TargetAuthMethodSshKey, buddy.TargetAuthMethodAssetsKey},
+}
+
+var targetRules = map[string]*targetTypeRules{
+	buddy.TargetTypeFtp: {
+		required: []string{"host"},
+		allowed:  []string{"port", "path", "secure", "auth"},
+		methods:  []string{buddy.TargetAuthMethodPassword},
+	},
+	buddy.TargetTypeSsh: {
+		required: []string{"host", "auth"},
+		allowed:  []string{"port", "path", "proxy"},
+		methods: []string{
+			buddy.TargetAuthMethodPassword,
+			buddy.TargetAuthMethodSshKey,
+			buddy.TargetAuthMethodAssetsKey,
+			buddy.TargetAuthMethodProxyCredentials,
+			buddy.TargetAuthMethodProxyKey,
+		},
+	},
+	buddy.TargetTypeGit: {
+		required: []string{"repository"},
+		allowed:  []string{"path", "auth"},
+		methods:  []string{buddy.TargetAuthMethodHttp, buddy.TargetAuthMethodSshKey},
+	},
+	buddy.TargetTypeMatch: {
+		required: []string{"scope"},
+		allowed:  []string{"path"},
+	},
+	buddy.TargetTypeUpcloud:      targetCloudRules,
+	buddy.TargetTypeVultr:        targetCloudRules,
+	buddy.TargetTypeDigitalOcean: targetCloudRules,
+}
+
+// auth block attributes required and allowed per auth method
+var targetAuthRules = map[string]struct {
+	required [][]string
+	allowed  []string
+}{
+	buddy.TargetAuthMethodPassword: {
+		required: [][]string{{"username"}, {"password"}},
+	},
+	buddy.TargetAuthMethodHttp: {
+		required: [][]string{{"username"}, {"password"}},
+	},
+	buddy.TargetAuthMethodSshKey: {
+		required: [][]string{{"key", "key_path"}},
+		allowed:  []string{"username", "passphrase"},
+	},
+	buddy.TargetAuthMethodAssetsKey: {
+		required: [][]string{{"asset"}},
+		allowed:  []string{"username", "passphrase"},
+	},
+	buddy.TargetAuthMethodProxyCredentials: {
+		allowed: []string{"username"},
+	},
+	buddy.TargetAuthMethodProxyKey: {
+		allowed: []string{"username"},
+	},
+}
+
+var targetAuthAttributes = []string{
+	"username",
+	"password",
+	"asset",
+	"passphrase",
+	"key",
+	"key_path",
+}
+
+// ValidateTargetConfig checks which attributes are required or forbidden by the target's type and auth method
+func ValidateTargetConfig(ctx context.Context, config *tfsdk.Config) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var typ types.String
+	diags.Append(config.GetAttribute(ctx, path.Root("type"), &typ)...)
+	diags.Append(validateWriteOnlyVersion(ctx, config, "auth_wo_version", []string{"auth_key_wo", "auth_passphrase_wo", "auth_password_wo"})...)
+	if diags.HasError() || typ.IsNull() || typ.IsUnknown() {
+		return diags
+	}
+	rules, ok := targetRules[typ.ValueString()]
+	if !ok {
+		return diags
+	}
+	description := fmt.Sprintf("`%s` target", typ.ValueString())
+	values := map[string]attr.Value{}
+	for _, name := range targetTypeAttributes {
+		var v attr.Value
+		var d diag.Diagnostics
+		switch name {
+		case "secure":
+			var b types.Bool
+			d = config.GetAttribute(ctx, path.Root(name), &b)
+			v = b
+		case "auth", "proxy":
+			var s types.Set
+			d = config.GetAttribute(ctx, path.Root(name), &s)
+			if !s.IsUnknown() && len(s.Elements()) == 0 {
+				s = types.SetNull(s.ElementType(ctx))
+			}
+			v = s
+		default:
+			var s types.String
+			d = config.GetAttribute(ctx, path.Root(name), &s)
+			v = s
+		}
+		diags.Append(d...)
+		values[name] = v
+	}
+	if diags.HasError() {
+		return diags
+	}
+	for _, name := range rules.required {
+		if values[name].IsNull() {
+			diags.AddAttributeError(path.Root(name), "Missing required attribute", fmt.Sprintf("`%s` is required by %s", name, description))
+		}
+	}
+	for _, name := range targetTypeAttributes {
+		if !values[name].IsNull() && !slices.Contains(rules.required, name) && !slices.Contains(rules.allowed, name) {
+			diags.AddAttributeError(path.Root(name), "Invalid attribute combination", fmt.Sprintf("`%s` is not supported by %s", name, description))
+		}
+	}
+	if diags.HasError() {
+		return diags
+	}
+	writeOnly := map[string]bool{}
+	for name, wo := range map[string]string{
+		"password":   "auth_password_wo",
+		"passphrase": "auth_passphrase_wo",
+		"key":        "auth_key_wo",
+	} {
+		var v types.String
+		diags.Append(config.GetAttribute(ctx, path.Root(wo), &v)...)
+		writeOnly[name] = !v.IsNull()
+	}
+	var method string
+	if auth, ok := values["auth"].(types.Set); ok && !auth.IsNull() && !auth.IsUnknown() {
+		var d diag.Diagnostics
+		method, d = validateTargetAuth(ctx, path.Root("auth"), "auth", &auth, rules.methods, writeOnly, description)
+		diags.Append(d...)
+	}
+	proxySet := false
+	if proxy, ok := values["proxy"].(types.Set); ok && !proxy.IsNull() {
+		proxySet = true
+		if !proxy.IsUnknown() {
+			diags.Append(validateTargetProxy(ctx, &proxy)...)
+		}
+	}
+	if (method == buddy.TargetAuthMethodProxyCredentials || method == buddy.TargetAuthMethodProxyKey) && !proxySet {
+		diags.AddAttributeError(path.Root("proxy"), "Missing required attribute", fmt.Sprintf("`proxy` is required by the `%s` auth method", method))
+	}
+	return diags
+}
+
+func validateTargetProxy(ctx context.Context, s *types.Set) diag.Diagnostics {
+	var proxies []targetProxyModel
+	diags := s.ElementsAs(ctx, &proxies, false)
+	if diags.HasError() {
+		return diags
+	}
+	for _, proxy := range proxies {
+		if proxy.Host.IsNull() {
+			diags.AddAttributeError(path.Root("proxy"), "Missing required attribute", "`proxy.host` is required")
+		}
+		if proxy.Auth.IsNull() || proxy.Auth.IsUnknown() || len(proxy.Auth.Elements()) == 0 {
+			diags.AddAttributeError(path.Root("proxy"), "Missing required attribute", "`proxy.auth` is required")
+			continue
+		}
+		methods := []string{buddy.TargetAuthMethodPassword, buddy.TargetAuthMethodSshKey, buddy.TargetAuthMethodAssetsKey}
+		_, d := validateTargetAuth(ctx, path.Root("proxy"), "proxy.auth", &proxy.Auth, methods, nil, "target's proxy")
+		diags.Append(d...)
+	}
+	return diags
+}
+
+// validateTargetAuth validates auth block and returns its method
+func validateTargetAuth(ctx context.Context, p path.Path, prefix string, s *types.Set, methods []string, writeOnly map[string]bool, description string) (string, diag.Diagnostics) {
+	var auths []targetAuthModel
+	diags := s.ElementsAs(ctx, &auths, false)
+	if diags.HasError() || len(auths) == 0 {
+		return "", diags
+	}
+	auth := auths[0]
+	if auth.Method.IsNull() || auth.Method.IsUnknown() {
+		return "", diags
+	}
+	method := auth.Method.ValueString()
+	if !slices.Contains(methods, method) {
+		diags.AddAttributeError(p, "Invalid attribute value", fmt.Sprintf("%s supports auth methods: `%s`", description, strings.Join(methods, "`, `")))
+		return method, diags
+	}
+	rules, ok := targetAuthRules[method]
+	if !ok {
+		return method, diags
+	}
+	set := map[string]bool{
+		"username":   !auth.Username.IsNull(),
+		"password":   !auth.Password.IsNull() || writeOnly["password"],
+		"asset":      !auth.Asset.IsNull(),
+		"passphrase": !auth.Passphrase.IsNull() || writeOnly["passphrase"],
+		"key":        !auth.Key.IsNull() || writeOnly["key"],
+		"key_path":   !auth.KeyPath.IsNull(),
+	}
+	allowed := map[string]bool{}
+	for _, name := range rules.allowed {
+		allowed[name] = true
+	}
+	for _, group := range rules.required {
+		found := false
+		for _, name := range group {
+			allowed[name] = true
+			found = found || set[name]
+		}
+		if !found {
+			diags.AddAttributeError(p, "Missing required attribute", fmt.Sprintf("`%s.%s` is required by the `%s` auth method", prefix, strings.Join(group, "` or `"+prefix+"."), method))
+		}
+	}
+	for _, name := range targetAuthAttributes {
+		if set[name] && !allowed[name] {
+			diags.AddAttributeError(p, "Invalid attribute combination", fmt.Sprintf("`%s.%s` is not supported by the `%s` auth method", prefix, name, method))
+		}
+	}
+	return method, diags
+}
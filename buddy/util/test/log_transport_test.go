@@ -0,0 +1,112 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"io"
+	"net/http"
+	"strings"
+	"terraform-provider-buddy/buddy/util"
+	"testing"
+)
+
+const testLogToken = "test-secret-token"
+
+func testLogRoundTrip(t *testing.T, reqBody string, respBody string, header map[string]string) (string, []map[string]interface{}) {
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	next := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		// body must be still readable after logging
+		if req.Body != nil {
+			b, _ := io.ReadAll(req.Body)
+			if err := util.CheckFieldEqual("request body", string(b), reqBody); err != nil {
+				t.Error(err)
+			}
+		}
+		return testResponse(http.StatusOK, respBody, header), nil
+	})
+	transport := util.NewLogTransport(ctx, next, testLogToken)
+	var body io.Reader
+	if reqBody != "" {
+		body = strings.NewReader(reqBody)
+	}
+	req, err := http.NewRequest(http.MethodPost, "https://api.buddy.works/workspaces/ws/projects/proj/variables", body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+testLogToken)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := io.ReadAll(resp.Body)
+	if err := util.CheckFieldEqual("response body", string(b), respBody); err != nil {
+		t.Fatal(err)
+	}
+	raw := output.String()
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return raw, entries
+}
+
+func testLogEntryField(entries []map[string]interface{}, field string) string {
+	for _, e := range entries {
+		if v, ok := e[field]; ok {
+			if s, ok := v.(string); ok {
+				return s
+			}
+		}
+	}
+	return ""
+}
+
+func TestLogTransportRedactHeaders(t *testing.T) {
+	raw, entries := testLogRoundTrip(t, "", "{}", map[string]string{
+		"Set-Cookie":   "session=secret-cookie",
+		"X-Request-Id": "req-1",
+	})
+	if strings.Contains(raw, testLogToken) {
+		t.Fatalf("token found in logs: %s", raw)
+	}
+	if strings.Contains(raw, "secret-cookie") {
+		t.Fatalf("cookie found in logs: %s", raw)
+	}
+	if !strings.Contains(raw, "application/json") {
+		t.Fatalf("not sensitive header not found in logs: %s", raw)
+	}
+	if err := util.CheckFieldEqual("http_request_id", testLogEntryField(entries, "http_request_id"), "req-1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := util.CheckFieldEqual("domain", testLogEntryField(entries, "domain"), "ws"); err != nil {
+		t.Fatal(err)
+	}
+	if err := util.CheckFieldEqual("project_name", testLogEntryField(entries, "project_name"), "proj"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLogTransportRedactBody(t *testing.T) {
+	reqBody := `{"key":"VAR","value":"secret-value","type":"VAR","target":{"auth":{"method":"SSH_KEY","key":"secret-ssh-key","passphrase":"secret-pass"}}}`
+	respBody := `{"id":1,"key":"VAR","value":"secret-value","value_processed":"secret-processed","integrations":[{"secret_key":"secret-integration","password":"secret-password"}]}`
+	raw, entries := testLogRoundTrip(t, reqBody, respBody, nil)
+	for _, secret := range []string{"secret-value", "secret-ssh-key", "secret-pass", "secret-processed", "secret-integration", "secret-password"} {
+		if strings.Contains(raw, secret) {
+			t.Fatalf("%s found in logs: %s", secret, raw)
+		}
+	}
+	// variable key is not a secret outside of target auth
+	if !strings.Contains(testLogEntryField(entries, "http_request_body"), `"key":"VAR"`) {
+		t.Fatalf("variable key not found in logs: %s", raw)
+	}
+}
+
+func TestLogTransportRedactNonJsonBody(t *testing.T) {
+	raw, _ := testLogRoundTrip(t, "value=secret-form-value", "{}", nil)
+	if strings.Contains(raw, "secret-form-value") {
+		t.Fatalf("non json body found in logs: %s", raw)
+	}
+}
@@ -0,0 +1,93 @@
+package test
+
+import (
+	"context"
+	"terraform-provider-buddy/buddy/util"
+	"testing"
+)
+
+const testPipelineYamlMapping = `
+pipeline: "a"
+actions:
+  - action: "build"
+    type: "BUILD"
+`
+
+const testPipelineYamlApi = `
+- pipeline: a
+  on: CLICK
+  actions:
+    - action: build
+      type: BUILD
+      shell: BASH
+`
+
+func TestPipelineYamlList(t *testing.T) {
+	list, err := util.PipelineYamlList(testPipelineYamlMapping)
+	if err != nil {
+		t.Fatal(err)
+	}
+	count, err := util.YamlPipelinesCount(list)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := util.CheckIntFieldEqual("pipelines", count, 1); err != nil {
+		t.Fatal(err)
+	}
+	if !util.YamlContains(testPipelineYamlApi, list) {
+		t.Fatalf("api yaml doesn't contain wrapped mapping: %s", list)
+	}
+	if util.YamlContains(testPipelineYamlApi, testPipelineYamlMapping) {
+		t.Fatal("api yaml list shouldn't contain not wrapped mapping")
+	}
+	same, err := util.PipelineYamlList(testPipelineYamlApi)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := util.CheckFieldEqual("list", same, testPipelineYamlApi); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestYamlDuplicateActionName(t *testing.T) {
+	name, err := util.YamlDuplicateActionName(testPipelineYamlApi)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := util.CheckFieldEqual("unique", name, ""); err != nil {
+		t.Fatal(err)
+	}
+	name, err = util.YamlDuplicateActionName(testPipelineYamlMapping + `  - action: "build"
+    type: "BUILD"
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := util.CheckFieldEqual("duplicate", name, "build"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestYamlValueSemanticEquals(t *testing.T) {
+	mapping := util.NewYamlValue(testPipelineYamlMapping)
+	list := util.NewYamlValue(`
+- pipeline: a
+  actions:
+    - type: BUILD
+      action: build
+`)
+	equal, diags := mapping.StringSemanticEquals(context.Background(), list)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if !equal {
+		t.Fatal("mapping should equal the list with the same pipeline")
+	}
+	equal, diags = mapping.StringSemanticEquals(context.Background(), util.NewYamlValue(testPipelineYamlApi))
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if equal {
+		t.Fatal("mapping shouldn't equal a different pipeline")
+	}
+}
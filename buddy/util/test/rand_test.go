@@ -0,0 +1,37 @@
+package test
+
+import (
+	"math/rand"
+	"terraform-provider-buddy/buddy/util"
+	"testing"
+)
+
+func testRandValues() []string {
+	return []string{
+		util.RandString(10),
+		util.UniqueString(),
+		util.RandEmail(),
+	}
+}
+
+func TestRandSourceSeeded(t *testing.T) {
+	defer util.SetRandSource(nil)
+	var r *rand.Rand
+	util.SetRandSource(func() *rand.Rand {
+		return r
+	})
+	r = rand.New(rand.NewSource(42))
+	first := testRandValues()
+	r = rand.New(rand.NewSource(42))
+	second := testRandValues()
+	for i := range first {
+		if err := util.CheckFieldEqual("value", second[i], first[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// nil source falls back to the default generator
+	r = nil
+	if util.UniqueString() == util.UniqueString() {
+		t.Fatal("expected unique strings from the default generator")
+	}
+}
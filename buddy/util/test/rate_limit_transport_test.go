@@ -0,0 +1,149 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"terraform-provider-buddy/buddy/util"
+	"testing"
+	"time"
+)
+
+func testRateLimitDo(t *testing.T, transport http.RoundTripper, ctx context.Context) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.buddy.works/workspaces", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return transport.RoundTrip(req)
+}
+
+func TestRateLimitTransportMaxConcurrentRequests(t *testing.T) {
+	var inFlight, maxInFlight int32
+	next := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		return testResponse(http.StatusOK, "{}", nil), nil
+	})
+	transport := util.NewRateLimitTransport(next, 0, 2)
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := testRateLimitDo(t, transport, context.Background())
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			_, _ = io.ReadAll(resp.Body)
+			_ = resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+	if maxInFlight > 2 {
+		t.Fatalf("expected at most 2 concurrent requests, got: %d", maxInFlight)
+	}
+}
+
+func TestRateLimitTransportReleaseOnRead(t *testing.T) {
+	next := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return testResponse(http.StatusOK, "{}", nil), nil
+	})
+	transport := util.NewRateLimitTransport(next, 0, 1)
+	resp, err := testRateLimitDo(t, transport, context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// body is read but never closed
+	_, _ = io.ReadAll(resp.Body)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	resp, err = testRateLimitDo(t, transport, ctx)
+	if err != nil {
+		t.Fatalf("slot was not released after reading the body: %s", err)
+	}
+	_ = resp.Body.Close()
+}
+
+func TestRateLimitTransportReleaseOnClose(t *testing.T) {
+	next := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return testResponse(http.StatusOK, "{}", nil), nil
+	})
+	transport := util.NewRateLimitTransport(next, 0, 1)
+	resp, err := testRateLimitDo(t, transport, context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// double close must not release two slots
+	_ = resp.Body.Close()
+	_ = resp.Body.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	resp, err = testRateLimitDo(t, transport, ctx)
+	if err != nil {
+		t.Fatalf("slot was not released after closing the body: %s", err)
+	}
+	_ = resp.Body.Close()
+}
+
+func TestRateLimitTransportReleaseOnError(t *testing.T) {
+	next := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return nil, errors.New("connection refused")
+	})
+	transport := util.NewRateLimitTransport(next, 0, 1)
+	for i := 0; i < 3; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		_, err := testRateLimitDo(t, transport, ctx)
+		cancel()
+		if err == nil || errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected transport error, got: %v", err)
+		}
+	}
+}
+
+func TestRateLimitTransportWaitCanceled(t *testing.T) {
+	next := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return testResponse(http.StatusOK, "{}", nil), nil
+	})
+	transport := util.NewRateLimitTransport(next, 0, 1)
+	resp, err := testRateLimitDo(t, transport, context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = testRateLimitDo(t, transport, ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded while waiting for a slot, got: %v", err)
+	}
+}
+
+func TestRateLimitTransportRequestsPerSecond(t *testing.T) {
+	next := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return testResponse(http.StatusOK, "{}", nil), nil
+	})
+	transport := util.NewRateLimitTransport(next, 10, 0)
+	start := time.Now()
+	// burst of 10 then 5 more requests at 10 per second
+	for i := 0; i < 15; i++ {
+		resp, err := testRateLimitDo(t, transport, context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+	}
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Fatalf("expected requests to be limited, took: %s", elapsed)
+	}
+}
@@ -0,0 +1,226 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"terraform-provider-buddy/buddy/util"
+	"testing"
+	"time"
+)
+
+func testRetryDo(t *testing.T, transport http.RoundTripper, ctx context.Context, method string, body string) (*http.Response, error) {
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, "https://api.buddy.works/workspaces", reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// simulate a body that cannot be rewound by the caller
+	req.GetBody = nil
+	return transport.RoundTrip(req)
+}
+
+func testRetryTransport(statuses []int, header map[string]string, attempts *int, bodies *[]string) http.RoundTripper {
+	return roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if bodies != nil && req.Body != nil {
+			b, _ := io.ReadAll(req.Body)
+			*bodies = append(*bodies, string(b))
+		}
+		status := statuses[len(statuses)-1]
+		if *attempts < len(statuses) {
+			status = statuses[*attempts]
+		}
+		*attempts++
+		return testResponse(status, "{}", header), nil
+	})
+}
+
+func TestRetryTransportTooManyRequests(t *testing.T) {
+	attempts := 0
+	var bodies []string
+	next := testRetryTransport([]int{http.StatusTooManyRequests, http.StatusOK}, map[string]string{"Retry-After": "0"}, &attempts, &bodies)
+	transport := util.NewRetryTransport(next, 3, time.Minute)
+	resp, err := testRetryDo(t, transport, context.Background(), http.MethodPost, `{"name":"test"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := util.CheckIntFieldEqual("StatusCode", resp.StatusCode, http.StatusOK); err != nil {
+		t.Fatal(err)
+	}
+	if err := util.CheckIntFieldEqual("attempts", attempts, 2); err != nil {
+		t.Fatal(err)
+	}
+	for _, b := range bodies {
+		if err := util.CheckFieldEqual("body", b, `{"name":"test"}`); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestRetryTransportServerErrorIdempotent(t *testing.T) {
+	attempts := 0
+	next := testRetryTransport([]int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusOK}, nil, &attempts, nil)
+	transport := util.NewRetryTransport(next, 3, 10*time.Millisecond)
+	resp, err := testRetryDo(t, transport, context.Background(), http.MethodGet, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := util.CheckIntFieldEqual("StatusCode", resp.StatusCode, http.StatusOK); err != nil {
+		t.Fatal(err)
+	}
+	if err := util.CheckIntFieldEqual("attempts", attempts, 3); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRetryTransportServerErrorNotIdempotent(t *testing.T) {
+	attempts := 0
+	next := testRetryTransport([]int{http.StatusBadGateway, http.StatusOK}, nil, &attempts, nil)
+	transport := util.NewRetryTransport(next, 3, 10*time.Millisecond)
+	resp, err := testRetryDo(t, transport, context.Background(), http.MethodPost, "{}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := util.CheckIntFieldEqual("StatusCode", resp.StatusCode, http.StatusBadGateway); err != nil {
+		t.Fatal(err)
+	}
+	if err := util.CheckIntFieldEqual("attempts", attempts, 1); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRetryTransportNotImplemented(t *testing.T) {
+	attempts := 0
+	next := testRetryTransport([]int{http.StatusNotImplemented, http.StatusOK}, nil, &attempts, nil)
+	transport := util.NewRetryTransport(next, 3, 10*time.Millisecond)
+	resp, err := testRetryDo(t, transport, context.Background(), http.MethodGet, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := util.CheckIntFieldEqual("StatusCode", resp.StatusCode, http.StatusNotImplemented); err != nil {
+		t.Fatal(err)
+	}
+	if err := util.CheckIntFieldEqual("attempts", attempts, 1); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRetryTransportMaxRetries(t *testing.T) {
+	attempts := 0
+	next := testRetryTransport([]int{http.StatusTooManyRequests}, map[string]string{"Retry-After": "0"}, &attempts, nil)
+	transport := util.NewRetryTransport(next, 2, time.Minute)
+	resp, err := testRetryDo(t, transport, context.Background(), http.MethodGet, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := util.CheckIntFieldEqual("StatusCode", resp.StatusCode, http.StatusTooManyRequests); err != nil {
+		t.Fatal(err)
+	}
+	if err := util.CheckIntFieldEqual("attempts", attempts, 3); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRetryTransportRetryAfterMaxWait(t *testing.T) {
+	attempts := 0
+	next := testRetryTransport([]int{http.StatusTooManyRequests, http.StatusOK}, map[string]string{"Retry-After": "120"}, &attempts, nil)
+	transport := util.NewRetryTransport(next, 1, 10*time.Millisecond)
+	start := time.Now()
+	resp, err := testRetryDo(t, transport, context.Background(), http.MethodGet, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := util.CheckIntFieldEqual("StatusCode", resp.StatusCode, http.StatusOK); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected Retry-After to be capped by max wait, took: %s", elapsed)
+	}
+}
+
+func TestRetryTransportRetryAfterDate(t *testing.T) {
+	attempts := 0
+	date := time.Now().Add(2 * time.Second).UTC().Format(http.TimeFormat)
+	next := testRetryTransport([]int{http.StatusTooManyRequests, http.StatusOK}, map[string]string{"Retry-After": date}, &attempts, nil)
+	transport := util.NewRetryTransport(next, 1, time.Minute)
+	start := time.Now()
+	_, err := testRetryDo(t, transport, context.Background(), http.MethodGet, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// http date has a second precision
+	if elapsed := time.Since(start); elapsed < time.Second || elapsed > 3*time.Second {
+		t.Fatalf("expected to wait until Retry-After date, took: %s", elapsed)
+	}
+}
+
+func TestRetryTransportBackoff(t *testing.T) {
+	attempts := 0
+	next := testRetryTransport([]int{http.StatusServiceUnavailable, http.StatusOK}, nil, &attempts, nil)
+	transport := util.NewRetryTransport(next, 1, time.Minute)
+	start := time.Now()
+	_, err := testRetryDo(t, transport, context.Background(), http.MethodGet, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// first retry waits between half and full RetryMinWait
+	if elapsed := time.Since(start); elapsed < util.RetryMinWait/2 || elapsed > 2*util.RetryMinWait {
+		t.Fatalf("expected backoff around %s, took: %s", util.RetryMinWait, elapsed)
+	}
+}
+
+func TestRetryTransportDialError(t *testing.T) {
+	attempts := 0
+	next := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		attempts++
+		if attempts == 1 {
+			return nil, &net.OpError{Op: "dial", Err: errors.New("connection refused")}
+		}
+		return testResponse(http.StatusOK, "{}", nil), nil
+	})
+	transport := util.NewRetryTransport(next, 1, 10*time.Millisecond)
+	resp, err := testRetryDo(t, transport, context.Background(), http.MethodPost, "{}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := util.CheckIntFieldEqual("StatusCode", resp.StatusCode, http.StatusOK); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRetryTransportReadErrorNotIdempotent(t *testing.T) {
+	attempts := 0
+	next := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		attempts++
+		return nil, &net.OpError{Op: "read", Err: errors.New("connection reset")}
+	})
+	transport := util.NewRetryTransport(next, 3, 10*time.Millisecond)
+	_, err := testRetryDo(t, transport, context.Background(), http.MethodPost, "{}")
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if err := util.CheckIntFieldEqual("attempts", attempts, 1); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRetryTransportContextCanceled(t *testing.T) {
+	attempts := 0
+	next := testRetryTransport([]int{http.StatusTooManyRequests}, map[string]string{"Retry-After": "3600"}, &attempts, nil)
+	transport := util.NewRetryTransport(next, 3, time.Hour)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := testRetryDo(t, transport, ctx, http.MethodGet, "")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got: %v", err)
+	}
+	if err := util.CheckIntFieldEqual("attempts", attempts, 1); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,57 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"terraform-provider-buddy/buddy/util"
+	"testing"
+)
+
+const testTlsPem = "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----\n"
+
+func TestReadPemOrFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "cert.pem")
+	if err := os.WriteFile(file, []byte(testTlsPem), 0600); err != nil {
+		t.Fatal(err)
+	}
+	fromFile, err := util.ReadPemOrFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := util.CheckFieldEqual("file", fromFile, testTlsPem); err != nil {
+		t.Fatal(err)
+	}
+	fromPem, err := util.ReadPemOrFile(testTlsPem)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := util.CheckFieldEqual("pem", fromPem, testTlsPem); err != nil {
+		t.Fatal(err)
+	}
+	empty, err := util.ReadPemOrFile("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := util.CheckFieldEqual("empty", empty, ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := util.ReadPemOrFile(filepath.Join(t.TempDir(), "missing.pem")); err == nil {
+		t.Fatal("expected error for missing file")
+	}
+}
+
+func TestNewTlsConfig(t *testing.T) {
+	if _, err := util.NewTlsConfig(&util.TlsConfig{ClientCert: testTlsPem}); err == nil {
+		t.Fatal("expected error for client certificate without key")
+	}
+	if _, err := util.NewTlsConfig(&util.TlsConfig{CaCertPem: "not a pem"}); err == nil {
+		t.Fatal("expected error for invalid CA certificate")
+	}
+	cfg, err := util.NewTlsConfig(&util.TlsConfig{Insecure: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := util.CheckBoolFieldEqual("InsecureSkipVerify", cfg.InsecureSkipVerify, true); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,177 @@
+package test
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"terraform-provider-buddy/buddy/util"
+	"testing"
+)
+
+func testTokenSourceEnv(t *testing.T, env map[string]string) {
+	for _, name := range []string{"BUDDY_TOKEN", "BUDDY_TOKEN_FILE", "BUDDY_TOKEN_COMMAND", "BUDDY_OIDC_TOKEN"} {
+		t.Setenv(name, env[name])
+	}
+}
+
+func TestResolveTokenSource(t *testing.T) {
+	tests := []struct {
+		name         string
+		token        types.String
+		tokenFile    types.String
+		tokenCommand types.String
+		oidcToken    types.String
+		env          map[string]string
+		source       string
+		value        string
+		err          string
+	}{
+		{
+			name:         "config token",
+			token:        types.StringValue("abc"),
+			tokenFile:    types.StringNull(),
+			tokenCommand: types.StringNull(),
+			oidcToken:    types.StringNull(),
+			source:       "token",
+			value:        "abc",
+		},
+		{
+			name:         "config takes precedence over env",
+			token:        types.StringNull(),
+			tokenFile:    types.StringNull(),
+			tokenCommand: types.StringValue("echo abc"),
+			oidcToken:    types.StringNull(),
+			env:          map[string]string{"BUDDY_TOKEN": "env"},
+			source:       "token_command",
+			value:        "echo abc",
+		},
+		{
+			name:         "conflicting config",
+			token:        types.StringValue("abc"),
+			tokenFile:    types.StringValue("/tmp/token"),
+			tokenCommand: types.StringNull(),
+			oidcToken:    types.StringNull(),
+			err:          "got both token and token_file",
+		},
+		{
+			name:         "env oidc token",
+			token:        types.StringNull(),
+			tokenFile:    types.StringNull(),
+			tokenCommand: types.StringNull(),
+			oidcToken:    types.StringNull(),
+			env:          map[string]string{"BUDDY_OIDC_TOKEN": "jwt"},
+			source:       "oidc_token",
+			value:        "jwt",
+		},
+		{
+			name:         "conflicting env",
+			token:        types.StringNull(),
+			tokenFile:    types.StringNull(),
+			tokenCommand: types.StringNull(),
+			oidcToken:    types.StringNull(),
+			env:          map[string]string{"BUDDY_TOKEN": "abc", "BUDDY_OIDC_TOKEN": "jwt"},
+			err:          "got both BUDDY_TOKEN and BUDDY_OIDC_TOKEN",
+		},
+		{
+			name:         "no source",
+			token:        types.StringNull(),
+			tokenFile:    types.StringNull(),
+			tokenCommand: types.StringNull(),
+			oidcToken:    types.StringNull(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testTokenSourceEnv(t, tt.env)
+			source, value, diags := util.ResolveTokenSource(tt.token, tt.tokenFile, tt.tokenCommand, tt.oidcToken)
+			if tt.err != "" {
+				if !diags.HasError() || !strings.Contains(diags[0].Detail(), tt.err) {
+					t.Fatalf("expected error %q, got: %v", tt.err, diags)
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if err := util.CheckFieldEqual("source", source, tt.source); err != nil {
+				t.Fatal(err)
+			}
+			if err := util.CheckFieldEqual("value", value, tt.value); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestReadTokenFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(file, []byte("  abc\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	token, err := util.ReadTokenFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := util.CheckFieldEqual("token", token, "abc"); err != nil {
+		t.Fatal(err)
+	}
+	empty := filepath.Join(t.TempDir(), "empty")
+	if err := os.WriteFile(empty, []byte("\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := util.ReadTokenFile(empty); err == nil {
+		t.Fatal("expected error for empty token file")
+	}
+}
+
+func TestExchangeOidcToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/oauth2/token" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if err := r.ParseForm(); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if r.PostForm.Get("grant_type") != "urn:ietf:params:oauth:grant-type:token-exchange" ||
+			r.PostForm.Get("subject_token_type") != "urn:ietf:params:oauth:token-type:jwt" ||
+			r.PostForm.Get("requested_token_type") != "urn:ietf:params:oauth:token-type:access_token" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"unsupported_grant_type"}`))
+			return
+		}
+		switch r.PostForm.Get("subject_token") {
+		case "valid-jwt":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"access_token":"buddy-token","token_type":"Bearer","expires_in":3600}`))
+		case "empty-jwt":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"token_type":"Bearer"}`))
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":"invalid_grant"}`))
+		}
+	}))
+	defer server.Close()
+	ctx := context.Background()
+	token, err := util.ExchangeOidcToken(ctx, server.Client(), server.URL+"/", "valid-jwt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := util.CheckFieldEqual("token", token, "buddy-token"); err != nil {
+		t.Fatal(err)
+	}
+	_, err = util.ExchangeOidcToken(ctx, server.Client(), server.URL, "invalid-jwt")
+	if err == nil || !strings.Contains(err.Error(), "status 401") || !strings.Contains(err.Error(), "invalid_grant") {
+		t.Fatalf("expected unauthorized error, got: %v", err)
+	}
+	_, err = util.ExchangeOidcToken(ctx, server.Client(), server.URL, "empty-jwt")
+	if err == nil || !strings.Contains(err.Error(), "empty access token") {
+		t.Fatalf("expected empty access token error, got: %v", err)
+	}
+}
@@ -0,0 +1,25 @@
+package test
+
+import (
+	"io"
+	"net/http"
+	"strings"
+)
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func testResponse(status int, body string, header map[string]string) *http.Response {
+	resp := &http.Response{
+		StatusCode: status,
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+	for k, v := range header {
+		resp.Header.Set(k, v)
+	}
+	return resp
+}
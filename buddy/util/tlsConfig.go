@@ -0,0 +1,55 @@
+package util
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"os"
+	"strings"
+)
+
+type TlsConfig struct {
+	Insecure   bool
+	CaCertPem  string
+	ClientCert string
+	ClientKey  string
+}
+
+// ReadPemOrFile returns value if it's PEM encoded or content of the file under value path
+func ReadPemOrFile(value string) (string, error) {
+	if value == "" || strings.Contains(value, "-----BEGIN") {
+		return value, nil
+	}
+	b, err := os.ReadFile(value)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func NewTlsConfig(cfg *TlsConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: cfg.Insecure,
+	}
+	if cfg.CaCertPem != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM([]byte(cfg.CaCertPem)) {
+			return nil, errors.New("no valid PEM encoded certificate found in CA certificate")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if cfg.ClientCert != "" || cfg.ClientKey != "" {
+		if cfg.ClientCert == "" || cfg.ClientKey == "" {
+			return nil, errors.New("both client certificate and client key must be provided")
+		}
+		cert, err := tls.X509KeyPair([]byte(cfg.ClientCert), []byte(cfg.ClientKey))
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
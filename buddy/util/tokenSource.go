@@ -0,0 +1,169 @@
+package util
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultBaseUrl          = "https://api.buddy.works"
+	TokenCommandTimeout     = 30 * time.Second
+	oidcTokenExchangeGrant  = "urn:ietf:params:oauth:grant-type:token-exchange"
+	oidcTokenExchangeJwt    = "urn:ietf:params:oauth:token-type:jwt"
+	oidcTokenExchangeAccess = "urn:ietf:params:oauth:token-type:access_token"
+)
+
+type oidcTokenExchangeResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+func ReadTokenFile(path string) (string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	token := strings.TrimSpace(string(b))
+	if token == "" {
+		return "", fmt.Errorf("token file %s is empty", path)
+	}
+	return token, nil
+}
+
+func RunTokenCommand(ctx context.Context, command string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, TokenCommandTimeout)
+	defer cancel()
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%w: %s", err, msg)
+		}
+		return "", err
+	}
+	token := strings.TrimSpace(stdout.String())
+	if token == "" {
+		return "", errors.New("token command returned empty output")
+	}
+	return token, nil
+}
+
+// ExchangeOidcToken trades CI issued JWT for short-lived Buddy token using the token exchange grant
+// (https://www.rfc-editor.org/rfc/rfc8693) of the Buddy OAuth2 token endpoint. The sdk has no wrapper for it
+func ExchangeOidcToken(ctx context.Context, httpClient *http.Client, baseUrl string, idToken string) (string, error) {
+	if baseUrl == "" {
+		baseUrl = DefaultBaseUrl
+	}
+	form := url.Values{}
+	form.Set("grant_type", oidcTokenExchangeGrant)
+	form.Set("subject_token", idToken)
+	form.Set("subject_token_type", oidcTokenExchangeJwt)
+	form.Set("requested_token_type", oidcTokenExchangeAccess)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimRight(baseUrl, "/")+"/oauth2/token", strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token exchange failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	var result oidcTokenExchangeResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", err
+	}
+	if result.AccessToken == "" {
+		return "", errors.New("token exchange returned empty access token")
+	}
+	return result.AccessToken, nil
+}
+
+// ResolveTokenSource returns the only one configured token source. Provider configuration takes precedence over env variables
+func ResolveTokenSource(token types.String, tokenFile types.String, tokenCommand types.String, oidcToken types.String) (string, string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	configSources := []struct {
+		name  string
+		value types.String
+	}{
+		{"token", token},
+		{"token_file", tokenFile},
+		{"token_command", tokenCommand},
+		{"oidc_token", oidcToken},
+	}
+	source, value := "", ""
+	for _, cs := range configSources {
+		if cs.value.IsNull() {
+			continue
+		}
+		if source != "" {
+			diags.AddAttributeError(
+				path.Root(cs.name),
+				"Conflicting Buddy Token sources",
+				fmt.Sprintf("Only one of token, token_file, token_command and oidc_token can be set, got both %s and %s", source, cs.name),
+			)
+			return "", "", diags
+		}
+		source, value = cs.name, cs.value.ValueString()
+	}
+	if source != "" {
+		return source, value, diags
+	}
+	envSources := []struct {
+		name string
+		env  string
+	}{
+		{"token", "BUDDY_TOKEN"},
+		{"token_file", "BUDDY_TOKEN_FILE"},
+		{"token_command", "BUDDY_TOKEN_COMMAND"},
+		{"oidc_token", "BUDDY_OIDC_TOKEN"},
+	}
+	sourceEnv := ""
+	for _, es := range envSources {
+		v := os.Getenv(es.env)
+		if v == "" {
+			continue
+		}
+		if source != "" {
+			diags.AddError(
+				"Conflicting Buddy Token sources",
+				fmt.Sprintf("Only one of BUDDY_TOKEN, BUDDY_TOKEN_FILE, BUDDY_TOKEN_COMMAND and BUDDY_OIDC_TOKEN env variables can be set, got both %s and %s", sourceEnv, es.env),
+			)
+			return "", "", diags
+		}
+		source, value, sourceEnv = es.name, v, es.env
+	}
+	return source, value, diags
+}
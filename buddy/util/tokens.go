@@ -0,0 +1,30 @@
+package util
+
+// TokenScopes lists scopes of the Buddy API personal access token required by the provider's resources
+var TokenScopes = []string{
+	"WORKSPACE",
+	"MANAGE_EMAILS",
+	"USER_INFO",
+	"USER_EMAIL",
+	"USER_KEY",
+	"PROJECT_DELETE",
+	"EXECUTION_INFO",
+	"EXECUTION_RUN",
+	"EXECUTION_MANAGE",
+	"ENVIRONMENT_INFO",
+	"ENVIRONMENT_MANAGE",
+	"INTEGRATION_ADD",
+	"INTEGRATION_INFO",
+	"INTEGRATION_MANAGE",
+	"SANDBOX_INFO",
+	"SANDBOX_MANAGE",
+	"TARGET_INFO",
+	"TARGET_MANAGE",
+	"VARIABLE_ADD",
+	"VARIABLE_INFO",
+	"VARIABLE_MANAGE",
+	"WEBHOOK_ADD",
+	"WEBHOOK_INFO",
+	"WEBHOOK_MANAGE",
+	"ZONE_MANAGE",
+}
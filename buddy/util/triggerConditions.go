@@ -3,8 +3,13 @@ package util
 import (
 	"context"
 	"github.com/buddy/api-go-sdk/buddy"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 )
 
 type triggerConditionModel struct {
@@ -21,6 +26,127 @@ type triggerConditionModel struct {
 	TriggerGroup  types.String `tfsdk:"trigger_group"`
 }
 
+func triggerConditionModelAttrs() map[string]attr.Type {
+	return map[string]attr.Type{
+		"condition":      types.StringType,
+		"paths":          types.SetType{ElemType: types.StringType},
+		"variable_key":   types.StringType,
+		"variable_value": types.StringType,
+		"hours":          types.SetType{ElemType: types.Int64Type},
+		"days":           types.SetType{ElemType: types.Int64Type},
+		"timezone":       types.StringType,
+		"project_name":   types.StringType,
+		"pipeline_name":  types.StringType,
+		"trigger_user":   types.StringType,
+		"trigger_group":  types.StringType,
+	}
+}
+
+// loadAPI leaves empty values null as all attributes except condition are optional
+func (t *triggerConditionModel) loadAPI(ctx context.Context, tc *buddy.PipelineTriggerCondition) diag.Diagnostics {
+	var diags diag.Diagnostics
+	t.Condition = types.StringValue(tc.TriggerCondition)
+	t.VariableKey = stringValueOrNull(tc.TriggerVariableKey)
+	t.VariableValue = stringValueOrNull(tc.TriggerVariableValue)
+	t.Timezone = stringValueOrNull(tc.Timezone)
+	t.ProjectName = stringValueOrNull(tc.TriggerProjectName)
+	t.PipelineName = stringValueOrNull(tc.TriggerPipelineName)
+	t.TriggerUser = stringValueOrNull(tc.TriggerUser)
+	t.TriggerGroup = stringValueOrNull(tc.TriggerGroup)
+	t.Paths = types.SetNull(types.StringType)
+	if len(tc.TriggerConditionPaths) > 0 {
+		paths, d := types.SetValueFrom(ctx, types.StringType, &tc.TriggerConditionPaths)
+		diags.Append(d...)
+		t.Paths = paths
+	}
+	t.Hours = types.SetNull(types.Int64Type)
+	if len(tc.TriggerHours) > 0 {
+		hours, d := types.SetValueFrom(ctx, types.Int64Type, &tc.TriggerHours)
+		diags.Append(d...)
+		t.Hours = hours
+	}
+	t.Days = types.SetNull(types.Int64Type)
+	if len(tc.TriggerDays) > 0 {
+		days, d := types.SetValueFrom(ctx, types.Int64Type, &tc.TriggerDays)
+		diags.Append(d...)
+		t.Days = days
+	}
+	return diags
+}
+
+// TriggerConditionsModelFromApi keeps the current null value when the API returns no conditions
+func TriggerConditionsModelFromApi(ctx context.Context, current *types.Set, conditions []*buddy.PipelineTriggerCondition) (basetypes.SetValue, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if len(conditions) == 0 && current.IsNull() {
+		return types.SetNull(types.ObjectType{AttrTypes: triggerConditionModelAttrs()}), diags
+	}
+	r := make([]*triggerConditionModel, len(conditions))
+	for i, v := range conditions {
+		r[i] = &triggerConditionModel{}
+		diags.Append(r[i].loadAPI(ctx, v)...)
+	}
+	result, d := types.SetValueFrom(ctx, types.ObjectType{AttrTypes: triggerConditionModelAttrs()}, &r)
+	diags.Append(d...)
+	return result, diags
+}
+
+func ResourceTriggerConditionModelAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"condition": schema.StringAttribute{
+			Required: true,
+			Validators: []validator.String{
+				stringvalidator.OneOf(
+					buddy.PipelineTriggerConditionOnChange,
+					buddy.PipelineTriggerConditionOnChangeAtPath,
+					buddy.PipelineTriggerConditionVarIs,
+					buddy.PipelineTriggerConditionVarIsNot,
+					buddy.PipelineTriggerConditionVarContains,
+					buddy.PipelineTriggerConditionVarNotContains,
+					buddy.PipelineTriggerConditionDateTime,
+					buddy.PipelineTriggerConditionSuccessPipeline,
+					buddy.PipelineTriggerConditionTriggeringUserIsNotInGroup,
+					buddy.PipelineTriggerConditionTriggeringUserIsInGroup,
+					buddy.PipelineTriggerConditionTriggeringUserIs,
+					buddy.PipelineTriggerConditionTriggeringUserIsNot,
+				),
+			},
+		},
+		"paths": schema.SetAttribute{
+			ElementType: types.StringType,
+			Optional:    true,
+		},
+		"variable_key": schema.StringAttribute{
+			Optional: true,
+		},
+		"variable_value": schema.StringAttribute{
+			Optional: true,
+		},
+		"hours": schema.SetAttribute{
+			ElementType: types.Int64Type,
+			Optional:    true,
+		},
+		"days": schema.SetAttribute{
+			ElementType: types.Int64Type,
+			Optional:    true,
+		},
+		"timezone": schema.StringAttribute{
+			Optional: true,
+		},
+		"project_name": schema.StringAttribute{
+			Optional: true,
+		},
+		"pipeline_name": schema.StringAttribute{
+			Optional: true,
+		},
+		"trigger_user": schema.StringAttribute{
+			Optional: true,
+		},
+		"trigger_group": schema.StringAttribute{
+			Optional: true,
+		},
+	}
+}
+
 func TriggerConditionsModelToApi(ctx context.Context, s *types.Set) (*[]*buddy.PipelineTriggerCondition, diag.Diagnostics) {
 	var tcm []triggerConditionModel
 	diags := s.ElementsAs(ctx, &tcm, false)
@@ -25,6 +25,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -52,6 +53,14 @@ func NewDiagnosticSandboxTimeout(detail string) diag.Diagnostic {
 	return diag.NewErrorDiagnostic("Timeout waiting for sandbox", detail)
 }
 
+func NewDiagnosticPipelineExecutionTimeout(detail string) diag.Diagnostic {
+	return diag.NewErrorDiagnostic("Timeout waiting for pipeline execution", detail)
+}
+
+func NewDiagnosticPipelineExecutionFailed(status string, htmlUrl string) diag.Diagnostic {
+	return diag.NewErrorDiagnostic("Pipeline execution failed", fmt.Sprintf("Pipeline execution finished with status %s:\n%s", status, htmlUrl))
+}
+
 func CheckFieldEqual(field string, got string, want string) error {
 	if got != want {
 		return ErrorFieldFormatted(field, got, want)
@@ -221,15 +230,47 @@ func DecomposeQuadrupleId(id string) (string, string, string, string, error) {
 	return parts[0], parts[1], parts[2], parts[3], nil
 }
 
+var (
+	randMu     sync.Mutex
+	randSource func() *rand.Rand
+)
+
+// SetRandSource replaces random values generator of RandString, RandInt, RandEmail and UniqueString.
+// Source returning nil falls back to the default generator
+func SetRandSource(source func() *rand.Rand) {
+	randMu.Lock()
+	defer randMu.Unlock()
+	randSource = source
+}
+
+func seededRand() *rand.Rand {
+	if randSource == nil {
+		return nil
+	}
+	return randSource()
+}
+
 func RandStringFromCharSet(strlen int, charSet string) string {
+	randMu.Lock()
+	defer randMu.Unlock()
+	r := seededRand()
 	result := make([]byte, strlen)
 	for i := 0; i < strlen; i++ {
-		result[i] = charSet[rand.Intn(len(charSet))]
+		if r != nil {
+			result[i] = charSet[r.Intn(len(charSet))]
+		} else {
+			result[i] = charSet[rand.Intn(len(charSet))]
+		}
 	}
 	return string(result)
 }
 
 func RandInt() int {
+	randMu.Lock()
+	defer randMu.Unlock()
+	if r := seededRand(); r != nil {
+		return r.Int()
+	}
 	return rand.New(rand.NewSource(time.Now().UnixNano())).Int()
 }
 
@@ -242,7 +283,16 @@ func RandEmail() string {
 }
 
 func UniqueString() string {
-	return fmt.Sprintf("%s%d", RandString(5), time.Now().UnixNano())
+	return fmt.Sprintf("%s%d", RandString(5), uniqueNumber())
+}
+
+func uniqueNumber() int64 {
+	randMu.Lock()
+	defer randMu.Unlock()
+	if r := seededRand(); r != nil {
+		return r.Int63()
+	}
+	return time.Now().UnixNano()
 }
 
 func PointerInt(v int64) *int {
@@ -282,6 +332,21 @@ func TestSleep(ms int) resource.TestCheckFunc {
 	}
 }
 
+// TestImportStateId composes import ID from the resource's attributes (e.g. to import by names)
+func TestImportStateId(n string, attrs ...string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return "", fmt.Errorf("not found: %s", n)
+		}
+		var parts []string
+		for _, attr := range attrs {
+			parts = append(parts, rs.Primary.Attributes[attr])
+		}
+		return strings.Join(parts, ":"), nil
+	}
+}
+
 func IsResourceNotFound(resp *http.Response, err error) bool {
 	if resp.StatusCode == http.StatusNotFound {
 		return true
@@ -300,6 +365,13 @@ func ArrayInt64ToInt(arr *[]int64) *[]int {
 	return &res
 }
 
+func stringValueOrNull(v string) types.String {
+	if v == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(v)
+}
+
 func StringSetToApi(ctx context.Context, s *types.Set) (*[]string, diag.Diagnostics) {
 	var arr []string
 	d := s.ElementsAs(ctx, &arr, false)
@@ -337,3 +409,25 @@ func GetPipelineDefinitionSource(pipeline *buddy.Pipeline) string {
 	}
 	return ds
 }
+
+func NewDiagnosticImportNotFound(resource string, name string) diag.Diagnostic {
+	return diag.NewAttributeErrorDiagnostic(
+		path.Root("id"),
+		"Unable to import",
+		fmt.Sprintf("The %s %q not found", resource, name),
+	)
+}
+
+func NewDiagnosticImportAmbiguous(resource string, name string) diag.Diagnostic {
+	return diag.NewAttributeErrorDiagnostic(
+		path.Root("id"),
+		"Unable to import",
+		fmt.Sprintf("More than one %s matches %q, use the ID instead", resource, name),
+	)
+}
+
+// IsNumericId returns true if part of the resource ID is an API numeric ID (not a name or identifier)
+func IsNumericId(id string) bool {
+	_, err := strconv.Atoi(id)
+	return err == nil
+}
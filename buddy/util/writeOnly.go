@@ -0,0 +1,20 @@
+package util
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// WriteOnlyStringToApi returns value of the write-only attribute. Write-only values are available only in the config,
+// they are always null in the plan and state
+func WriteOnlyStringToApi(ctx context.Context, config *tfsdk.Config, attr path.Path) (*string, diag.Diagnostics) {
+	var v types.String
+	diags := config.GetAttribute(ctx, attr, &v)
+	if v.IsNull() || v.IsUnknown() {
+		return nil, diags
+	}
+	return v.ValueStringPointer(), diags
+}
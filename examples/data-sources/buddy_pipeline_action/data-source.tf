@@ -0,0 +1,13 @@
+data "buddy_pipeline_action" "by_name" {
+  domain       = "mydomain"
+  project_name = "myproject"
+  pipeline_id  = 123456
+  name         = "build"
+}
+
+data "buddy_pipeline_action" "by_id" {
+  domain       = "mydomain"
+  project_name = "myproject"
+  pipeline_id  = 123456
+  action_id    = 654321
+}
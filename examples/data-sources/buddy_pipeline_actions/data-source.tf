@@ -0,0 +1,12 @@
+data "buddy_pipeline_actions" "all" {
+  domain       = "mydomain"
+  project_name = "myproject"
+  pipeline_id  = 123456
+}
+
+data "buddy_pipeline_actions" "with_name_started" {
+  domain       = "mydomain"
+  project_name = "myproject"
+  pipeline_id  = 123456
+  name_regex   = "^build"
+}
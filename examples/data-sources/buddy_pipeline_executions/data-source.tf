@@ -0,0 +1,26 @@
+data "buddy_pipeline_executions" "recent" {
+  domain       = "mydomain"
+  project_name = "myproject"
+  pipeline_id  = 123456
+  limit        = 10
+}
+
+data "buddy_pipeline_executions" "last_deploy" {
+  domain          = "mydomain"
+  project_name    = "myproject"
+  pipeline_id     = 123456
+  branch          = "main"
+  limit           = 1
+  include_actions = true
+}
+
+data "buddy_pipeline_executions" "failed_this_year" {
+  domain         = "mydomain"
+  project_name   = "myproject"
+  pipeline_id    = 123456
+  status         = "FAILED"
+  creator_email  = "mail@example.com"
+  started_after  = "2026-01-01T00:00:00Z"
+  started_before = "2027-01-01T00:00:00Z"
+  limit          = 100
+}
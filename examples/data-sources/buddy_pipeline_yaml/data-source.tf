@@ -0,0 +1,9 @@
+data "buddy_pipeline_yaml" "deploy" {
+  domain       = "mydomain"
+  project_name = "myproject"
+  pipeline_id  = 123456
+}
+
+output "deploy_yaml" {
+  value = data.buddy_pipeline_yaml.deploy.yaml
+}
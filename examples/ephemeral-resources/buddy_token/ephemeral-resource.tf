@@ -0,0 +1,13 @@
+ephemeral "buddy_token" "ci" {
+  name                   = "ci"
+  scopes                 = ["WORKSPACE", "EXECUTION_MANAGE"]
+  expires_in             = 1
+  workspace_restrictions = ["mydomain"]
+}
+
+resource "buddy_variable" "ci_token" {
+  domain           = "mydomain"
+  key              = "BUDDY_TOKEN"
+  value_wo         = ephemeral.buddy_token.ci.token
+  value_wo_version = 1
+}
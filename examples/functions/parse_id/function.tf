@@ -0,0 +1,11 @@
+locals {
+  pipeline = provider::buddy::parse_id("pipeline", buddy_pipeline.test.id)
+}
+
+output "project_name" {
+  value = local.pipeline.project_name
+}
+
+output "pipeline_id" {
+  value = local.pipeline.pipeline_id
+}
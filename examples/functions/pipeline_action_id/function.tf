@@ -0,0 +1,4 @@
+import {
+  to = buddy_pipeline_action.test
+  id = provider::buddy::pipeline_action_id("mydomain", "myproject", 123, 456)
+}
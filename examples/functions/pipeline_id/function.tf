@@ -0,0 +1,4 @@
+import {
+  to = buddy_pipeline.test
+  id = provider::buddy::pipeline_id("mydomain", "myproject", 123)
+}
@@ -1,2 +1,5 @@
 # import using domain(mydomain), member_id(1234)
-terraform import buddy_member.john mydomain:1234
\ No newline at end of file
+terraform import buddy_member.john mydomain:1234
+
+# import using domain(mydomain), member email(john@example.com)
+terraform import buddy_member.john mydomain:john@example.com
\ No newline at end of file
@@ -1,2 +1,5 @@
 # import using domain(mydomain), project name (myproject) and pipeline id (123456)
-terraform import buddy_pipeline.test mydomain:myproject:123456
\ No newline at end of file
+terraform import buddy_pipeline.test mydomain:myproject:123456
+
+# import using domain(mydomain), project name (myproject) and pipeline identifier (my-pipeline)
+terraform import buddy_pipeline.test mydomain:myproject:my-pipeline
\ No newline at end of file
@@ -136,7 +136,7 @@ resource "buddy_pipeline" "conditions" {
   trigger_condition {
     condition = "DATETIME"
     hours     = [10]
-    days      = [1, 20]
+    days      = [1, 5]
     timezone  = "America/Monterrey"
   }
   trigger_condition {
@@ -0,0 +1,34 @@
+resource "buddy_pipeline_action" "build" {
+  domain            = "mydomain"
+  project_name      = "myproject"
+  pipeline_id       = 123456
+  name              = "build"
+  type              = "BUILD"
+  docker_image_name = "library/node"
+  docker_image_tag  = "22"
+  execute_commands  = ["npm ci", "npm run build"]
+
+  variable {
+    key   = "NODE_ENV"
+    value = "production"
+  }
+}
+
+resource "buddy_pipeline_action" "notify" {
+  domain            = "mydomain"
+  project_name      = "myproject"
+  pipeline_id       = 123456
+  after_action_id   = buddy_pipeline_action.build.action_id
+  name              = "notify on failure"
+  type              = "BUILD"
+  docker_image_name = "library/alpine"
+  docker_image_tag  = "latest"
+  execute_commands  = ["echo failed"]
+  trigger_time      = "ON_FAILURE"
+
+  trigger_condition {
+    condition      = "VAR_IS"
+    variable_key   = "NOTIFY"
+    variable_value = "true"
+  }
+}
@@ -0,0 +1,2 @@
+# import using domain(mydomain), project name (myproject), pipeline id (123456) and execution id (654321)
+terraform import buddy_pipeline_execution.deploy mydomain:myproject:123456:654321
\ No newline at end of file
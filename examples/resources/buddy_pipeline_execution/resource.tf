@@ -0,0 +1,28 @@
+resource "buddy_pipeline_execution" "deploy" {
+  domain       = "mydomain"
+  project_name = "myproject"
+  pipeline_id  = 123456
+  branch       = "main"
+  comment      = "Deploy after infrastructure change"
+  clear_cache  = true
+
+  variable {
+    key   = "ENVIRONMENT"
+    value = "production"
+  }
+
+  triggers = {
+    cluster = "cluster-id"
+  }
+
+  wait_for_finish         = true
+  wait_for_finish_timeout = 1800
+}
+
+resource "buddy_pipeline_execution" "smoke_test" {
+  domain          = "mydomain"
+  project_name    = "myproject"
+  pipeline_id     = 654321
+  revision        = "1a2b3c4d5e6f"
+  wait_for_finish = false
+}
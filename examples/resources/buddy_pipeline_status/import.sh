@@ -0,0 +1,2 @@
+# import using domain(mydomain), project name (myproject) and pipeline ids (123456,654321) or tags (tags:deploy,release)
+terraform import buddy_pipeline_status.pause mydomain:myproject:123456,654321
\ No newline at end of file
@@ -0,0 +1,22 @@
+resource "buddy_pipeline" "deploy" {
+  domain                = "mydomain"
+  project_name          = "myproject"
+  name                  = "deploy"
+  tags                  = ["deploy"]
+  ignore_status_changes = true
+}
+
+resource "buddy_pipeline_status" "freeze" {
+  domain           = "mydomain"
+  project_name     = "myproject"
+  tags             = ["deploy"]
+  disabled         = true
+  disabling_reason = "Incident in progress"
+}
+
+resource "buddy_pipeline_status" "pause" {
+  domain       = "mydomain"
+  project_name = "myproject"
+  pipeline_ids = [123456, 654321]
+  paused       = true
+}
@@ -0,0 +1,2 @@
+# import using domain(mydomain), project name (myproject) and pipeline id (123456)
+terraform import buddy_pipeline_yaml.deploy mydomain:myproject:123456
\ No newline at end of file
@@ -0,0 +1,34 @@
+resource "buddy_pipeline_yaml" "deploy" {
+  domain       = "mydomain"
+  project_name = "myproject"
+  yaml = templatefile("${path.module}/deploy.yml", {
+    branch = "main"
+  })
+}
+
+resource "buddy_pipeline_yaml" "test" {
+  domain       = "mydomain"
+  project_name = "myproject"
+  yaml         = <<-EOT
+    - pipeline: "Test"
+      refs:
+        - "refs/heads/main"
+      actions:
+        - action: "Run tests"
+          type: "BUILD"
+          docker_image_name: "library/node"
+          docker_image_tag: "22"
+          execute_commands:
+            - "npm test"
+  EOT
+}
+
+resource "buddy_variable" "token" {
+  domain       = "mydomain"
+  project_name = "myproject"
+  pipeline_id  = buddy_pipeline_yaml.test.pipeline_id
+  action_id    = buddy_pipeline_yaml.test.action_ids["Run tests"]
+  key          = "TOKEN"
+  value        = "secret"
+  encrypted    = true
+}
@@ -1,2 +1,8 @@
 # import using domain(mydomain) and target_id (a1b2c3d4)
-terraform import buddy_target.test mydomain:a1b2c3d4
\ No newline at end of file
+terraform import buddy_target.test mydomain:a1b2c3d4
+
+# import using domain(mydomain) and target identifier (my-target)
+terraform import buddy_target.test mydomain:my-target
+
+# import using domain(mydomain), project name (myproject) and target identifier (my-target)
+terraform import buddy_target.test mydomain:myproject:my-target
\ No newline at end of file
@@ -1,2 +1,8 @@
 # import using domain(mydomain), variable_id(1234)
-terraform import buddy_variable.mysecret mydomain:1234
\ No newline at end of file
+terraform import buddy_variable.mysecret mydomain:1234
+
+# import workspace variable using domain(mydomain) and variable key (MY_KEY)
+terraform import buddy_variable.mysecret mydomain:MY_KEY
+
+# import project variable using domain(mydomain), project name (myproject) and variable key (MY_KEY)
+terraform import buddy_variable.mysecret mydomain:myproject:MY_KEY
\ No newline at end of file
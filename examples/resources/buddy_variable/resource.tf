@@ -20,4 +20,15 @@ resource "buddy_variable" "in_pipeline" {
   key          = "KEY"
   value        = "VAL"
   description  = "variable visibile only in this pipeline"
+}
+
+ephemeral "random_password" "token" {
+  length = 32
+}
+
+resource "buddy_variable" "write_only" {
+  domain           = "mydomain"
+  key              = "API_TOKEN"
+  value_wo         = ephemeral.random_password.token.result
+  value_wo_version = 1
 }
\ No newline at end of file